@@ -76,6 +76,8 @@ func NewEchoRequestLogger(logger *zap.Logger) echo.MiddlewareFunc {
 				zap.Int64("response.response_size", v.ResponseSize),
 				zap.String("request.content_length", v.ContentLength),
 			}
+			// 트레이싱 미들웨어가 시작한 span이 있으면 trace_id, span_id를 함께 기록
+			fields = append(fields, TraceFields(c.Request().Context())...)
 
 			// Header, QueryParam, FormValue 같은 slice 형태 데이터들을 로깅하는 예시
 			if len(v.Headers) > 0 {
@@ -22,6 +22,9 @@ func NewGrpcUnaryServerInterceptor(logger *zap.Logger) grpc.UnaryServerIntercept
 		service := path.Dir(fullMethod)[1:]
 		method := path.Base(fullMethod)
 
+		// 트레이싱 인터셉터가 시작한 span이 있으면 trace_id, span_id를 함께 기록
+		logger := WithTraceContext(ctx, logger)
+
 		// 핸들러 호출 및 응답/에러 캡처
 		resp, err = handler(ctx, req)
 
@@ -80,6 +83,9 @@ func NewGrpcStreamServerInterceptor(logger *zap.Logger) grpc.StreamServerInterce
 		service := path.Dir(fullMethod)[1:]
 		method := path.Base(fullMethod)
 
+		// 트레이싱 인터셉터가 시작한 span이 있으면 trace_id, span_id를 함께 기록
+		logger := WithTraceContext(ss.Context(), logger)
+
 		// 시작 로그
 		logger.Info("gRPC 스트림 시작",
 			zap.String("grpc.service", service),
@@ -0,0 +1,32 @@
+// File: pkg/logger/trace.go
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// TraceFields는 context에 담긴 span의 trace_id, span_id 필드를 반환합니다.
+// 진행 중인 span이 없으면 nil을 반환합니다.
+func TraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
+
+// WithTraceContext는 context에 담긴 span의 trace_id, span_id를 필드로 가진 로거를 반환합니다.
+// 진행 중인 span이 없으면 로거를 그대로 반환합니다.
+func WithTraceContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := TraceFields(ctx)
+	if fields == nil {
+		return logger
+	}
+	return logger.With(fields...)
+}
@@ -0,0 +1,62 @@
+// File: pkg/metrics/echo_metrics.go
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewEchoMiddleware는 HTTP 요청의 RED(요청 수, 에러, 소요 시간) 메트릭을 기록하는 Echo 미들웨어를 생성합니다.
+// 경로 레이블에는 실제 URL 대신 라우트 패턴(/tasks/:id 등)을 사용하여 카디널리티를 제한합니다.
+func NewEchoMiddleware(reg prometheus.Registerer) echo.MiddlewareFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "처리된 HTTP 요청 수",
+	}, []string{"method", "route", "code"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP 요청 처리 시간(초)",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "처리 중인 HTTP 요청 수",
+	})
+	reg.MustRegister(requests, duration, inFlight)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				// 매칭되는 라우트가 없는 요청(404)
+				route = "unmatched"
+			}
+			method := c.Request().Method
+			requests.WithLabelValues(method, route, strconv.Itoa(statusCode(c, err))).Inc()
+			duration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// statusCode는 에러 핸들러가 응답하기 전의 에러까지 고려하여 응답 상태 코드를 결정합니다.
+func statusCode(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return 500
+}
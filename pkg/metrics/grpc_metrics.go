@@ -0,0 +1,69 @@
+// File: pkg/metrics/grpc_metrics.go
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMetrics는 gRPC 서버 인터셉터가 공유하는 메트릭입니다.
+type grpcMetrics struct {
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewGrpcServerInterceptors는 gRPC 요청의 RED 메트릭을 기록하는 단일/스트림 인터셉터를 생성합니다.
+// 스트림의 소요 시간은 스트림이 종료될 때까지의 시간입니다.
+func NewGrpcServerInterceptors(reg prometheus.Registerer) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	m := &grpcMetrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "처리된 gRPC 요청 수",
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "gRPC 요청 처리 시간(초)",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+	}
+	reg.MustRegister(m.handled, m.duration)
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe("unary", info.FullMethod, start, err)
+		return resp, err
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(streamType(info), info.FullMethod, start, err)
+		return err
+	}
+
+	return unary, stream
+}
+
+func (m *grpcMetrics) observe(grpcType, fullMethod string, start time.Time, err error) {
+	service := path.Dir(fullMethod)[1:]
+	method := path.Base(fullMethod)
+	m.handled.WithLabelValues(grpcType, service, method, status.Code(err).String()).Inc()
+	m.duration.WithLabelValues(grpcType, service, method).Observe(time.Since(start).Seconds())
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
@@ -0,0 +1,63 @@
+// File: pkg/metrics/metrics.go
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// NewRegistry는 Go 런타임 및 프로세스 메트릭이 등록된 Prometheus 레지스트리를 생성합니다.
+// 서비스의 모든 메트릭은 이 레지스트리에 등록하고 AdminServer로 노출합니다.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// AdminServer는 서비스 포트와 분리된 관리용 포트에서 /metrics를 제공하는 HTTP 서버입니다.
+type AdminServer struct {
+	server *http.Server
+	mux    *http.ServeMux
+	logger *zap.Logger
+}
+
+// NewAdminServer는 gatherer의 메트릭을 port의 /metrics로 제공하는 AdminServer를 생성합니다.
+func NewAdminServer(port int, gatherer prometheus.Gatherer, logger *zap.Logger) *AdminServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+
+	return &AdminServer{
+		server: &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux},
+		mux:    mux,
+		logger: logger,
+	}
+}
+
+// Handle 관리용 엔드포인트(예: 상태 조회)를 추가합니다. Start 전에 호출해야 합니다.
+func (s *AdminServer) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start 서버를 시작합니다. Shutdown으로 종료된 경우 nil을 반환합니다.
+func (s *AdminServer) Start() error {
+	s.logger.Info("메트릭 서버 시작", zap.String("addr", s.server.Addr))
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown 서버를 안전하게 종료합니다.
+func (s *AdminServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
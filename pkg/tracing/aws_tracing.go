@@ -0,0 +1,55 @@
+// File: pkg/tracing/aws_tracing.go
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentAWS는 AWS SDK 호출(예: ECS RunTask)마다 클라이언트 span을 기록하는 API 옵션입니다.
+// aws.Config.APIOptions 또는 config.WithAPIOptions로 등록합니다. span에는 SDK 재시도 시간이 포함되며,
+// 진행 중인 trace가 없는 호출(예: 상태 폴링)은 기록하지 않습니다.
+func InstrumentAWS(stack *middleware.Stack) error {
+	tracer := otel.Tracer(instrumentationName)
+
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OtelTracing",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if !inTrace(ctx) {
+				return next.HandleInitialize(ctx, in)
+			}
+
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			ctx, span := tracer.Start(ctx, service+"."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.RPCSystemKey.String("aws-api"),
+					semconv.RPCService(service),
+					semconv.RPCMethod(operation),
+				),
+			)
+			defer span.End()
+
+			out, md, err := next.HandleInitialize(ctx, in)
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(md); ok {
+				span.SetAttributes(semconv.AWSRequestID(requestID))
+			}
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					span.SetAttributes(attribute.String("aws.error_code", apiErr.ErrorCode()))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return out, md, err
+		}), middleware.After)
+}
@@ -0,0 +1,98 @@
+// File: pkg/tracing/grpc_tracing.go
+package tracing
+
+import (
+	"context"
+	"path"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier는 gRPC 메타데이터를 trace 컨텍스트 전파용 TextMapCarrier로 사용합니다.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+// startGrpcSpan은 수신 메타데이터의 trace 컨텍스트를 이어받아 서버 span을 시작합니다.
+func startGrpcSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	return otel.Tracer(instrumentationName).Start(ctx, fullMethod[1:],
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(path.Dir(fullMethod)[1:]),
+			semconv.RPCMethod(path.Base(fullMethod)),
+		),
+	)
+}
+
+// endGrpcSpan은 응답 상태 코드를 기록하고 span을 종료합니다.
+func endGrpcSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if code != codes.OK {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+	span.End()
+}
+
+// NewGrpcUnaryServerInterceptor는 단일 요청/응답 gRPC 메서드에 대한 트레이싱 인터셉터를 생성합니다.
+func NewGrpcUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startGrpcSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endGrpcSpan(span, err)
+		return resp, err
+	}
+}
+
+// NewGrpcStreamServerInterceptor는 스트리밍 gRPC 메서드에 대한 트레이싱 인터셉터를 생성합니다.
+// span은 스트림이 종료될 때 끝납니다.
+func NewGrpcStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startGrpcSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		endGrpcSpan(span, err)
+		return err
+	}
+}
+
+// tracedStream은 span이 담긴 context를 스트림 핸들러에 전달합니다.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
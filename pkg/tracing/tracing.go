@@ -0,0 +1,91 @@
+// File: pkg/tracing/tracing.go
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName은 이 패키지가 만드는 span의 계측 라이브러리 이름입니다.
+const instrumentationName = "github.com/SKD-fastcampus/bot-management/pkg/tracing"
+
+// Config는 트레이싱 설정입니다.
+type Config struct {
+	// ServiceName span에 기록할 서비스 이름 (service.name)
+	ServiceName string
+	// Exporter span 내보내기 방식 (otlp, stdout). 비어 있으면 트레이싱을 사용하지 않습니다.
+	Exporter string
+	// Endpoint OTLP gRPC 수집기 주소 (예: otel-collector:4317). 비어 있으면 OTEL_EXPORTER_OTLP_* 환경 변수를 따릅니다.
+	Endpoint string
+	// Insecure OTLP 수집기와 TLS 없이 통신할지 여부
+	Insecure bool
+	// SampleRatio 새 trace를 샘플링할 비율 (0~1). 0이면 모두 샘플링합니다.
+	// 상위 span이 있는 요청은 상위 span의 샘플링 결정을 따릅니다.
+	SampleRatio float64
+}
+
+// Setup은 설정에 맞는 TracerProvider를 전역으로 등록하고, 종료 시 남은 span을 내보내는 함수를 반환합니다.
+// Exporter가 비어 있으면 아무것도 기록하지 않는 전역 TracerProvider를 그대로 둡니다.
+// 어느 경우든 W3C Trace Context 전파기는 등록되므로 상위 서비스의 trace 컨텍스트는 그대로 전달됩니다.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("지원하지 않는 트레이싱 exporter: %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("트레이싱 exporter 생성 실패: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("트레이싱 리소스 생성 실패: %w", err)
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if cfg.SampleRatio > 0 && cfg.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// inTrace는 ctx가 진행 중인 trace에 속해 있는지 반환합니다.
+// DB 쿼리와 AWS 호출은 trace에 속한 경우에만 기록하여, 백그라운드 워커의 주기적인 조회가
+// 각각 별도의 trace로 쌓이지 않도록 합니다.
+func inTrace(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
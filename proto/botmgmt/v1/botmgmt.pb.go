@@ -0,0 +1,1497 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/botmgmt/v1/botmgmt.proto
+
+package botmgmtv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_PENDING     TaskStatus = 1
+	TaskStatus_TASK_STATUS_DISPATCHING TaskStatus = 2
+	TaskStatus_TASK_STATUS_RUNNING     TaskStatus = 3
+	TaskStatus_TASK_STATUS_COMPLETED   TaskStatus = 4
+	TaskStatus_TASK_STATUS_FAILED      TaskStatus = 5
+	TaskStatus_TASK_STATUS_TIMED_OUT   TaskStatus = 6
+	TaskStatus_TASK_STATUS_CANCELLED   TaskStatus = 7
+	TaskStatus_TASK_STATUS_DEAD_LETTER TaskStatus = 8
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_PENDING",
+		2: "TASK_STATUS_DISPATCHING",
+		3: "TASK_STATUS_RUNNING",
+		4: "TASK_STATUS_COMPLETED",
+		5: "TASK_STATUS_FAILED",
+		6: "TASK_STATUS_TIMED_OUT",
+		7: "TASK_STATUS_CANCELLED",
+		8: "TASK_STATUS_DEAD_LETTER",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_PENDING":     1,
+		"TASK_STATUS_DISPATCHING": 2,
+		"TASK_STATUS_RUNNING":     3,
+		"TASK_STATUS_COMPLETED":   4,
+		"TASK_STATUS_FAILED":      5,
+		"TASK_STATUS_TIMED_OUT":   6,
+		"TASK_STATUS_CANCELLED":   7,
+		"TASK_STATUS_DEAD_LETTER": 8,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_botmgmt_v1_botmgmt_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_proto_botmgmt_v1_botmgmt_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{0}
+}
+
+type Verdict int32
+
+const (
+	Verdict_VERDICT_UNSPECIFIED Verdict = 0
+	Verdict_VERDICT_PHISHING    Verdict = 1
+	Verdict_VERDICT_SUSPICIOUS  Verdict = 2
+	Verdict_VERDICT_BENIGN      Verdict = 3
+	Verdict_VERDICT_UNKNOWN     Verdict = 4 // The bot could not reach a conclusion
+)
+
+// Enum value maps for Verdict.
+var (
+	Verdict_name = map[int32]string{
+		0: "VERDICT_UNSPECIFIED",
+		1: "VERDICT_PHISHING",
+		2: "VERDICT_SUSPICIOUS",
+		3: "VERDICT_BENIGN",
+		4: "VERDICT_UNKNOWN",
+	}
+	Verdict_value = map[string]int32{
+		"VERDICT_UNSPECIFIED": 0,
+		"VERDICT_PHISHING":    1,
+		"VERDICT_SUSPICIOUS":  2,
+		"VERDICT_BENIGN":      3,
+		"VERDICT_UNKNOWN":     4,
+	}
+)
+
+func (x Verdict) Enum() *Verdict {
+	p := new(Verdict)
+	*p = x
+	return p
+}
+
+func (x Verdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Verdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_botmgmt_v1_botmgmt_proto_enumTypes[1].Descriptor()
+}
+
+func (Verdict) Type() protoreflect.EnumType {
+	return &file_proto_botmgmt_v1_botmgmt_proto_enumTypes[1]
+}
+
+func (x Verdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Verdict.Descriptor instead.
+func (Verdict) EnumDescriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskSortField int32
+
+const (
+	TaskSortField_TASK_SORT_FIELD_UNSPECIFIED TaskSortField = 0 // created_at
+	TaskSortField_TASK_SORT_FIELD_CREATED_AT  TaskSortField = 1
+	TaskSortField_TASK_SORT_FIELD_UPDATED_AT  TaskSortField = 2
+	TaskSortField_TASK_SORT_FIELD_RETRY_COUNT TaskSortField = 3
+)
+
+// Enum value maps for TaskSortField.
+var (
+	TaskSortField_name = map[int32]string{
+		0: "TASK_SORT_FIELD_UNSPECIFIED",
+		1: "TASK_SORT_FIELD_CREATED_AT",
+		2: "TASK_SORT_FIELD_UPDATED_AT",
+		3: "TASK_SORT_FIELD_RETRY_COUNT",
+	}
+	TaskSortField_value = map[string]int32{
+		"TASK_SORT_FIELD_UNSPECIFIED": 0,
+		"TASK_SORT_FIELD_CREATED_AT":  1,
+		"TASK_SORT_FIELD_UPDATED_AT":  2,
+		"TASK_SORT_FIELD_RETRY_COUNT": 3,
+	}
+)
+
+func (x TaskSortField) Enum() *TaskSortField {
+	p := new(TaskSortField)
+	*p = x
+	return p
+}
+
+func (x TaskSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_botmgmt_v1_botmgmt_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskSortField) Type() protoreflect.EnumType {
+	return &file_proto_botmgmt_v1_botmgmt_proto_enumTypes[2]
+}
+
+func (x TaskSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskSortField.Descriptor instead.
+func (TaskSortField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{2}
+}
+
+type Task struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestUuid      string                 `protobuf:"bytes,2,opt,name=request_uuid,json=requestUuid,proto3" json:"request_uuid,omitempty"`
+	AnalysisId       string                 `protobuf:"bytes,3,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	Url              string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	CanonicalUrl     string                 `protobuf:"bytes,5,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	RegisteredDomain string                 `protobuf:"bytes,6,opt,name=registered_domain,json=registeredDomain,proto3" json:"registered_domain,omitempty"`
+	Status           TaskStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=semo.botmgmt.v1.TaskStatus" json:"status,omitempty"`
+	FromCache        bool                   `protobuf:"varint,8,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"` // Result reused from a recent analysis of the same URL
+	CallbackUrl      string                 `protobuf:"bytes,9,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	RetryCount       int32                  `protobuf:"varint,10,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	NextAttemptAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	Result           string                 `protobuf:"bytes,12,opt,name=result,proto3" json:"result,omitempty"`                                       // Free-form result or failure reason
+	AnalysisResult   *AnalysisResult        `protobuf:"bytes,13,opt,name=analysis_result,json=analysisResult,proto3" json:"analysis_result,omitempty"` // Set for COMPLETED tasks with a structured result
+	StartedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetRequestUuid() string {
+	if x != nil {
+		return x.RequestUuid
+	}
+	return ""
+}
+
+func (x *Task) GetAnalysisId() string {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return ""
+}
+
+func (x *Task) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Task) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *Task) GetRegisteredDomain() string {
+	if x != nil {
+		return x.RegisteredDomain
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+func (x *Task) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *Task) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *Task) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *Task) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *Task) GetAnalysisResult() *AnalysisResult {
+	if x != nil {
+		return x.AnalysisResult
+	}
+	return nil
+}
+
+func (x *Task) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type AnalysisResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Verdict       Verdict                `protobuf:"varint,2,opt,name=verdict,proto3,enum=semo.botmgmt.v1.Verdict" json:"verdict,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0.0 - 1.0
+	FinalUrl      string                 `protobuf:"bytes,4,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	FinalDomain   string                 `protobuf:"bytes,5,opt,name=final_domain,json=finalDomain,proto3" json:"final_domain,omitempty"`
+	RedirectChain []string               `protobuf:"bytes,6,rep,name=redirect_chain,json=redirectChain,proto3" json:"redirect_chain,omitempty"`
+	PageTitle     string                 `protobuf:"bytes,7,opt,name=page_title,json=pageTitle,proto3" json:"page_title,omitempty"`
+	DetectedBrand string                 `protobuf:"bytes,8,opt,name=detected_brand,json=detectedBrand,proto3" json:"detected_brand,omitempty"`
+	Artifacts     []*Artifact            `protobuf:"bytes,9,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Indicators    []*Indicator           `protobuf:"bytes,10,rep,name=indicators,proto3" json:"indicators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalysisResult) Reset() {
+	*x = AnalysisResult{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisResult) ProtoMessage() {}
+
+func (x *AnalysisResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisResult.ProtoReflect.Descriptor instead.
+func (*AnalysisResult) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AnalysisResult) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetVerdict() Verdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return Verdict_VERDICT_UNSPECIFIED
+}
+
+func (x *AnalysisResult) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetFinalDomain() string {
+	if x != nil {
+		return x.FinalDomain
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetRedirectChain() []string {
+	if x != nil {
+		return x.RedirectChain
+	}
+	return nil
+}
+
+func (x *AnalysisResult) GetPageTitle() string {
+	if x != nil {
+		return x.PageTitle
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetDetectedBrand() string {
+	if x != nil {
+		return x.DetectedBrand
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *AnalysisResult) GetIndicators() []*Indicator {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+type Artifact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "screenshot" or "har"
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Artifact) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Artifact) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type Indicator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Indicator) Reset() {
+	*x = Indicator{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Indicator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Indicator) ProtoMessage() {}
+
+func (x *Indicator) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Indicator.ProtoReflect.Descriptor instead.
+func (*Indicator) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Indicator) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Indicator) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Indicator) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// TaskTransition is a status change of a task
+type TaskTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    TaskStatus             `protobuf:"varint,1,opt,name=from_status,json=fromStatus,proto3,enum=semo.botmgmt.v1.TaskStatus" json:"from_status,omitempty"`
+	ToStatus      TaskStatus             `protobuf:"varint,2,opt,name=to_status,json=toStatus,proto3,enum=semo.botmgmt.v1.TaskStatus" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTransition) Reset() {
+	*x = TaskTransition{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTransition) ProtoMessage() {}
+
+func (x *TaskTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTransition.ProtoReflect.Descriptor instead.
+func (*TaskTransition) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TaskTransition) GetFromStatus() TaskStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *TaskTransition) GetToStatus() TaskStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *TaskTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TaskTransition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TaskTransition) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Url         string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	AnalysisId  string                 `protobuf:"bytes,2,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	RequestUuid string                 `protobuf:"bytes,3,opt,name=request_uuid,json=requestUuid,proto3" json:"request_uuid,omitempty"`
+	// Oldest cached result in seconds this request accepts; unset uses the server default, 0 disables the cache
+	MaxAgeSeconds *int32 `protobuf:"varint,4,opt,name=max_age_seconds,json=maxAgeSeconds,proto3,oneof" json:"max_age_seconds,omitempty"`
+	// Launch a new analysis even if a cached result exists
+	ForceRefresh bool `protobuf:"varint,5,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"`
+	// Receives a signed POST with the outcome once the task finishes; the host must be allowlisted
+	CallbackUrl   string `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTaskRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetAnalysisId() string {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetRequestUuid() string {
+	if x != nil {
+		return x.RequestUuid
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetMaxAgeSeconds() int32 {
+	if x != nil && x.MaxAgeSeconds != nil {
+		return *x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetForceRefresh() bool {
+	if x != nil {
+		return x.ForceRefresh
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []TaskStatus           `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=semo.botmgmt.v1.TaskStatus" json:"statuses,omitempty"`
+	UrlContains   string                 `protobuf:"bytes,2,opt,name=url_contains,json=urlContains,proto3" json:"url_contains,omitempty"` // Case-insensitive URL substring
+	AnalysisId    string                 `protobuf:"bytes,3,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // Inclusive
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // Exclusive
+	MinRetryCount *int32                 `protobuf:"varint,6,opt,name=min_retry_count,json=minRetryCount,proto3,oneof" json:"min_retry_count,omitempty"`
+	MaxRetryCount *int32                 `protobuf:"varint,7,opt,name=max_retry_count,json=maxRetryCount,proto3,oneof" json:"max_retry_count,omitempty"`
+	SortBy        TaskSortField          `protobuf:"varint,8,opt,name=sort_by,json=sortBy,proto3,enum=semo.botmgmt.v1.TaskSortField" json:"sort_by,omitempty"`
+	Ascending     bool                   `protobuf:"varint,9,opt,name=ascending,proto3" json:"ascending,omitempty"`                  // Descending by default
+	PageSize      int32                  `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Default 50, max 200
+	PageToken     string                 `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTasksRequest) GetStatuses() []TaskStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetUrlContains() string {
+	if x != nil {
+		return x.UrlContains
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetAnalysisId() string {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetMinRetryCount() int32 {
+	if x != nil && x.MinRetryCount != nil {
+		return *x.MinRetryCount
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetMaxRetryCount() int32 {
+	if x != nil && x.MaxRetryCount != nil {
+		return *x.MaxRetryCount
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetSortBy() TaskSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return TaskSortField_TASK_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListTasksRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelTaskRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskResponse) Reset() {
+	*x = CancelTaskResponse{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskResponse) ProtoMessage() {}
+
+func (x *CancelTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskResponse.ProtoReflect.Descriptor instead.
+func (*CancelTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type WatchTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTaskRequest) Reset() {
+	*x = WatchTaskRequest{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTaskRequest) ProtoMessage() {}
+
+func (x *WatchTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTaskRequest.ProtoReflect.Descriptor instead.
+func (*WatchTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// The transition that produced this state; unset for the initial state and for
+	// changes that are not transitions (e.g. a result attached later)
+	Transition    *TaskTransition `protobuf:"bytes,2,opt,name=transition,proto3" json:"transition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTaskResponse) Reset() {
+	*x = WatchTaskResponse{}
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTaskResponse) ProtoMessage() {}
+
+func (x *WatchTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_botmgmt_v1_botmgmt_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTaskResponse.ProtoReflect.Descriptor instead.
+func (*WatchTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *WatchTaskResponse) GetTransition() *TaskTransition {
+	if x != nil {
+		return x.Transition
+	}
+	return nil
+}
+
+var File_proto_botmgmt_v1_botmgmt_proto protoreflect.FileDescriptor
+
+const file_proto_botmgmt_v1_botmgmt_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/botmgmt/v1/botmgmt.proto\x12\x0fsemo.botmgmt.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x05\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\frequest_uuid\x18\x02 \x01(\tR\vrequestUuid\x12\x1f\n" +
+	"\vanalysis_id\x18\x03 \x01(\tR\n" +
+	"analysisId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12#\n" +
+	"\rcanonical_url\x18\x05 \x01(\tR\fcanonicalUrl\x12+\n" +
+	"\x11registered_domain\x18\x06 \x01(\tR\x10registeredDomain\x123\n" +
+	"\x06status\x18\a \x01(\x0e2\x1b.semo.botmgmt.v1.TaskStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\b \x01(\bR\tfromCache\x12!\n" +
+	"\fcallback_url\x18\t \x01(\tR\vcallbackUrl\x12\x1f\n" +
+	"\vretry_count\x18\n" +
+	" \x01(\x05R\n" +
+	"retryCount\x12B\n" +
+	"\x0fnext_attempt_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12\x16\n" +
+	"\x06result\x18\f \x01(\tR\x06result\x12H\n" +
+	"\x0fanalysis_result\x18\r \x01(\v2\x1f.semo.botmgmt.v1.AnalysisResultR\x0eanalysisResult\x129\n" +
+	"\n" +
+	"started_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xad\x03\n" +
+	"\x0eAnalysisResult\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x122\n" +
+	"\averdict\x18\x02 \x01(\x0e2\x18.semo.botmgmt.v1.VerdictR\averdict\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12\x1b\n" +
+	"\tfinal_url\x18\x04 \x01(\tR\bfinalUrl\x12!\n" +
+	"\ffinal_domain\x18\x05 \x01(\tR\vfinalDomain\x12%\n" +
+	"\x0eredirect_chain\x18\x06 \x03(\tR\rredirectChain\x12\x1d\n" +
+	"\n" +
+	"page_title\x18\a \x01(\tR\tpageTitle\x12%\n" +
+	"\x0edetected_brand\x18\b \x01(\tR\rdetectedBrand\x127\n" +
+	"\tartifacts\x18\t \x03(\v2\x19.semo.botmgmt.v1.ArtifactR\tartifacts\x12:\n" +
+	"\n" +
+	"indicators\x18\n" +
+	" \x03(\v2\x1a.semo.botmgmt.v1.IndicatorR\n" +
+	"indicators\"0\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"W\n" +
+	"\tIndicator\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xf1\x01\n" +
+	"\x0eTaskTransition\x12<\n" +
+	"\vfrom_status\x18\x01 \x01(\x0e2\x1b.semo.botmgmt.v1.TaskStatusR\n" +
+	"fromStatus\x128\n" +
+	"\tto_status\x18\x02 \x01(\x0e2\x1b.semo.botmgmt.v1.TaskStatusR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf2\x01\n" +
+	"\x11CreateTaskRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vanalysis_id\x18\x02 \x01(\tR\n" +
+	"analysisId\x12!\n" +
+	"\frequest_uuid\x18\x03 \x01(\tR\vrequestUuid\x12+\n" +
+	"\x0fmax_age_seconds\x18\x04 \x01(\x05H\x00R\rmaxAgeSeconds\x88\x01\x01\x12#\n" +
+	"\rforce_refresh\x18\x05 \x01(\bR\fforceRefresh\x12!\n" +
+	"\fcallback_url\x18\x06 \x01(\tR\vcallbackUrlB\x12\n" +
+	"\x10_max_age_seconds\"?\n" +
+	"\x12CreateTaskResponse\x12)\n" +
+	"\x04task\x18\x01 \x01(\v2\x15.semo.botmgmt.v1.TaskR\x04task\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x0fGetTaskResponse\x12)\n" +
+	"\x04task\x18\x01 \x01(\v2\x15.semo.botmgmt.v1.TaskR\x04task\"\x9e\x04\n" +
+	"\x10ListTasksRequest\x127\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x1b.semo.botmgmt.v1.TaskStatusR\bstatuses\x12!\n" +
+	"\furl_contains\x18\x02 \x01(\tR\vurlContains\x12\x1f\n" +
+	"\vanalysis_id\x18\x03 \x01(\tR\n" +
+	"analysisId\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12+\n" +
+	"\x0fmin_retry_count\x18\x06 \x01(\x05H\x00R\rminRetryCount\x88\x01\x01\x12+\n" +
+	"\x0fmax_retry_count\x18\a \x01(\x05H\x01R\rmaxRetryCount\x88\x01\x01\x127\n" +
+	"\asort_by\x18\b \x01(\x0e2\x1e.semo.botmgmt.v1.TaskSortFieldR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\t \x01(\bR\tascending\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageTokenB\x12\n" +
+	"\x10_min_retry_countB\x12\n" +
+	"\x10_max_retry_count\"h\n" +
+	"\x11ListTasksResponse\x12+\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x15.semo.botmgmt.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\";\n" +
+	"\x11CancelTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"?\n" +
+	"\x12CancelTaskResponse\x12)\n" +
+	"\x04task\x18\x01 \x01(\v2\x15.semo.botmgmt.v1.TaskR\x04task\"\"\n" +
+	"\x10WatchTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x7f\n" +
+	"\x11WatchTaskResponse\x12)\n" +
+	"\x04task\x18\x01 \x01(\v2\x15.semo.botmgmt.v1.TaskR\x04task\x12?\n" +
+	"\n" +
+	"transition\x18\x02 \x01(\v2\x1f.semo.botmgmt.v1.TaskTransitionR\n" +
+	"transition*\xfe\x01\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13TASK_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17TASK_STATUS_DISPATCHING\x10\x02\x12\x17\n" +
+	"\x13TASK_STATUS_RUNNING\x10\x03\x12\x19\n" +
+	"\x15TASK_STATUS_COMPLETED\x10\x04\x12\x16\n" +
+	"\x12TASK_STATUS_FAILED\x10\x05\x12\x19\n" +
+	"\x15TASK_STATUS_TIMED_OUT\x10\x06\x12\x19\n" +
+	"\x15TASK_STATUS_CANCELLED\x10\a\x12\x1b\n" +
+	"\x17TASK_STATUS_DEAD_LETTER\x10\b*y\n" +
+	"\aVerdict\x12\x17\n" +
+	"\x13VERDICT_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10VERDICT_PHISHING\x10\x01\x12\x16\n" +
+	"\x12VERDICT_SUSPICIOUS\x10\x02\x12\x12\n" +
+	"\x0eVERDICT_BENIGN\x10\x03\x12\x13\n" +
+	"\x0fVERDICT_UNKNOWN\x10\x04*\x91\x01\n" +
+	"\rTaskSortField\x12\x1f\n" +
+	"\x1bTASK_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aTASK_SORT_FIELD_CREATED_AT\x10\x01\x12\x1e\n" +
+	"\x1aTASK_SORT_FIELD_UPDATED_AT\x10\x02\x12\x1f\n" +
+	"\x1bTASK_SORT_FIELD_RETRY_COUNT\x10\x032\xc0\x03\n" +
+	"\x0eBotMgmtService\x12W\n" +
+	"\n" +
+	"CreateTask\x12\".semo.botmgmt.v1.CreateTaskRequest\x1a#.semo.botmgmt.v1.CreateTaskResponse\"\x00\x12N\n" +
+	"\aGetTask\x12\x1f.semo.botmgmt.v1.GetTaskRequest\x1a .semo.botmgmt.v1.GetTaskResponse\"\x00\x12T\n" +
+	"\tListTasks\x12!.semo.botmgmt.v1.ListTasksRequest\x1a\".semo.botmgmt.v1.ListTasksResponse\"\x00\x12W\n" +
+	"\n" +
+	"CancelTask\x12\".semo.botmgmt.v1.CancelTaskRequest\x1a#.semo.botmgmt.v1.CancelTaskResponse\"\x00\x12V\n" +
+	"\tWatchTask\x12!.semo.botmgmt.v1.WatchTaskRequest\x1a\".semo.botmgmt.v1.WatchTaskResponse\"\x000\x01BEZCgithub.com/SKD-fastcampus/bot-management/proto/botmgmt/v1;botmgmtv1b\x06proto3"
+
+var (
+	file_proto_botmgmt_v1_botmgmt_proto_rawDescOnce sync.Once
+	file_proto_botmgmt_v1_botmgmt_proto_rawDescData []byte
+)
+
+func file_proto_botmgmt_v1_botmgmt_proto_rawDescGZIP() []byte {
+	file_proto_botmgmt_v1_botmgmt_proto_rawDescOnce.Do(func() {
+		file_proto_botmgmt_v1_botmgmt_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_botmgmt_v1_botmgmt_proto_rawDesc), len(file_proto_botmgmt_v1_botmgmt_proto_rawDesc)))
+	})
+	return file_proto_botmgmt_v1_botmgmt_proto_rawDescData
+}
+
+var file_proto_botmgmt_v1_botmgmt_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_botmgmt_v1_botmgmt_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_botmgmt_v1_botmgmt_proto_goTypes = []any{
+	(TaskStatus)(0),               // 0: semo.botmgmt.v1.TaskStatus
+	(Verdict)(0),                  // 1: semo.botmgmt.v1.Verdict
+	(TaskSortField)(0),            // 2: semo.botmgmt.v1.TaskSortField
+	(*Task)(nil),                  // 3: semo.botmgmt.v1.Task
+	(*AnalysisResult)(nil),        // 4: semo.botmgmt.v1.AnalysisResult
+	(*Artifact)(nil),              // 5: semo.botmgmt.v1.Artifact
+	(*Indicator)(nil),             // 6: semo.botmgmt.v1.Indicator
+	(*TaskTransition)(nil),        // 7: semo.botmgmt.v1.TaskTransition
+	(*CreateTaskRequest)(nil),     // 8: semo.botmgmt.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),    // 9: semo.botmgmt.v1.CreateTaskResponse
+	(*GetTaskRequest)(nil),        // 10: semo.botmgmt.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),       // 11: semo.botmgmt.v1.GetTaskResponse
+	(*ListTasksRequest)(nil),      // 12: semo.botmgmt.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),     // 13: semo.botmgmt.v1.ListTasksResponse
+	(*CancelTaskRequest)(nil),     // 14: semo.botmgmt.v1.CancelTaskRequest
+	(*CancelTaskResponse)(nil),    // 15: semo.botmgmt.v1.CancelTaskResponse
+	(*WatchTaskRequest)(nil),      // 16: semo.botmgmt.v1.WatchTaskRequest
+	(*WatchTaskResponse)(nil),     // 17: semo.botmgmt.v1.WatchTaskResponse
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_proto_botmgmt_v1_botmgmt_proto_depIdxs = []int32{
+	0,  // 0: semo.botmgmt.v1.Task.status:type_name -> semo.botmgmt.v1.TaskStatus
+	18, // 1: semo.botmgmt.v1.Task.next_attempt_at:type_name -> google.protobuf.Timestamp
+	4,  // 2: semo.botmgmt.v1.Task.analysis_result:type_name -> semo.botmgmt.v1.AnalysisResult
+	18, // 3: semo.botmgmt.v1.Task.started_at:type_name -> google.protobuf.Timestamp
+	18, // 4: semo.botmgmt.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	18, // 5: semo.botmgmt.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 6: semo.botmgmt.v1.AnalysisResult.verdict:type_name -> semo.botmgmt.v1.Verdict
+	5,  // 7: semo.botmgmt.v1.AnalysisResult.artifacts:type_name -> semo.botmgmt.v1.Artifact
+	6,  // 8: semo.botmgmt.v1.AnalysisResult.indicators:type_name -> semo.botmgmt.v1.Indicator
+	0,  // 9: semo.botmgmt.v1.TaskTransition.from_status:type_name -> semo.botmgmt.v1.TaskStatus
+	0,  // 10: semo.botmgmt.v1.TaskTransition.to_status:type_name -> semo.botmgmt.v1.TaskStatus
+	18, // 11: semo.botmgmt.v1.TaskTransition.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 12: semo.botmgmt.v1.CreateTaskResponse.task:type_name -> semo.botmgmt.v1.Task
+	3,  // 13: semo.botmgmt.v1.GetTaskResponse.task:type_name -> semo.botmgmt.v1.Task
+	0,  // 14: semo.botmgmt.v1.ListTasksRequest.statuses:type_name -> semo.botmgmt.v1.TaskStatus
+	18, // 15: semo.botmgmt.v1.ListTasksRequest.created_from:type_name -> google.protobuf.Timestamp
+	18, // 16: semo.botmgmt.v1.ListTasksRequest.created_to:type_name -> google.protobuf.Timestamp
+	2,  // 17: semo.botmgmt.v1.ListTasksRequest.sort_by:type_name -> semo.botmgmt.v1.TaskSortField
+	3,  // 18: semo.botmgmt.v1.ListTasksResponse.tasks:type_name -> semo.botmgmt.v1.Task
+	3,  // 19: semo.botmgmt.v1.CancelTaskResponse.task:type_name -> semo.botmgmt.v1.Task
+	3,  // 20: semo.botmgmt.v1.WatchTaskResponse.task:type_name -> semo.botmgmt.v1.Task
+	7,  // 21: semo.botmgmt.v1.WatchTaskResponse.transition:type_name -> semo.botmgmt.v1.TaskTransition
+	8,  // 22: semo.botmgmt.v1.BotMgmtService.CreateTask:input_type -> semo.botmgmt.v1.CreateTaskRequest
+	10, // 23: semo.botmgmt.v1.BotMgmtService.GetTask:input_type -> semo.botmgmt.v1.GetTaskRequest
+	12, // 24: semo.botmgmt.v1.BotMgmtService.ListTasks:input_type -> semo.botmgmt.v1.ListTasksRequest
+	14, // 25: semo.botmgmt.v1.BotMgmtService.CancelTask:input_type -> semo.botmgmt.v1.CancelTaskRequest
+	16, // 26: semo.botmgmt.v1.BotMgmtService.WatchTask:input_type -> semo.botmgmt.v1.WatchTaskRequest
+	9,  // 27: semo.botmgmt.v1.BotMgmtService.CreateTask:output_type -> semo.botmgmt.v1.CreateTaskResponse
+	11, // 28: semo.botmgmt.v1.BotMgmtService.GetTask:output_type -> semo.botmgmt.v1.GetTaskResponse
+	13, // 29: semo.botmgmt.v1.BotMgmtService.ListTasks:output_type -> semo.botmgmt.v1.ListTasksResponse
+	15, // 30: semo.botmgmt.v1.BotMgmtService.CancelTask:output_type -> semo.botmgmt.v1.CancelTaskResponse
+	17, // 31: semo.botmgmt.v1.BotMgmtService.WatchTask:output_type -> semo.botmgmt.v1.WatchTaskResponse
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_proto_botmgmt_v1_botmgmt_proto_init() }
+func file_proto_botmgmt_v1_botmgmt_proto_init() {
+	if File_proto_botmgmt_v1_botmgmt_proto != nil {
+		return
+	}
+	file_proto_botmgmt_v1_botmgmt_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_botmgmt_v1_botmgmt_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_botmgmt_v1_botmgmt_proto_rawDesc), len(file_proto_botmgmt_v1_botmgmt_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_botmgmt_v1_botmgmt_proto_goTypes,
+		DependencyIndexes: file_proto_botmgmt_v1_botmgmt_proto_depIdxs,
+		EnumInfos:         file_proto_botmgmt_v1_botmgmt_proto_enumTypes,
+		MessageInfos:      file_proto_botmgmt_v1_botmgmt_proto_msgTypes,
+	}.Build()
+	File_proto_botmgmt_v1_botmgmt_proto = out.File
+	file_proto_botmgmt_v1_botmgmt_proto_goTypes = nil
+	file_proto_botmgmt_v1_botmgmt_proto_depIdxs = nil
+}
@@ -2,24 +2,40 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
-
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	smithyMiddleware "github.com/aws/smithy-go/middleware"
 
 	"github.com/SKD-fastcampus/bot-management/pkg/config"
 	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	pkgMetrics "github.com/SKD-fastcampus/bot-management/pkg/metrics"
+	"github.com/SKD-fastcampus/bot-management/pkg/tracing"
+	botmgmtv1 "github.com/SKD-fastcampus/bot-management/proto/botmgmt/v1"
+	grpcHandler "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/handler/grpc"
 	httpHandler "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/handler/http"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/repository"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
 	awsInfra "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/aws"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/callback"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/db"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/docker"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/fake"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/firebase"
+	grpcInfra "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/grpc"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/hmacauth"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/k8s"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/leader"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/metrics"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
@@ -28,7 +44,11 @@ import (
 	_ "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/docs"
 	echoSwagger "github.com/swaggo/echo-swagger"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // @title Bot Management Server API
@@ -36,6 +56,10 @@ import (
 // @description API for managing smishing analysis bots.
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Firebase ID token as "Bearer <token>"
 func main() {
 
 	// 1-1. Config Load
@@ -68,47 +92,75 @@ func main() {
 	}
 	defer log.Sync()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		code := runMigrate(cfg, log, os.Args[2:])
+		log.Sync()
+		os.Exit(code)
+	}
+
 	log.Info("Starting Bot Management Server")
 
+	// 1-3. Tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: "bot-mgmt-server",
+		Exporter:    cfg.GetString("tracing.exporter"),
+		Endpoint:    cfg.GetString("tracing.otlp.endpoint"),
+		Insecure:    cfg.GetBool("tracing.otlp.insecure"),
+		SampleRatio: cfg.GetFloat64("tracing.sample_ratio"),
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+
 	// 2. Database
-	database, err := db.NewDB(cfg)
+	dbCfg, err := newDBConfig(cfg)
+	if err != nil {
+		log.Fatal("Invalid db config", zap.Error(err))
+	}
+	database, err := db.NewDB(dbCfg, log)
 	if err != nil {
 		log.Fatal("Failed to connect to database", zap.Error(err))
 	}
-
-	// Auto Migration
-	if err := database.AutoMigrate(&domain.AnalysisTask{}); err != nil {
-		log.Fatal("Failed to migrate database", zap.Error(err))
+	if err := tracing.RegisterGormCallbacks(database); err != nil {
+		log.Fatal("Failed to instrument database", zap.Error(err))
 	}
 
-	// 3. AWS Config
-	awsOpts := []func(*awsConfig.LoadOptions) error{
-		awsConfig.WithRegion(cfg.GetString("aws.region")),
+	// Schema: migrations are applied with `server migrate up` before deploying, or on
+	// start when db.migrate_on_start is set (dev and single-replica setups)
+	migrator, err := db.NewMigrator(database, log)
+	if err != nil {
+		log.Fatal("Failed to load migrations", zap.Error(err))
 	}
-	if profile := cfg.GetString("aws.profile"); profile != "" {
-		awsOpts = append(awsOpts, awsConfig.WithSharedConfigProfile(profile))
+	if cfg.GetBool("db.migrate_on_start") {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			log.Fatal("Failed to migrate database", zap.Error(err))
+		}
 	}
-
-	awsCfg, err := awsConfig.LoadDefaultConfig(context.Background(), awsOpts...)
+	if err := migrator.Check(context.Background()); err != nil {
+		log.Fatal("Database schema does not match this release; run `server migrate up`", zap.Error(err))
+	}
+	canonicalizer, err := newURLCanonicalizer(cfg)
 	if err != nil {
-		log.Fatal("Failed to load AWS config", zap.Error(err))
+		log.Fatal("Invalid dedupe config", zap.Error(err))
 	}
 
-	// 4. Infrastructure & Repositories
+	// 3. Infrastructure & Repositories
 	maxRetries := cfg.GetInt("task.max_retries")
 	if maxRetries == 0 {
 		maxRetries = 3 // Default
 	}
 
 	taskRepo := repository.NewGormTaskRepository(database, maxRetries)
-	ecsClient := awsInfra.NewECSClient(awsCfg,
-		cfg.GetString("ecs.cluster"),
-		cfg.GetString("ecs.task_def"),
-		cfg.GetString("ecs.container_name"),
-		cfg.GetStringSlice("ecs.subnets"),
-		cfg.GetString("ecs.sec_group"),
-		log,
-	)
+	callbackRepo := repository.NewGormCallbackRepository(database)
+	nonceRepo := repository.NewGormNonceRepository(database)
+
+	registry := pkgMetrics.NewRegistry()
+	taskMetrics := metrics.NewPrometheus(registry)
+
+	executor, err := newBotExecutor(context.Background(), cfg, log, taskMetrics)
+	if err != nil {
+		log.Fatal("Failed to initialize bot executor", zap.Error(err))
+	}
 
 	firebaseVerifier, err := firebase.NewTokenVerifier(context.Background(), cfg)
 	if err != nil {
@@ -117,59 +169,193 @@ func main() {
 		log.Fatal("Failed to initialize firebase verifier", zap.Error(err))
 	}
 
-	// 5. Usecase
-	taskUC := usecase.NewTaskUsecase(taskRepo, ecsClient, firebaseVerifier, log)
+	webhookKeyring, err := newKeyring(cfg, "webhook")
+	if err != nil {
+		log.Fatal("Invalid webhook config", zap.Error(err))
+	}
+	var webhookVerifier *hmacauth.Verifier
+	if webhookKeyring != nil {
+		maxSkew := time.Duration(cfg.GetInt("webhook.max_skew_seconds")) * time.Second
+		if maxSkew == 0 {
+			maxSkew = 5 * time.Minute // Default
+		}
+		webhookVerifier = hmacauth.NewVerifier(webhookKeyring, nonceRepo, maxSkew)
+	} else {
+		log.Warn("No webhook.secrets configured; all webhook requests will be rejected")
+	}
+
+	// 4. Usecase
+	dispatchCfg := usecase.DispatchConfig{
+		BatchSize:     cfg.GetInt("dispatcher.batch_size"),
+		MaxConcurrent: cfg.GetInt("dispatcher.max_concurrent"),
+		MaxPerUser:    cfg.GetInt("dispatcher.max_per_user"),
+		LaunchTimeout: time.Duration(cfg.GetInt("dispatcher.launch_timeout_seconds")) * time.Second,
+	}
+	reaperCfg := usecase.ReaperConfig{
+		DispatchDeadline: time.Duration(cfg.GetInt("reaper.dispatch_deadline_seconds")) * time.Second,
+		MaxRuntime:       time.Duration(cfg.GetInt("reaper.max_runtime_seconds")) * time.Second,
+	}
+	if err := usecase.ValidateDeadlines(dispatchCfg, reaperCfg); err != nil {
+		log.Fatal("Invalid dispatcher config: dispatcher.launch_timeout_seconds must be below reaper.dispatch_deadline_seconds", zap.Error(err))
+	}
+	retryPolicy, err := newRetryPolicy(cfg)
+	if err != nil {
+		log.Fatal("Invalid retry config", zap.Error(err))
+	}
+	dedupeScope := usecase.DedupeScope(cfg.GetString("dedupe.scope"))
+	switch dedupeScope {
+	case "":
+		dedupeScope = usecase.DedupeByURL
+	case usecase.DedupeByURL, usecase.DedupeByDomain:
+	default:
+		log.Fatal("Invalid dedupe config", zap.String("scope", string(dedupeScope)))
+	}
+	ucOpts := []usecase.Option{
+		usecase.WithDispatchConfig(dispatchCfg),
+		usecase.WithReaperConfig(reaperCfg),
+		usecase.WithRetryPolicy(retryPolicy),
+		usecase.WithURLCanonicalizer(canonicalizer),
+		usecase.WithDedupeScope(dedupeScope),
+		usecase.WithResultCache(usecase.CacheConfig{
+			MaxAge: time.Duration(cfg.GetInt("cache.max_age_seconds")) * time.Second,
+		}),
+		usecase.WithMetrics(taskMetrics),
+		usecase.WithBatchConfig(usecase.BatchConfig{MaxItems: cfg.GetInt("batch.max_items")}),
+		usecase.WithWatchRecheckInterval(time.Duration(cfg.GetInt("stream.recheck_interval_seconds")) * time.Second),
+	}
+	if webhookKeyring != nil {
+		ucOpts = append(ucOpts, usecase.WithBotEnv(webhookKeyring.BotEnv))
+	}
+	callbackSender, callbackCfg, err := newCallbackSender(cfg)
+	if err != nil {
+		log.Fatal("Invalid callback config", zap.Error(err))
+	}
+	if callbackSender != nil {
+		ucOpts = append(ucOpts, usecase.WithCallbacks(callbackRepo, callbackSender, callbackCfg))
+	} else {
+		log.Info("No callback.allowed_hosts configured; requests with a callback_url will be rejected")
+	}
+	taskUC := usecase.NewTaskUsecase(taskRepo, executor, log, ucOpts...)
 
-	// 6. Handlers
+	// 5. Handlers
 	h := httpHandler.NewTaskHandler(taskUC)
 
-	// 7. Echo Server
+	// 6. Echo Server
 	e := echo.New()
+	e.Use(tracing.NewEchoMiddleware())
 	e.Use(middleware.Logger())
+	e.Use(pkgMetrics.NewEchoMiddleware(registry))
 	e.Use(middleware.Recover())
 	apiGroup := e.Group("/api/v1")
-	h.RegisterRoutes(apiGroup)
+	h.RegisterRoutes(apiGroup,
+		httpHandler.AuthMiddleware(firebaseVerifier),
+		httpHandler.WebhookAuthMiddleware(webhookVerifier),
+	)
 
 	// Swagger
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	// 8. Background Workers
+	// gRPC Server
+	grpcPort := cfg.GetInt("server.grpc.port")
+	if grpcPort == 0 {
+		grpcPort = 9090 // Default
+	}
+	grpcSrv := grpcInfra.NewServer(
+		grpcInfra.WithPort(grpcPort),
+		grpcInfra.WithLogger(log),
+		grpcInfra.WithInterceptors(pkgMetrics.NewGrpcServerInterceptors(registry)),
+		grpcInfra.WithInterceptors(grpcHandler.AuthInterceptors(firebaseVerifier)),
+	)
+	grpcSrv.RegisterService(func(s *grpc.Server) {
+		botmgmtv1.RegisterBotMgmtServiceServer(s, grpcHandler.NewTaskHandler(taskUC))
+	})
+
+	// 7. Background Workers
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	var workers sync.WaitGroup
 
-	// Retry Worker
+	// Leader Election: with several replicas, only the leader runs the workers that must not
+	// run concurrently. The election outlives the workers so that leadership is only handed
+	// over once they have stopped.
+	elector, err := newLeaderElector(cfg, database, log, taskMetrics.SetLeader)
+	if err != nil {
+		log.Fatal("Failed to initialize leader election", zap.Error(err))
+	}
+	electionCtx, stopElection := context.WithCancel(context.Background())
+	defer stopElection()
+	electionDone := make(chan struct{})
 	go func() {
-		ticker := time.NewTicker(1 * time.Minute)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := taskUC.RetryFailedTasks(ctx); err != nil {
-					log.Error("Failed to retry tasks", zap.Error(err))
-				}
-			}
-		}
+		defer close(electionDone)
+		elector.Run(electionCtx)
 	}()
 
-	// Polling Worker
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := taskUC.CheckRunningTasks(ctx); err != nil {
-					log.Error("Failed to check running tasks", zap.Error(err))
-				}
-			}
+	// Dispatch Worker
+	dispatchInterval := time.Duration(cfg.GetInt("dispatcher.interval_seconds")) * time.Second
+	if dispatchInterval == 0 {
+		dispatchInterval = 2 * time.Second // Default
+	}
+	startWorker(ctx, &workers, dispatchInterval, func(ctx context.Context) {
+		if err := taskUC.DispatchPendingTasks(ctx); err != nil {
+			log.Error("Failed to dispatch pending tasks", zap.Error(err))
 		}
-	}()
+	})
+
+	// Retry Worker (leader only)
+	startWorker(ctx, &workers, 1*time.Minute, leaderOnly(elector, func(ctx context.Context) {
+		if err := taskUC.RetryFailedTasks(ctx); err != nil {
+			log.Error("Failed to retry tasks", zap.Error(err))
+		}
+	}))
+
+	// Polling Worker (leader only)
+	startWorker(ctx, &workers, 30*time.Second, leaderOnly(elector, func(ctx context.Context) {
+		if err := taskUC.CheckRunningTasks(ctx); err != nil {
+			log.Error("Failed to check running tasks", zap.Error(err))
+		}
+	}))
+
+	// Reaper Worker (leader only)
+	reaperInterval := time.Duration(cfg.GetInt("reaper.interval_seconds")) * time.Second
+	if reaperInterval == 0 {
+		reaperInterval = 30 * time.Second // Default
+	}
+	startWorker(ctx, &workers, reaperInterval, leaderOnly(elector, func(ctx context.Context) {
+		if err := taskUC.ReapOverdueTasks(ctx); err != nil {
+			log.Error("Failed to reap overdue tasks", zap.Error(err))
+		}
+	}))
+
+	// Nonce Purge Worker (leader only)
+	startWorker(ctx, &workers, 1*time.Minute, leaderOnly(elector, func(ctx context.Context) {
+		if err := nonceRepo.DeleteExpired(ctx, time.Now()); err != nil {
+			log.Error("Failed to purge expired webhook nonces", zap.Error(err))
+		}
+	}))
+
+	// Callback Worker
+	callbackInterval := time.Duration(cfg.GetInt("callback.interval_seconds")) * time.Second
+	if callbackInterval == 0 {
+		callbackInterval = 5 * time.Second // Default
+	}
+	startWorker(ctx, &workers, callbackInterval, func(ctx context.Context) {
+		if err := taskUC.DeliverCallbacks(ctx); err != nil {
+			log.Error("Failed to deliver callbacks", zap.Error(err))
+		}
+	})
+
+	// Queue Depth Worker
+	queueDepthInterval := time.Duration(cfg.GetInt("metrics.queue_depth_interval_seconds")) * time.Second
+	if queueDepthInterval == 0 {
+		queueDepthInterval = 15 * time.Second // Default
+	}
+	startWorker(ctx, &workers, queueDepthInterval, func(ctx context.Context) {
+		if err := taskUC.RecordQueueDepth(ctx); err != nil {
+			log.Error("Failed to record queue depth", zap.Error(err))
+		}
+	})
 
-	// 9. Start Server
+	// 8. Start Server
 	port := cfg.GetString("server.http.port")
 	if port == "" {
 		port = "8080"
@@ -179,8 +365,26 @@ func main() {
 			log.Fatal("Shutting down server", zap.Error(err))
 		}
 	}()
+	go func() {
+		if err := grpcSrv.Start(); err != nil {
+			log.Fatal("Shutting down gRPC server", zap.Error(err))
+		}
+	}()
+
+	// Metrics are served on a separate admin port, away from the public API
+	adminPort := cfg.GetInt("server.admin.port")
+	if adminPort == 0 {
+		adminPort = 9100 // Default
+	}
+	adminSrv := pkgMetrics.NewAdminServer(adminPort, registry, log)
+	adminSrv.Handle("/leader", leaderHandler(elector))
+	go func() {
+		if err := adminSrv.Start(); err != nil {
+			log.Fatal("Shutting down admin server", zap.Error(err))
+		}
+	}()
 
-	// 10. Graceful Shutdown
+	// 9. Graceful Shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -188,7 +392,363 @@ func main() {
 
 	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelShutdown()
+	if err := grpcSrv.Shutdown(ctxShutdown); err != nil {
+		log.Warn("gRPC server did not stop gracefully", zap.Error(err))
+	}
 	if err := e.Shutdown(ctxShutdown); err != nil {
 		e.Logger.Fatal(err)
 	}
+	if err := adminSrv.Shutdown(ctxShutdown); err != nil {
+		log.Warn("Admin server did not stop gracefully", zap.Error(err))
+	}
+
+	// Stop background workers and wait for in-flight dispatches to be recorded
+	cancel()
+	workers.Wait()
+
+	// Hand leadership over now rather than making the other replicas wait for the lease to expire
+	stopElection()
+	<-electionDone
+
+	// Export the spans still buffered, including those of the dispatches just finished
+	ctxTracing, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracing()
+	if err := shutdownTracing(ctxTracing); err != nil {
+		log.Warn("Failed to flush traces", zap.Error(err))
+	}
+}
+
+// startWorker runs fn every interval until ctx is cancelled.
+func startWorker(ctx context.Context, wg *sync.WaitGroup, interval time.Duration, fn func(ctx context.Context)) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fn(ctx)
+			}
+		}
+	}()
+}
+
+// leaderOnly wraps a worker function so that it only runs while this replica is the leader
+func leaderOnly(elector leader.Elector, fn func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		if elector.IsLeader() {
+			fn(ctx)
+		}
+	}
+}
+
+// leaderHandler reports this replica's view of the leader election as JSON
+func leaderHandler(elector leader.Elector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(elector.Status())
+	})
+}
+
+// newLeaderElector creates the leader election backend selected by leader_election.backend
+func newLeaderElector(cfg config.Config, database *gorm.DB, log *zap.Logger, onChange leader.Observer) (leader.Elector, error) {
+	electionCfg := leader.DefaultConfig
+	if name := cfg.GetString("leader_election.lease_name"); name != "" {
+		electionCfg.Name = name
+	}
+	if d := cfg.GetInt("leader_election.lease_duration_seconds"); d > 0 {
+		electionCfg.LeaseDuration = time.Duration(d) * time.Second
+	}
+	if d := cfg.GetInt("leader_election.renew_interval_seconds"); d > 0 {
+		electionCfg.RenewInterval = time.Duration(d) * time.Second
+	}
+	if electionCfg.RenewInterval >= electionCfg.LeaseDuration {
+		return nil, fmt.Errorf("leader_election.renew_interval_seconds must be shorter than lease_duration_seconds")
+	}
+
+	electionCfg.Identity = cfg.GetString("leader_election.identity")
+	if electionCfg.Identity == "" {
+		// The pod name on Kubernetes; the suffix keeps replicas on one host apart
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		electionCfg.Identity = fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+	}
+
+	switch backend := cfg.GetString("leader_election.backend"); backend {
+	case "", "db":
+		return leader.NewLeaseElector(repository.NewGormLeaseRepository(database), electionCfg, log, onChange), nil
+	case "k8s":
+		clientset, err := k8s.NewClientset(cfg.GetString("k8s.kubeconfig"))
+		if err != nil {
+			return nil, err
+		}
+		return k8s.NewLeaseElector(clientset, cfg.GetString("leader_election.k8s_namespace"), electionCfg, log, onChange)
+	case "none":
+		// Single replica: always the leader
+		onChange(true)
+		return leader.Always{Identity: electionCfg.Identity}, nil
+	default:
+		return nil, fmt.Errorf("unsupported leader election backend: %s", backend)
+	}
+}
+
+// newBotExecutor builds the domain.BotExecutor selected by executor.type (ecs by default).
+// AWS API calls of the ecs executor are recorded in m.
+func newBotExecutor(ctx context.Context, cfg config.Config, log *zap.Logger, m *metrics.Prometheus) (domain.BotExecutor, error) {
+	executorType := cfg.GetString("executor.type")
+	if executorType == "" {
+		executorType = "ecs" // Default
+	}
+
+	log.Info("Using bot executor", zap.String("type", executorType))
+
+	switch executorType {
+	case "ecs":
+		awsOpts := []func(*awsConfig.LoadOptions) error{
+			awsConfig.WithRegion(cfg.GetString("aws.region")),
+			awsConfig.WithAPIOptions([]func(*smithyMiddleware.Stack) error{tracing.InstrumentAWS, m.InstrumentAWS}),
+		}
+		if profile := cfg.GetString("aws.profile"); profile != "" {
+			awsOpts = append(awsOpts, awsConfig.WithSharedConfigProfile(profile))
+		}
+
+		awsCfg, err := awsConfig.LoadDefaultConfig(ctx, awsOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+
+		return awsInfra.NewECSClient(awsCfg,
+			cfg.GetString("ecs.cluster"),
+			cfg.GetString("ecs.task_def"),
+			cfg.GetString("ecs.container_name"),
+			cfg.GetStringSlice("ecs.subnets"),
+			cfg.GetString("ecs.sec_group"),
+			log,
+		), nil
+	case "docker":
+		return docker.NewDockerClient(
+			cfg.GetString("docker.host"),
+			cfg.GetString("docker.image"),
+			cfg.GetString("docker.network"),
+			log,
+		)
+	case "k8s":
+		clientset, err := k8s.NewClientset(cfg.GetString("k8s.kubeconfig"))
+		if err != nil {
+			return nil, err
+		}
+
+		return k8s.NewJobClient(clientset, k8s.JobConfig{
+			Namespace:               cfg.GetString("k8s.namespace"),
+			Image:                   cfg.GetString("k8s.image"),
+			ContainerName:           cfg.GetString("k8s.container_name"),
+			ServiceAccount:          cfg.GetString("k8s.service_account"),
+			Env:                     cfg.GetStringSlice("k8s.env"),
+			TTLSecondsAfterFinished: optionalInt32(cfg, "k8s.ttl_seconds_after_finished"),
+			ActiveDeadlineSeconds:   int64(cfg.GetInt("k8s.active_deadline_seconds")),
+			BackoffLimit:            int32(cfg.GetInt("k8s.backoff_limit")),
+			Requests:                toStringMap(cfg.GetStringMap("k8s.resources.requests")),
+			Limits:                  toStringMap(cfg.GetStringMap("k8s.resources.limits")),
+		}, log)
+	case "fake":
+		return fake.NewFakeExecutorFromConfig(cfg, log)
+	default:
+		return nil, fmt.Errorf("unsupported executor type: %s", executorType)
+	}
+}
+
+// optionalInt32 returns nil for a key that is not set, which differs from an explicit 0
+func optionalInt32(cfg config.Config, key string) *int32 {
+	if cfg.GetString(key) == "" {
+		return nil
+	}
+	v := int32(cfg.GetInt(key))
+	return &v
+}
+
+func toStringMap(in map[string]interface{}) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
+
+// newDBConfig builds the database settings from the db.* config keys, keeping defaults for unset keys.
+func newDBConfig(cfg config.Config) (db.Config, error) {
+	dbCfg := db.DefaultConfig
+	dbCfg.Driver = cfg.GetString("db.driver")
+	dbCfg.Host = cfg.GetString("db.host")
+	dbCfg.Port = cfg.GetString("db.port")
+	dbCfg.User = cfg.GetString("db.user")
+	dbCfg.Password = cfg.GetString("db.password")
+	dbCfg.Name = cfg.GetString("db.name")
+	dbCfg.TimeZone = cfg.GetString("db.timezone")
+	dbCfg.StatementTimeout = time.Duration(cfg.GetInt("db.statement_timeout_ms")) * time.Millisecond
+	if v := cfg.GetInt("db.connect_timeout_seconds"); v > 0 {
+		dbCfg.ConnectTimeout = time.Duration(v) * time.Second
+	}
+
+	if mode := cfg.GetString("db.tls.mode"); mode != "" {
+		dbCfg.TLS.Mode = db.TLSMode(mode)
+	}
+	dbCfg.TLS.CAFile = cfg.GetString("db.tls.ca_file")
+	dbCfg.TLS.CertFile = cfg.GetString("db.tls.cert_file")
+	dbCfg.TLS.KeyFile = cfg.GetString("db.tls.key_file")
+
+	if v := cfg.GetInt("db.pool.max_open_conns"); v > 0 {
+		dbCfg.Pool.MaxOpenConns = v
+	}
+	if v := cfg.GetInt("db.pool.max_idle_conns"); v > 0 {
+		dbCfg.Pool.MaxIdleConns = v
+	}
+	if v := cfg.GetInt("db.pool.conn_max_lifetime_seconds"); v > 0 {
+		dbCfg.Pool.ConnMaxLifetime = time.Duration(v) * time.Second
+	}
+	if v := cfg.GetInt("db.pool.conn_max_idle_time_seconds"); v > 0 {
+		dbCfg.Pool.ConnMaxIdleTime = time.Duration(v) * time.Second
+	}
+	dbCfg.Replicas = cfg.GetStringSlice("db.replicas")
+
+	switch level := cfg.GetString("db.log_level"); level {
+	case "":
+	case "silent":
+		dbCfg.LogLevel = gormlogger.Silent
+	case "error":
+		dbCfg.LogLevel = gormlogger.Error
+	case "warn":
+		dbCfg.LogLevel = gormlogger.Warn
+	case "info":
+		dbCfg.LogLevel = gormlogger.Info
+	default:
+		return dbCfg, fmt.Errorf("unsupported db.log_level: %s", level)
+	}
+	if v := cfg.GetInt("db.slow_query_threshold_ms"); v > 0 {
+		dbCfg.SlowThreshold = time.Duration(v) * time.Millisecond
+	}
+
+	if v := cfg.GetInt("db.connect_attempts"); v > 0 {
+		dbCfg.ConnectAttempts = v
+	}
+	if v := cfg.GetInt("db.connect_backoff_ms"); v > 0 {
+		dbCfg.ConnectBackoff = time.Duration(v) * time.Millisecond
+	}
+	return dbCfg, dbCfg.Validate()
+}
+
+// newRetryPolicy builds the retry policy from the retry.* config keys, keeping defaults for unset keys.
+func newRetryPolicy(cfg config.Config) (domain.RetryPolicy, error) {
+	policy := domain.DefaultRetryPolicy
+	if v := cfg.GetInt("retry.base_delay_seconds"); v > 0 {
+		policy.BaseDelay = time.Duration(v) * time.Second
+	}
+	if v := cfg.GetInt("retry.max_delay_seconds"); v > 0 {
+		policy.MaxDelay = time.Duration(v) * time.Second
+	}
+	if v := cfg.GetFloat64("retry.multiplier"); v > 0 {
+		policy.Multiplier = v
+	}
+	if v := cfg.GetFloat64("retry.jitter"); v > 0 {
+		policy.Jitter = v
+	}
+	for _, code := range cfg.GetStringSlice("retry.permanent_exit_codes") {
+		c, err := strconv.Atoi(code)
+		if err != nil {
+			return policy, fmt.Errorf("invalid retry.permanent_exit_codes entry %q: %w", code, err)
+		}
+		policy.PermanentExitCodes = append(policy.PermanentExitCodes, c)
+	}
+	return policy, nil
+}
+
+// newKeyring reads the signing keys of a config section (e.g. "webhook"). It returns nil
+// when none are configured.
+func newKeyring(cfg config.Config, section string) (*hmacauth.Keyring, error) {
+	raw := cfg.GetStringMap(section + ".secrets")
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	secrets := make(map[string]string, len(raw))
+	for id, v := range raw {
+		secret, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.secrets.%s must be a string", section, id)
+		}
+		secrets[id] = secret
+	}
+	return hmacauth.NewKeyring(secrets, cfg.GetString(section+".current_key_id"))
+}
+
+// newCallbackSender builds the callback sender and settings from the callback.* config keys.
+// It returns a nil sender when no allowed hosts are configured, which disables callbacks.
+func newCallbackSender(cfg config.Config) (*callback.HTTPSender, usecase.CallbackConfig, error) {
+	callbackCfg := usecase.CallbackConfig{
+		AllowedHosts: cfg.GetStringSlice("callback.allowed_hosts"),
+		MaxAttempts:  cfg.GetInt("callback.max_attempts"),
+		BatchSize:    cfg.GetInt("callback.batch_size"),
+		Retry:        usecase.DefaultCallbackConfig.Retry,
+	}
+	if len(callbackCfg.AllowedHosts) == 0 {
+		return nil, callbackCfg, nil
+	}
+	if v := cfg.GetInt("callback.base_delay_seconds"); v > 0 {
+		callbackCfg.Retry.BaseDelay = time.Duration(v) * time.Second
+	}
+	if v := cfg.GetInt("callback.max_delay_seconds"); v > 0 {
+		callbackCfg.Retry.MaxDelay = time.Duration(v) * time.Second
+	}
+
+	timeout := time.Duration(cfg.GetInt("callback.timeout_seconds")) * time.Second
+	if timeout == 0 {
+		timeout = 10 * time.Second // Default
+	}
+	// A delivery stays claimed until its attempt has certainly finished
+	callbackCfg.Lease = timeout + time.Minute
+
+	keyring, err := newKeyring(cfg, "callback")
+	if err != nil {
+		return nil, callbackCfg, err
+	}
+	if keyring == nil {
+		return nil, callbackCfg, fmt.Errorf("callback.secrets is required when callback.allowed_hosts is set")
+	}
+	return callback.NewHTTPSender(keyring, timeout), callbackCfg, nil
+}
+
+// newURLCanonicalizer builds the canonicalizer from dedupe.canonicalize. Without a steps
+// list every normalization step is applied.
+func newURLCanonicalizer(cfg config.Config) (*urlcanon.Canonicalizer, error) {
+	opts := urlcanon.DefaultOptions
+	if steps := cfg.GetStringSlice("dedupe.canonicalize.steps"); len(steps) > 0 {
+		opts = urlcanon.Options{TrackingParams: urlcanon.DefaultTrackingParams}
+		for _, step := range steps {
+			switch step {
+			case "lowercase_host":
+				opts.LowercaseHost = true
+			case "idna":
+				opts.IDNA = true
+			case "strip_default_port":
+				opts.StripDefaultPort = true
+			case "strip_trailing_slash":
+				opts.StripTrailingSlash = true
+			case "remove_tracking_params":
+				opts.RemoveTrackingParams = true
+			case "sort_query":
+				opts.SortQuery = true
+			case "drop_fragment":
+				opts.DropFragment = true
+			default:
+				return nil, fmt.Errorf("unknown dedupe.canonicalize.steps entry %q", step)
+			}
+		}
+	}
+	if params := cfg.GetStringSlice("dedupe.canonicalize.tracking_params"); len(params) > 0 {
+		opts.TrackingParams = params
+	}
+	return urlcanon.New(opts), nil
 }
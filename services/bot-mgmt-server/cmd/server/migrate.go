@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/pkg/config"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/db"
+	"go.uber.org/zap"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up                  apply all pending migrations
+  down [-steps N]     roll back the latest N migrations (default 1)
+  status              list migrations and whether they are applied
+  create [-dir D] NAME
+                      add empty up/down files for the next version of every dialect
+`
+
+// runMigrate implements the migrate subcommand and returns the process exit code
+func runMigrate(cfg config.Config, log *zap.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	// create works on the source tree and needs no database
+	if args[0] == "create" {
+		fs := flag.NewFlagSet("create", flag.ContinueOnError)
+		dir := fs.String("dir", "services/bot-mgmt-server/internal/infrastructure/db/migrations", "migrations directory holding one subdirectory per dialect")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if fs.NArg() != 1 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		paths, err := db.CreateMigration(*dir, fs.Arg(0))
+		if err != nil {
+			log.Error("Failed to create migration", zap.Error(err))
+			return 1
+		}
+		for _, p := range paths {
+			fmt.Println(p)
+		}
+		return 0
+	}
+
+	dbCfg, err := newDBConfig(cfg)
+	if err != nil {
+		log.Error("Invalid db config", zap.Error(err))
+		return 1
+	}
+	database, err := db.NewDB(dbCfg, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return 1
+	}
+	migrator, err := db.NewMigrator(database, log)
+	if err != nil {
+		log.Error("Failed to load migrations", zap.Error(err))
+		return 1
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Error("Migration failed", zap.Error(err))
+			return 1
+		}
+		if len(applied) == 0 {
+			log.Info("Database schema is up to date")
+		}
+	case "down":
+		fs := flag.NewFlagSet("down", flag.ContinueOnError)
+		steps := fs.Int("steps", 1, "number of migrations to roll back")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if _, err := migrator.Down(ctx, *steps); err != nil {
+			log.Error("Rollback failed", zap.Error(err))
+			return 1
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Error("Failed to read migration status", zap.Error(err))
+			return 1
+		}
+		printMigrationStatus(statuses)
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	return 0
+}
+
+func printMigrationStatus(statuses []db.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", ""
+		if s.Applied {
+			state, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+		}
+		switch {
+		case s.Dirty:
+			state = "dirty"
+		case s.Modified:
+			state = "modified"
+		case s.Unknown:
+			state = "unknown"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
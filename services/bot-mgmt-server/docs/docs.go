@@ -17,7 +17,12 @@ const docTemplate = `{
     "paths": {
         "/analyze": {
             "post": {
-                "description": "Initiates a new smishing analysis task for a given URL",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Initiates a new smishing analysis task for a given URL. If the URL was analysed recently,\nthe task is returned already COMPLETED with that result (from_cache).\nWith callback_url, the outcome is POSTed there when the task finishes, signed like the webhook\n(X-Webhook-* headers) with the current callback key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -43,7 +48,135 @@ const docTemplate = `{
                     "202": {
                         "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.AnalysisTask"
+                            "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/analyze/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a task per URL. Items succeed or fail individually; URLs that canonicalize to an earlier\nitem's URL share its task (duplicate). Returns 400 if no item could be submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Submit several URLs",
+                "parameters": [
+                    {
+                        "description": "Create Batch Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.CreateBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/batches/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregate status of the tasks of a batch",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get batch progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.BatchProgressResponse"
                         }
                     },
                     "400": {
@@ -55,6 +188,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -69,6 +220,11 @@ const docTemplate = `{
         },
         "/status/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Retrieve the current status of an analysis task",
                 "produces": [
                     "application/json"
@@ -91,7 +247,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.AnalysisTask"
+                            "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
                         }
                     },
                     "400": {
@@ -103,6 +259,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -115,33 +289,110 @@ const docTemplate = `{
                 }
             }
         },
-        "/webhook": {
-            "post": {
-                "description": "Update task status via webhook (Internal use)",
-                "consumes": [
-                    "application/json"
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the caller's tasks, newest first by default. Pass next_cursor back as cursor to get the following page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "tasks"
                 ],
-                "summary": "Handle webhook update",
+                "summary": "List tasks",
                 "parameters": [
                     {
-                        "description": "Webhook Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapter_handler_http.WebhookRequest"
-                        }
+                        "type": "string",
+                        "description": "Comma-separated statuses, e.g. FAILED,DEAD_LETTER",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive URL substring, e.g. a domain",
+                        "name": "url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search Server analysis ID",
+                        "name": "analysis_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive lower bound on created_at (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive upper bound on created_at (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum retry count",
+                        "name": "min_retry_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum retry count",
+                        "name": "max_retry_count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "retry_count"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.TaskListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -149,8 +400,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -169,99 +420,1062 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.AnalysisTask": {
-            "type": "object",
-            "properties": {
-                "analysis_id": {
-                    "description": "Search Server's DB PK",
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "external_id": {
-                    "description": "AWS Task ARN or similar",
-                    "type": "string"
-                },
-                "firebase_token": {
-                    "description": "Firebase User Token",
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "request_uuid": {
-                    "description": "External User/Request UUID (Deprecated/Legacy use)",
-                    "type": "string"
-                },
-                "result": {
-                    "type": "string"
-                },
-                "retry_count": {
-                    "type": "integer"
-                },
-                "status": {
-                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus": {
-            "type": "string",
-            "enum": [
-                "PENDING",
-                "RUNNING",
-                "COMPLETED",
-                "FAILED"
-            ],
-            "x-enum-varnames": [
-                "TaskStatusPending",
-                "TaskStatusRunning",
-                "TaskStatusCompleted",
-                "TaskStatusFailed"
-            ]
         },
-        "internal_adapter_handler_http.CreateTaskRequest": {
-            "type": "object",
-            "properties": {
-                "analysis_id": {
-                    "type": "string"
-                },
-                "firebase_token": {
-                    "type": "string"
-                },
-                "request_uuid": {
-                    "description": "Optional/Legacy",
-                    "type": "string"
-                },
+        "/tasks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the task CANCELLED and stops the running bot. Finished tasks cannot be cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Cancel a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cancellation reason",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/callbacks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The deliveries of the task's outcome to its callback_url, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "List callback deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_adapter_handler_http.CallbackDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/callbacks/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a new delivery of the finished task's outcome to its callback_url",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Redeliver the callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.CallbackDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of a task: the current state, then a \"status\" event for every change.\nThe last event is \"result\" once the task reaches a terminal status; the stream then ends.\nComment lines are sent as heartbeats. Requires the Authorization header, so browsers need an\nEventSource implementation that can set headers.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Stream task status (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.TaskChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "WebSocket alternative to /tasks/{id}/events. Each text message is a WebSocketMessage;\nthe server closes the connection after the \"result\" message. Client messages are ignored.",
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Stream task status (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.WebSocketMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "post": {
+                "description": "Update task status via webhook (Internal use). Requests must be signed with the task's\nWEBHOOK_SECRET: X-Webhook-Signature = \"sha256=\" + hex(HMAC-SHA256(secret, timestamp + \".\" + nonce + \".\" + body)).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Handle webhook update",
+                "parameters": [
+                    {
+                        "description": "Webhook Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_handler_http.WebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "WEBHOOK_KEY_ID given to the bot",
+                        "name": "X-Webhook-Key-Id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix time in seconds",
+                        "name": "X-Webhook-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique per request, at most 128 bytes",
+                        "name": "X-Webhook-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256=\u003chex HMAC\u003e",
+                        "name": "X-Webhook-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.AnalysisResult": {
+            "type": "object",
+            "properties": {
+                "artifacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ResultArtifact"
+                    }
+                },
+                "confidence": {
+                    "description": "0.0 - 1.0",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "detected_brand": {
+                    "type": "string"
+                },
+                "final_domain": {
+                    "description": "Host of FinalURL, derived on validation",
+                    "type": "string"
+                },
+                "final_url": {
+                    "type": "string"
+                },
+                "indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ResultIndicator"
+                    }
+                },
+                "page_title": {
+                    "type": "string"
+                },
+                "redirect_chain": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schema_version": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.Verdict"
+                }
+            }
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ArtifactKind": {
+            "type": "string",
+            "enum": [
+                "screenshot",
+                "har"
+            ],
+            "x-enum-varnames": [
+                "ArtifactScreenshot",
+                "ArtifactHAR"
+            ]
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.CallbackStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DELIVERED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "CallbackFailed": "Given up; can still be redelivered manually",
+                "CallbackPending": "Waiting for its next attempt"
+            },
+            "x-enum-descriptions": [
+                "Waiting for its next attempt",
+                "",
+                "Given up; can still be redelivered manually"
+            ],
+            "x-enum-varnames": [
+                "CallbackPending",
+                "CallbackDelivered",
+                "CallbackFailed"
+            ]
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ResultArtifact": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ArtifactKind"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ResultIndicator": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "e.g. \"brand_impersonation\", \"credential_form\", \"blocklist\"",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "What matched (a brand name, a form action URL, a list name, ...)",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskActor": {
+            "type": "string",
+            "enum": [
+                "api",
+                "webhook",
+                "poller",
+                "retry_worker",
+                "dispatcher",
+                "reaper"
+            ],
+            "x-enum-varnames": [
+                "ActorAPI",
+                "ActorWebhook",
+                "ActorPoller",
+                "ActorRetryWorker",
+                "ActorDispatcher",
+                "ActorReaper"
+            ]
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DISPATCHING",
+                "RUNNING",
+                "COMPLETED",
+                "FAILED",
+                "TIMED_OUT",
+                "CANCELLED",
+                "DEAD_LETTER"
+            ],
+            "x-enum-comments": {
+                "TaskStatusDeadLetter": "Failed permanently; never retried",
+                "TaskStatusDispatching": "Claimed by the dispatcher, RunBot in progress"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Claimed by the dispatcher, RunBot in progress",
+                "",
+                "",
+                "",
+                "",
+                "",
+                "Failed permanently; never retried"
+            ],
+            "x-enum-varnames": [
+                "TaskStatusPending",
+                "TaskStatusDispatching",
+                "TaskStatusRunning",
+                "TaskStatusCompleted",
+                "TaskStatusFailed",
+                "TaskStatusTimedOut",
+                "TaskStatusCancelled",
+                "TaskStatusDeadLetter"
+            ]
+        },
+        "github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.Verdict": {
+            "type": "string",
+            "enum": [
+                "phishing",
+                "suspicious",
+                "benign",
+                "unknown"
+            ],
+            "x-enum-comments": {
+                "VerdictUnknown": "The bot could not reach a conclusion (e.g. page did not load)"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "The bot could not reach a conclusion (e.g. page did not load)"
+            ],
+            "x-enum-varnames": [
+                "VerdictPhishing",
+                "VerdictSuspicious",
+                "VerdictBenign",
+                "VerdictUnknown"
+            ]
+        },
+        "internal_adapter_handler_http.AnalysisResultResponse": {
+            "type": "object",
+            "properties": {
+                "artifacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapter_handler_http.ArtifactResponse"
+                    }
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "detected_brand": {
+                    "type": "string"
+                },
+                "final_domain": {
+                    "type": "string"
+                },
+                "final_url": {
+                    "type": "string"
+                },
+                "indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapter_handler_http.IndicatorResponse"
+                    }
+                },
+                "page_title": {
+                    "type": "string"
+                },
+                "redirect_chain": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schema_version": {
+                    "type": "integer"
+                },
+                "verdict": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.Verdict"
+                }
+            }
+        },
+        "internal_adapter_handler_http.ArtifactResponse": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.ArtifactKind"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.BatchItemRequest": {
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string"
+                },
+                "request_uuid": {
+                    "type": "string"
+                },
                 "url": {
                     "type": "string"
                 }
             }
         },
-        "internal_adapter_handler_http.WebhookRequest": {
+        "internal_adapter_handler_http.BatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "description": "Same canonical URL as an earlier item",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
+                }
+            }
+        },
+        "internal_adapter_handler_http.BatchProgressResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Still in flight or due to be retried",
+                    "type": "integer"
+                },
+                "batch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "status_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_adapter_handler_http.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "description": "In submission order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapter_handler_http.BatchItemResponse"
+                    }
+                }
+            }
+        },
+        "internal_adapter_handler_http.CallbackDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "description": "HTTP status of the last attempt",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.CallbackStatus"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.CreateBatchRequest": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "force_refresh": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapter_handler_http.BatchItemRequest"
+                    }
+                },
+                "max_age": {
+                    "description": "MaxAge, ForceRefresh and CallbackURL apply to every item, as in CreateTaskRequest",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_adapter_handler_http.CreateTaskRequest": {
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string"
+                },
+                "callback_url": {
+                    "description": "CallbackURL receives a signed POST with the outcome once the task finishes; its host must be on callback.allowed_hosts",
+                    "type": "string"
+                },
+                "force_refresh": {
+                    "description": "ForceRefresh launches a new analysis even if a cached result exists",
+                    "type": "boolean"
+                },
+                "max_age": {
+                    "description": "MaxAge is the oldest cached result in seconds this request accepts; defaults to cache.max_age_seconds, 0 disables the cache",
+                    "type": "integer"
+                },
+                "request_uuid": {
+                    "description": "Optional/Legacy",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.IndicatorResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.TaskChangeResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
+                },
+                "transition": {
+                    "description": "Absent for the initial snapshot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_adapter_handler_http.TransitionResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_adapter_handler_http.TaskListResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Absent on the last page",
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapter_handler_http.TaskResponse"
+                    }
+                }
+            }
+        },
+        "internal_adapter_handler_http.TaskResponse": {
             "type": "object",
             "properties": {
+                "analysis_id": {
+                    "type": "string"
+                },
+                "analysis_result": {
+                    "$ref": "#/definitions/internal_adapter_handler_http.AnalysisResultResponse"
+                },
+                "callback_url": {
+                    "type": "string"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_cache": {
+                    "description": "Result reused from a recent analysis of the same URL",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "registered_domain": {
+                    "type": "string"
+                },
+                "request_uuid": {
+                    "type": "string"
+                },
                 "result": {
                     "type": "string"
                 },
+                "retry_count": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus"
                 },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.TransitionResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskActor"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus"
+                }
+            }
+        },
+        "internal_adapter_handler_http.WebSocketMessage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_adapter_handler_http.TaskChangeResponse"
+                },
+                "type": {
+                    "description": "\"status\" or \"result\"",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_handler_http.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "analysis_result": {
+                    "description": "AnalysisResult is the structured result, accepted only with status COMPLETED",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.AnalysisResult"
+                        }
+                    ]
+                },
+                "exit_code": {
+                    "description": "ExitCode is the bot's exit code for FAILED reports; codes listed in\nretry.permanent_exit_codes move the task to DEAD_LETTER instead of retrying it",
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is RUNNING, COMPLETED or FAILED; other statuses are set by the server only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_SKD-fastcampus_bot-management_services_bot-mgmt-server_internal_domain.TaskStatus"
+                        }
+                    ]
+                },
                 "task_id": {
                     "description": "Matches our internal ID",
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Firebase ID token as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
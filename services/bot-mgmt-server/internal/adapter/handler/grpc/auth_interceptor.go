@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	botmgmtv1 "github.com/SKD-fastcampus/bot-management/proto/botmgmt/v1"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/firebase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ownerUIDKey is the context key holding the verified Firebase UID
+type ownerUIDKey struct{}
+
+// servicePrefix selects the methods that require authentication; health checks and
+// reflection stay open
+var servicePrefix = "/" + botmgmtv1.BotMgmtService_ServiceDesc.ServiceName + "/"
+
+// AuthInterceptors verify the Firebase ID token in the "authorization: Bearer <token>"
+// metadata of BotMgmtService calls and store the caller's UID in the context.
+func AuthInterceptors(verifier firebase.TokenVerifier) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, servicePrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, servicePrefix) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+
+	return unary, stream
+}
+
+func authenticate(ctx context.Context, verifier firebase.TokenVerifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	idToken, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || idToken == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	token, err := verifier.VerifyIDToken(ctx, idToken)
+	if err != nil || token == nil || token.UID == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid firebase token")
+	}
+	return context.WithValue(ctx, ownerUIDKey{}, token.UID), nil
+}
+
+// authenticatedStream carries the context with the caller's UID into stream handlers
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// OwnerUID returns the UID verified by AuthInterceptors, or ""
+func OwnerUID(ctx context.Context) string {
+	uid, _ := ctx.Value(ownerUIDKey{}).(string)
+	return uid
+}
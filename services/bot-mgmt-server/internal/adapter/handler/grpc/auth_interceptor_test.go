@@ -0,0 +1,95 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	botmgmtv1 "github.com/SKD-fastcampus/bot-management/proto/botmgmt/v1"
+	grpcHandler "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/handler/grpc"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptors_Unary(t *testing.T) {
+	verifier := new(mocks.MockTokenVerifier)
+	verifier.On("VerifyIDToken", mock.Anything, "good-token").Return(&auth.Token{UID: "user-1"}, nil)
+	verifier.On("VerifyIDToken", mock.Anything, "bad-token").Return(nil, errors.New("expired"))
+
+	unary, _ := grpcHandler.AuthInterceptors(verifier)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return grpcHandler.OwnerUID(ctx), nil
+	}
+	getTask := &grpc.UnaryServerInfo{FullMethod: botmgmtv1.BotMgmtService_GetTask_FullMethodName}
+
+	cases := []struct {
+		name   string
+		header string
+		code   codes.Code
+		uid    string
+	}{
+		{"valid token", "Bearer good-token", codes.OK, "user-1"},
+		{"invalid token", "Bearer bad-token", codes.Unauthenticated, ""},
+		{"missing header", "", codes.Unauthenticated, ""},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", codes.Unauthenticated, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.header))
+			}
+			resp, err := unary(ctx, nil, getTask, handler)
+
+			assert.Equal(t, tc.code, status.Code(err))
+			if tc.code == codes.OK {
+				assert.Equal(t, tc.uid, resp)
+			}
+		})
+	}
+
+	t.Run("other services skip authentication", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName}
+		_, err := unary(context.Background(), nil, info, handler)
+		assert.NoError(t, err)
+	})
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptors_Stream(t *testing.T) {
+	verifier := new(mocks.MockTokenVerifier)
+	verifier.On("VerifyIDToken", mock.Anything, "good-token").Return(&auth.Token{UID: "user-1"}, nil)
+
+	_, stream := grpcHandler.AuthInterceptors(verifier)
+	info := &grpc.StreamServerInfo{FullMethod: botmgmtv1.BotMgmtService_WatchTask_FullMethodName, IsServerStream: true}
+
+	var uid string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		uid = grpcHandler.OwnerUID(ss.Context())
+		return nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer good-token"))
+	err := stream(nil, &fakeStream{ctx: ctx}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", uid)
+
+	err = stream(nil, &fakeStream{ctx: context.Background()}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
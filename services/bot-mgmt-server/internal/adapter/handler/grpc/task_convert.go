@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"time"
+
+	botmgmtv1 "github.com/SKD-fastcampus/bot-management/proto/botmgmt/v1"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statusToProto = map[domain.TaskStatus]botmgmtv1.TaskStatus{
+	domain.TaskStatusPending:     botmgmtv1.TaskStatus_TASK_STATUS_PENDING,
+	domain.TaskStatusDispatching: botmgmtv1.TaskStatus_TASK_STATUS_DISPATCHING,
+	domain.TaskStatusRunning:     botmgmtv1.TaskStatus_TASK_STATUS_RUNNING,
+	domain.TaskStatusCompleted:   botmgmtv1.TaskStatus_TASK_STATUS_COMPLETED,
+	domain.TaskStatusFailed:      botmgmtv1.TaskStatus_TASK_STATUS_FAILED,
+	domain.TaskStatusTimedOut:    botmgmtv1.TaskStatus_TASK_STATUS_TIMED_OUT,
+	domain.TaskStatusCancelled:   botmgmtv1.TaskStatus_TASK_STATUS_CANCELLED,
+	domain.TaskStatusDeadLetter:  botmgmtv1.TaskStatus_TASK_STATUS_DEAD_LETTER,
+}
+
+var statusFromProto = func() map[botmgmtv1.TaskStatus]domain.TaskStatus {
+	m := make(map[botmgmtv1.TaskStatus]domain.TaskStatus, len(statusToProto))
+	for s, p := range statusToProto {
+		m[p] = s
+	}
+	return m
+}()
+
+var verdictToProto = map[domain.Verdict]botmgmtv1.Verdict{
+	domain.VerdictPhishing:   botmgmtv1.Verdict_VERDICT_PHISHING,
+	domain.VerdictSuspicious: botmgmtv1.Verdict_VERDICT_SUSPICIOUS,
+	domain.VerdictBenign:     botmgmtv1.Verdict_VERDICT_BENIGN,
+	domain.VerdictUnknown:    botmgmtv1.Verdict_VERDICT_UNKNOWN,
+}
+
+var sortFieldFromProto = map[botmgmtv1.TaskSortField]domain.TaskSortField{
+	botmgmtv1.TaskSortField_TASK_SORT_FIELD_UNSPECIFIED: domain.SortByCreatedAt,
+	botmgmtv1.TaskSortField_TASK_SORT_FIELD_CREATED_AT:  domain.SortByCreatedAt,
+	botmgmtv1.TaskSortField_TASK_SORT_FIELD_UPDATED_AT:  domain.SortByUpdatedAt,
+	botmgmtv1.TaskSortField_TASK_SORT_FIELD_RETRY_COUNT: domain.SortByRetryCount,
+}
+
+func newTask(t *domain.AnalysisTask) *botmgmtv1.Task {
+	return &botmgmtv1.Task{
+		Id:               t.ID.String(),
+		RequestUuid:      t.RequestUUID,
+		AnalysisId:       t.AnalysisID,
+		Url:              t.URL,
+		CanonicalUrl:     t.CanonicalURL,
+		RegisteredDomain: t.RegisteredDomain,
+		Status:           statusToProto[t.Status],
+		FromCache:        t.FromCache,
+		CallbackUrl:      t.CallbackURL,
+		RetryCount:       int32(t.RetryCount),
+		NextAttemptAt:    newTimestamp(t.NextAttemptAt),
+		Result:           t.Result,
+		AnalysisResult:   newAnalysisResult(t.AnalysisResult),
+		StartedAt:        newTimestamp(t.StartedAt),
+		CreatedAt:        timestamppb.New(t.CreatedAt),
+		UpdatedAt:        timestamppb.New(t.UpdatedAt),
+	}
+}
+
+func newAnalysisResult(r *domain.AnalysisResult) *botmgmtv1.AnalysisResult {
+	if r == nil {
+		return nil
+	}
+
+	resp := &botmgmtv1.AnalysisResult{
+		SchemaVersion: int32(r.SchemaVersion),
+		Verdict:       verdictToProto[r.Verdict],
+		Confidence:    r.Confidence,
+		FinalUrl:      r.FinalURL,
+		FinalDomain:   r.FinalDomain,
+		RedirectChain: r.RedirectChain,
+		PageTitle:     r.PageTitle,
+		DetectedBrand: r.DetectedBrand,
+	}
+	for _, a := range r.Artifacts {
+		resp.Artifacts = append(resp.Artifacts, &botmgmtv1.Artifact{Kind: string(a.Kind), Uri: a.URI})
+	}
+	for _, i := range r.Indicators {
+		resp.Indicators = append(resp.Indicators, &botmgmtv1.Indicator{Type: i.Type, Value: i.Value, Description: i.Description})
+	}
+	return resp
+}
+
+func newWatchTaskResponse(change usecase.TaskChange) *botmgmtv1.WatchTaskResponse {
+	resp := &botmgmtv1.WatchTaskResponse{Task: newTask(change.Task)}
+	if e := change.Event; e != nil {
+		resp.Transition = &botmgmtv1.TaskTransition{
+			FromStatus: statusToProto[e.FromStatus],
+			ToStatus:   statusToProto[e.ToStatus],
+			Actor:      string(e.Actor),
+			Reason:     e.Reason,
+			CreatedAt:  timestamppb.New(e.CreatedAt),
+		}
+	}
+	return resp
+}
+
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	botmgmtv1 "github.com/SKD-fastcampus/bot-management/proto/botmgmt/v1"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TaskHandler implements BotMgmtService on top of usecase.TaskUsecase
+type TaskHandler struct {
+	botmgmtv1.UnimplementedBotMgmtServiceServer
+	usecase usecase.TaskUsecase
+}
+
+func NewTaskHandler(u usecase.TaskUsecase) *TaskHandler {
+	return &TaskHandler{usecase: u}
+}
+
+func (h *TaskHandler) CreateTask(ctx context.Context, req *botmgmtv1.CreateTaskRequest) (*botmgmtv1.CreateTaskResponse, error) {
+	if req.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	opts := usecase.CreateOptions{ForceRefresh: req.ForceRefresh, CallbackURL: req.CallbackUrl}
+	if req.MaxAgeSeconds != nil {
+		if *req.MaxAgeSeconds < 0 {
+			return nil, status.Error(codes.InvalidArgument, "max_age_seconds must not be negative")
+		}
+		maxAge := time.Duration(*req.MaxAgeSeconds) * time.Second
+		opts.MaxAge = &maxAge
+	}
+
+	task, err := h.usecase.CreateTask(ctx, req.Url, req.RequestUuid, req.AnalysisId, OwnerUID(ctx), opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &botmgmtv1.CreateTaskResponse{Task: newTask(task)}, nil
+}
+
+func (h *TaskHandler) GetTask(ctx context.Context, req *botmgmtv1.GetTaskRequest) (*botmgmtv1.GetTaskResponse, error) {
+	id, err := parseTaskID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.usecase.GetTaskStatus(ctx, id, OwnerUID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &botmgmtv1.GetTaskResponse{Task: newTask(task)}, nil
+}
+
+func (h *TaskHandler) ListTasks(ctx context.Context, req *botmgmtv1.ListTasksRequest) (*botmgmtv1.ListTasksResponse, error) {
+	filter := domain.TaskFilter{
+		URLContains: req.UrlContains,
+		AnalysisID:  req.AnalysisId,
+		CreatedFrom: timeFromProto(req.CreatedFrom),
+		CreatedTo:   timeFromProto(req.CreatedTo),
+	}
+	for _, s := range req.Statuses {
+		st, ok := statusFromProto[s]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown status %s", s)
+		}
+		filter.Statuses = append(filter.Statuses, st)
+	}
+	if req.MinRetryCount != nil {
+		n := int(*req.MinRetryCount)
+		filter.MinRetryCount = &n
+	}
+	if req.MaxRetryCount != nil {
+		n := int(*req.MaxRetryCount)
+		filter.MaxRetryCount = &n
+	}
+
+	sortBy, ok := sortFieldFromProto[req.SortBy]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort field %s", req.SortBy)
+	}
+	page := domain.Page{
+		SortBy: sortBy,
+		Desc:   !req.Ascending,
+		Limit:  int(req.PageSize),
+		Cursor: req.PageToken,
+	}
+
+	result, err := h.usecase.ListTasks(ctx, OwnerUID(ctx), filter, page)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &botmgmtv1.ListTasksResponse{NextPageToken: result.NextCursor}
+	for _, t := range result.Tasks {
+		resp.Tasks = append(resp.Tasks, newTask(t))
+	}
+	return resp, nil
+}
+
+func (h *TaskHandler) CancelTask(ctx context.Context, req *botmgmtv1.CancelTaskRequest) (*botmgmtv1.CancelTaskResponse, error) {
+	id, err := parseTaskID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.usecase.CancelTask(ctx, id, OwnerUID(ctx), req.Reason)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &botmgmtv1.CancelTaskResponse{Task: newTask(task)}, nil
+}
+
+func (h *TaskHandler) WatchTask(req *botmgmtv1.WatchTaskRequest, stream botmgmtv1.BotMgmtService_WatchTaskServer) error {
+	id, err := parseTaskID(req.Id)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	changes, err := h.usecase.WatchTask(ctx, id, OwnerUID(ctx))
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for change := range changes {
+		if err := stream.Send(newWatchTaskResponse(change)); err != nil {
+			return err
+		}
+	}
+	// The stream ended before a terminal status because the client went away
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+func parseTaskID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid task ID")
+	}
+	return id, nil
+}
+
+// toStatus maps usecase errors to gRPC status errors, as the REST handler maps them to HTTP statuses
+func toStatus(err error) error {
+	var invalid *domain.InvalidTransitionError
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, urlcanon.ErrInvalidURL), errors.Is(err, domain.ErrInvalidCallbackURL), errors.Is(err, domain.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &invalid), errors.Is(err, domain.ErrTransitionConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
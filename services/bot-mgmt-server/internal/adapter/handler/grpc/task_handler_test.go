@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{domain.ErrTaskNotFound, codes.NotFound},
+		{fmt.Errorf("create: %w", urlcanon.ErrInvalidURL), codes.InvalidArgument},
+		{domain.ErrInvalidCallbackURL, codes.InvalidArgument},
+		{domain.ErrInvalidQuery, codes.InvalidArgument},
+		{&domain.InvalidTransitionError{From: domain.TaskStatusCompleted, To: domain.TaskStatusCancelled}, codes.FailedPrecondition},
+		{domain.ErrTransitionConflict, codes.FailedPrecondition},
+		{errors.New("db down"), codes.Internal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tc.code, status.Code(toStatus(tc.err)))
+		})
+	}
+}
+
+func TestParseTaskID(t *testing.T) {
+	_, err := parseTaskID("not-a-uuid")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	id, err := parseTaskID("6f1c2a4e-2b8e-4f57-9a0e-3c7d9a1b2c3d")
+	assert.NoError(t, err)
+	assert.Equal(t, "6f1c2a4e-2b8e-4f57-9a0e-3c7d9a1b2c3d", id.String())
+}
+
+func TestStatusMappingIsComplete(t *testing.T) {
+	for s, p := range statusToProto {
+		assert.Equal(t, s, statusFromProto[p])
+	}
+	assert.Len(t, statusFromProto, len(statusToProto))
+}
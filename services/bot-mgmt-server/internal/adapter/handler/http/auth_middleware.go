@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/firebase"
+	"github.com/labstack/echo/v4"
+)
+
+// ownerUIDKey is the echo context key holding the verified Firebase UID
+const ownerUIDKey = "owner_uid"
+
+// AuthMiddleware verifies the Firebase ID token in the "Authorization: Bearer <token>" header
+// and stores the caller's UID in the echo context.
+func AuthMiddleware(verifier firebase.TokenVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			idToken, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || idToken == "" {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing bearer token"})
+			}
+
+			token, err := verifier.VerifyIDToken(c.Request().Context(), idToken)
+			if err != nil || token == nil || token.UID == "" {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid firebase token"})
+			}
+
+			c.Set(ownerUIDKey, token.UID)
+			return next(c)
+		}
+	}
+}
+
+// OwnerUID returns the UID verified by AuthMiddleware, or "" on unauthenticated routes
+func OwnerUID(c echo.Context) string {
+	uid, _ := c.Get(ownerUIDKey).(string)
+	return uid
+}
@@ -0,0 +1,54 @@
+package http_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"firebase.google.com/go/v4/auth"
+	httpHandler "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/handler/http"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	verifier := new(mocks.MockTokenVerifier)
+	verifier.On("VerifyIDToken", mock.Anything, "good-token").Return(&auth.Token{UID: "user-1"}, nil)
+	verifier.On("VerifyIDToken", mock.Anything, "bad-token").Return(nil, errors.New("expired"))
+
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, httpHandler.OwnerUID(c))
+	}, httpHandler.AuthMiddleware(verifier))
+
+	cases := []struct {
+		name   string
+		header string
+		code   int
+		body   string
+	}{
+		{"valid token", "Bearer good-token", http.StatusOK, "user-1"},
+		{"invalid token", "Bearer bad-token", http.StatusUnauthorized, ""},
+		{"missing header", "", http.StatusUnauthorized, ""},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.body != "" {
+				assert.Equal(t, tc.body, rec.Body.String())
+			}
+		})
+	}
+}
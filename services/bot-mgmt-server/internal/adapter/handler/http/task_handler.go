@@ -1,11 +1,16 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -19,30 +24,49 @@ func NewTaskHandler(u usecase.TaskUsecase) *TaskHandler {
 	return &TaskHandler{usecase: u}
 }
 
-// RegisterRoutes registers the task routes with the echo group
-func (h *TaskHandler) RegisterRoutes(g *echo.Group) {
-	g.POST("/analyze", h.CreateTask)
-	g.GET("/status/:id", h.GetStatus)
-	g.POST("/webhook", h.HandleWebhook)
+// RegisterRoutes registers the task routes with the echo group.
+// auth guards every route called by end users; webhookAuth guards the webhook, which is called by bots.
+func (h *TaskHandler) RegisterRoutes(g *echo.Group, auth, webhookAuth echo.MiddlewareFunc) {
+	g.POST("/analyze", h.CreateTask, auth)
+	g.POST("/analyze/batch", h.CreateBatch, auth)
+	g.GET("/batches/:id", h.GetBatch, auth)
+	g.GET("/status/:id", h.GetStatus, auth)
+	g.GET("/tasks", h.ListTasks, auth)
+	g.POST("/webhook", h.HandleWebhook, webhookAuth)
+	g.DELETE("/tasks/:id", h.CancelTask, auth)
+	g.GET("/tasks/:id/events", h.StreamTaskEvents, auth)
+	g.GET("/tasks/:id/ws", h.StreamTaskEventsWS, auth)
+	g.GET("/tasks/:id/callbacks", h.ListCallbackDeliveries, auth)
+	g.POST("/tasks/:id/callbacks/redeliver", h.RedeliverCallback, auth)
 }
 
 type CreateTaskRequest struct {
-	URL           string `json:"url"`
-	FirebaseToken string `json:"firebase_token"`
-	AnalysisID    string `json:"analysis_id"`
-	RequestUUID   string `json:"request_uuid"` // Optional/Legacy
+	URL         string `json:"url"`
+	AnalysisID  string `json:"analysis_id"`
+	RequestUUID string `json:"request_uuid"` // Optional/Legacy
+	// MaxAge is the oldest cached result in seconds this request accepts; defaults to cache.max_age_seconds, 0 disables the cache
+	MaxAge *int `json:"max_age,omitempty"`
+	// ForceRefresh launches a new analysis even if a cached result exists
+	ForceRefresh bool `json:"force_refresh,omitempty"`
+	// CallbackURL receives a signed POST with the outcome once the task finishes; its host must be on callback.allowed_hosts
+	CallbackURL string `json:"callback_url,omitempty"`
 }
 
 // CreateTask godoc
 
 // @Summary Create a new analysis task
-// @Description Initiates a new smishing analysis task for a given URL
+// @Description Initiates a new smishing analysis task for a given URL. If the URL was analysed recently,
+// @Description the task is returned already COMPLETED with that result (from_cache).
+// @Description With callback_url, the outcome is POSTed there when the task finishes, signed like the webhook
+// @Description (X-Webhook-* headers) with the current callback key.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param request body CreateTaskRequest true "Create Task Request"
-// @Success 202 {object} domain.AnalysisTask
+// @Security BearerAuth
+// @Success 202 {object} TaskResponse
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /analyze [post]
 func (h *TaskHandler) CreateTask(c echo.Context) error {
@@ -64,14 +88,125 @@ func (h *TaskHandler) CreateTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "URL must use http or https scheme"})
 	}
 
-	req.FirebaseToken = strings.TrimPrefix(req.FirebaseToken, "Bearer ")
+	opts, err := createOptions(req.MaxAge, req.ForceRefresh, req.CallbackURL)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	task, err := h.usecase.CreateTask(c.Request().Context(), req.URL, req.RequestUUID, req.AnalysisID, OwnerUID(c), opts)
+	if err != nil {
+		if errors.Is(err, urlcanon.ErrInvalidURL) || errors.Is(err, domain.ErrInvalidCallbackURL) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, newTaskResponse(task))
+}
+
+// createOptions converts the cache and callback settings of a create request
+func createOptions(maxAgeSeconds *int, forceRefresh bool, callbackURL string) (usecase.CreateOptions, error) {
+	opts := usecase.CreateOptions{ForceRefresh: forceRefresh, CallbackURL: callbackURL}
+	if maxAgeSeconds != nil {
+		if *maxAgeSeconds < 0 {
+			return opts, errors.New("max_age must not be negative")
+		}
+		maxAge := time.Duration(*maxAgeSeconds) * time.Second
+		opts.MaxAge = &maxAge
+	}
+	return opts, nil
+}
+
+type BatchItemRequest struct {
+	URL         string `json:"url"`
+	AnalysisID  string `json:"analysis_id"`
+	RequestUUID string `json:"request_uuid,omitempty"`
+}
+
+type CreateBatchRequest struct {
+	Items []BatchItemRequest `json:"items"`
+	// MaxAge, ForceRefresh and CallbackURL apply to every item, as in CreateTaskRequest
+	MaxAge       *int   `json:"max_age,omitempty"`
+	ForceRefresh bool   `json:"force_refresh,omitempty"`
+	CallbackURL  string `json:"callback_url,omitempty"`
+}
+
+// CreateBatch godoc
+// @Summary Submit several URLs
+// @Description Creates a task per URL. Items succeed or fail individually; URLs that canonicalize to an earlier
+// @Description item's URL share its task (duplicate). Returns 400 if no item could be submitted.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body CreateBatchRequest true "Create Batch Request"
+// @Security BearerAuth
+// @Success 202 {object} BatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /analyze/batch [post]
+func (h *TaskHandler) CreateBatch(c echo.Context) error {
+	var req CreateBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	opts, err := createOptions(req.MaxAge, req.ForceRefresh, req.CallbackURL)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	items := make([]usecase.BatchItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, usecase.BatchItem{URL: item.URL, AnalysisID: item.AnalysisID, RequestUUID: item.RequestUUID})
+	}
 
-	task, err := h.usecase.CreateTask(c.Request().Context(), req.URL, req.RequestUUID, req.FirebaseToken, req.AnalysisID)
+	result, err := h.usecase.CreateBatch(c.Request().Context(), OwnerUID(c), items, opts)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBatch) {
+			body := map[string]any{"error": err.Error()}
+			if result != nil {
+				body["items"] = newBatchResponse(result).Items
+			}
+			return c.JSON(http.StatusBadRequest, body)
+		}
+		if errors.Is(err, domain.ErrInvalidCallbackURL) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusAccepted, task)
+	return c.JSON(http.StatusAccepted, newBatchResponse(result))
+}
+
+// GetBatch godoc
+// @Summary Get batch progress
+// @Description Aggregate status of the tasks of a batch
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Batch ID" format(uuid)
+// @Security BearerAuth
+// @Success 200 {object} BatchProgressResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /batches/{id} [get]
+func (h *TaskHandler) GetBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid batch ID"})
+	}
+
+	progress, err := h.usecase.GetBatchProgress(c.Request().Context(), id, OwnerUID(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrBatchNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, newBatchProgressResponse(progress))
 }
 
 // GetStatus godoc
@@ -80,8 +215,11 @@ func (h *TaskHandler) CreateTask(c echo.Context) error {
 // @Tags tasks
 // @Produce json
 // @Param id path string true "Task ID" format(uuid)
-// @Success 200 {object} domain.AnalysisTask
+// @Security BearerAuth
+// @Success 200 {object} TaskResponse
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /status/{id} [get]
 func (h *TaskHandler) GetStatus(c echo.Context) error {
@@ -91,31 +229,145 @@ func (h *TaskHandler) GetStatus(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
 	}
 
-	task, err := h.usecase.GetTaskStatus(c.Request().Context(), id)
+	task, err := h.usecase.GetTaskStatus(c.Request().Context(), id, OwnerUID(c))
 	if err != nil {
-		// Differentiate between 404 and 500
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, task)
+	return c.JSON(http.StatusOK, newTaskResponse(task))
+}
+
+// ListTasks godoc
+// @Summary List tasks
+// @Description List the caller's tasks, newest first by default. Pass next_cursor back as cursor to get the following page.
+// @Tags tasks
+// @Produce json
+// @Param status query string false "Comma-separated statuses, e.g. FAILED,DEAD_LETTER"
+// @Param url query string false "Case-insensitive URL substring, e.g. a domain"
+// @Param analysis_id query string false "Search Server analysis ID"
+// @Param created_from query string false "Inclusive lower bound on created_at (RFC 3339)"
+// @Param created_to query string false "Exclusive upper bound on created_at (RFC 3339)"
+// @Param min_retry_count query int false "Minimum retry count"
+// @Param max_retry_count query int false "Maximum retry count"
+// @Param sort query string false "Sort field" Enums(created_at, updated_at, retry_count) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param limit query int false "Page size (max 200)" default(50)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Security BearerAuth
+// @Success 200 {object} TaskListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks [get]
+func (h *TaskHandler) ListTasks(c echo.Context) error {
+	filter, page, err := parseListQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.usecase.ListTasks(c.Request().Context(), OwnerUID(c), filter, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, newTaskListResponse(result))
+}
+
+// parseListQuery reads the ListTasks query parameters. Range and enum checks are left to
+// the domain so every caller of TaskRepository.List gets the same validation.
+func parseListQuery(c echo.Context) (domain.TaskFilter, domain.Page, error) {
+	filter := domain.TaskFilter{
+		URLContains: c.QueryParam("url"),
+		AnalysisID:  c.QueryParam("analysis_id"),
+	}
+	page := domain.Page{
+		SortBy: domain.SortByCreatedAt,
+		Desc:   true,
+		Cursor: c.QueryParam("cursor"),
+	}
+
+	if v := c.QueryParam("status"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			filter.Statuses = append(filter.Statuses, domain.TaskStatus(strings.ToUpper(strings.TrimSpace(s))))
+		}
+	}
+
+	for name, dst := range map[string]**time.Time{"created_from": &filter.CreatedFrom, "created_to": &filter.CreatedTo} {
+		if v := c.QueryParam(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, page, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+			}
+			*dst = &t
+		}
+	}
+
+	for name, dst := range map[string]**int{"min_retry_count": &filter.MinRetryCount, "max_retry_count": &filter.MaxRetryCount} {
+		if v := c.QueryParam(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return filter, page, fmt.Errorf("%s must be an integer", name)
+			}
+			*dst = &n
+		}
+	}
+
+	if v := c.QueryParam("sort"); v != "" {
+		page.SortBy = domain.TaskSortField(v)
+	}
+	switch c.QueryParam("order") {
+	case "", "desc":
+	case "asc":
+		page.Desc = false
+	default:
+		return filter, page, errors.New("order must be asc or desc")
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return filter, page, errors.New("limit must be a positive integer")
+		}
+		page.Limit = n
+	}
+
+	return filter, page, nil
 }
 
 type WebhookRequest struct {
-	TaskID string            `json:"task_id"` // Matches our internal ID
+	TaskID string `json:"task_id"` // Matches our internal ID
+	// Status is RUNNING, COMPLETED or FAILED; other statuses are set by the server only
 	Status domain.TaskStatus `json:"status"`
 	Result string            `json:"result"`
+	// ExitCode is the bot's exit code for FAILED reports; codes listed in
+	// retry.permanent_exit_codes move the task to DEAD_LETTER instead of retrying it
+	ExitCode *int `json:"exit_code,omitempty"`
+	// AnalysisResult is the structured result, accepted only with status COMPLETED
+	AnalysisResult *domain.AnalysisResult `json:"analysis_result,omitempty"`
 }
 
 // HandleWebhook godoc
 
 // @Summary Handle webhook update
-// @Description Update task status via webhook (Internal use)
+// @Description Update task status via webhook (Internal use). Requests must be signed with the task's
+// @Description WEBHOOK_SECRET: X-Webhook-Signature = "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + nonce + "." + body)).
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param request body WebhookRequest true "Webhook Request"
+// @Param X-Webhook-Key-Id header string true "WEBHOOK_KEY_ID given to the bot"
+// @Param X-Webhook-Timestamp header string true "Unix time in seconds"
+// @Param X-Webhook-Nonce header string true "Unique per request, at most 128 bytes"
+// @Param X-Webhook-Signature header string true "sha256=<hex HMAC>"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /webhook [post]
 func (h *TaskHandler) HandleWebhook(c echo.Context) error {
@@ -129,7 +381,20 @@ func (h *TaskHandler) HandleWebhook(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
 	}
 
-	if err := h.usecase.UpdateTaskStatus(c.Request().Context(), id, req.Status, req.Result); err != nil {
+	update := usecase.StatusUpdate{
+		Status:         req.Status,
+		Result:         req.Result,
+		ExitCode:       req.ExitCode,
+		AnalysisResult: req.AnalysisResult,
+	}
+	if err := h.usecase.UpdateTaskStatus(c.Request().Context(), id, update, domain.ActorWebhook); err != nil {
+		var invalid *domain.InvalidTransitionError
+		if errors.As(err, &invalid) || errors.Is(err, domain.ErrTransitionConflict) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, domain.ErrInvalidResult) || errors.Is(err, domain.ErrUnreportableStatus) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -139,3 +404,109 @@ func (h *TaskHandler) HandleWebhook(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Status updated"})
 }
+
+// CancelTask godoc
+// @Summary Cancel a task
+// @Description Marks the task CANCELLED and stops the running bot. Finished tasks cannot be cancelled.
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID" format(uuid)
+// @Param reason query string false "Cancellation reason"
+// @Security BearerAuth
+// @Success 200 {object} TaskResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id} [delete]
+func (h *TaskHandler) CancelTask(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
+	}
+
+	task, err := h.usecase.CancelTask(c.Request().Context(), id, OwnerUID(c), c.QueryParam("reason"))
+	if err != nil {
+		var invalid *domain.InvalidTransitionError
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.As(err, &invalid), errors.Is(err, domain.ErrTransitionConflict):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, newTaskResponse(task))
+}
+
+// ListCallbackDeliveries godoc
+// @Summary List callback deliveries
+// @Description The deliveries of the task's outcome to its callback_url, oldest first
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID" format(uuid)
+// @Security BearerAuth
+// @Success 200 {array} CallbackDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id}/callbacks [get]
+func (h *TaskHandler) ListCallbackDeliveries(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
+	}
+
+	deliveries, err := h.usecase.ListCallbackDeliveries(c.Request().Context(), id, OwnerUID(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	resp := make([]CallbackDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, newCallbackDeliveryResponse(d))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RedeliverCallback godoc
+// @Summary Redeliver the callback
+// @Description Queues a new delivery of the finished task's outcome to its callback_url
+// @Tags tasks
+// @Produce json
+// @Param id path string true "Task ID" format(uuid)
+// @Security BearerAuth
+// @Success 202 {object} CallbackDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id}/callbacks/redeliver [post]
+func (h *TaskHandler) RedeliverCallback(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
+	}
+
+	delivery, err := h.usecase.RedeliverCallback(c.Request().Context(), id, OwnerUID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, domain.ErrCallbackNotDeliverable):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusAccepted, newCallbackDeliveryResponse(delivery))
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+)
+
+// TaskResponse is the API representation of an analysis task.
+// Owner and executor details stay internal.
+type TaskResponse struct {
+	ID               string                  `json:"id"`
+	RequestUUID      string                  `json:"request_uuid,omitempty"`
+	AnalysisID       string                  `json:"analysis_id,omitempty"`
+	URL              string                  `json:"url"`
+	CanonicalURL     string                  `json:"canonical_url,omitempty"`
+	RegisteredDomain string                  `json:"registered_domain,omitempty"`
+	Status           domain.TaskStatus       `json:"status"`
+	FromCache        bool                    `json:"from_cache,omitempty"` // Result reused from a recent analysis of the same URL
+	CallbackURL      string                  `json:"callback_url,omitempty"`
+	RetryCount       int                     `json:"retry_count"`
+	NextAttemptAt    *time.Time              `json:"next_attempt_at,omitempty"`
+	Result           string                  `json:"result,omitempty"`
+	AnalysisResult   *AnalysisResultResponse `json:"analysis_result,omitempty"`
+	StartedAt        *time.Time              `json:"started_at,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+// TaskListResponse is one page of a task listing
+type TaskListResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Absent on the last page
+}
+
+// BatchResponse is the outcome of a batch submission
+type BatchResponse struct {
+	BatchID string              `json:"batch_id"`
+	Items   []BatchItemResponse `json:"items"` // In submission order
+}
+
+// BatchItemResponse is the outcome of one submitted URL: a task or an error
+type BatchItemResponse struct {
+	Index     int           `json:"index"`
+	Task      *TaskResponse `json:"task,omitempty"`
+	Duplicate bool          `json:"duplicate,omitempty"` // Same canonical URL as an earlier item
+	Error     string        `json:"error,omitempty"`
+}
+
+// BatchProgressResponse aggregates the statuses of a batch's tasks
+type BatchProgressResponse struct {
+	BatchID      string                    `json:"batch_id"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	Total        int                       `json:"total"`
+	Active       int                       `json:"active"` // Still in flight or due to be retried
+	Done         bool                      `json:"done"`
+	StatusCounts map[domain.TaskStatus]int `json:"status_counts"`
+}
+
+// CallbackDeliveryResponse is one delivery of a task's outcome to its callback URL
+type CallbackDeliveryResponse struct {
+	ID             string                `json:"id"`
+	URL            string                `json:"url"`
+	Status         domain.CallbackStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseStatus int                   `json:"response_status,omitempty"` // HTTP status of the last attempt
+	LastError      string                `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// AnalysisResultResponse is the API representation of a structured analysis result
+type AnalysisResultResponse struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Verdict       domain.Verdict      `json:"verdict"`
+	Confidence    float64             `json:"confidence"`
+	FinalURL      string              `json:"final_url,omitempty"`
+	FinalDomain   string              `json:"final_domain,omitempty"`
+	RedirectChain []string            `json:"redirect_chain,omitempty"`
+	PageTitle     string              `json:"page_title,omitempty"`
+	DetectedBrand string              `json:"detected_brand,omitempty"`
+	Artifacts     []ArtifactResponse  `json:"artifacts,omitempty"`
+	Indicators    []IndicatorResponse `json:"indicators,omitempty"`
+}
+
+type ArtifactResponse struct {
+	Kind domain.ArtifactKind `json:"kind"`
+	URI  string              `json:"uri"`
+}
+
+type IndicatorResponse struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+func newTaskResponse(t *domain.AnalysisTask) TaskResponse {
+	return TaskResponse{
+		ID:               t.ID.String(),
+		RequestUUID:      t.RequestUUID,
+		AnalysisID:       t.AnalysisID,
+		URL:              t.URL,
+		CanonicalURL:     t.CanonicalURL,
+		RegisteredDomain: t.RegisteredDomain,
+		Status:           t.Status,
+		FromCache:        t.FromCache,
+		CallbackURL:      t.CallbackURL,
+		RetryCount:       t.RetryCount,
+		NextAttemptAt:    t.NextAttemptAt,
+		Result:           t.Result,
+		AnalysisResult:   newAnalysisResultResponse(t.AnalysisResult),
+		StartedAt:        t.StartedAt,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
+
+func newTaskListResponse(p *domain.TaskPage) TaskListResponse {
+	resp := TaskListResponse{Tasks: make([]TaskResponse, 0, len(p.Tasks)), NextCursor: p.NextCursor}
+	for _, t := range p.Tasks {
+		resp.Tasks = append(resp.Tasks, newTaskResponse(t))
+	}
+	return resp
+}
+
+func newBatchResponse(r *usecase.BatchResult) BatchResponse {
+	var resp BatchResponse
+	if r.Batch != nil {
+		resp.BatchID = r.Batch.ID.String()
+	}
+	for i, item := range r.Items {
+		itemResp := BatchItemResponse{Index: i, Duplicate: item.Duplicate}
+		if item.Err != nil {
+			itemResp.Error = item.Err.Error()
+		} else {
+			task := newTaskResponse(item.Task)
+			itemResp.Task = &task
+		}
+		resp.Items = append(resp.Items, itemResp)
+	}
+	return resp
+}
+
+func newBatchProgressResponse(p *domain.BatchProgress) BatchProgressResponse {
+	return BatchProgressResponse{
+		BatchID:      p.Batch.ID.String(),
+		CreatedAt:    p.Batch.CreatedAt,
+		Total:        p.Total,
+		Active:       p.Active,
+		Done:         p.Done(),
+		StatusCounts: p.StatusCounts,
+	}
+}
+
+func newCallbackDeliveryResponse(d *domain.CallbackDelivery) CallbackDeliveryResponse {
+	return CallbackDeliveryResponse{
+		ID:             d.ID.String(),
+		URL:            d.URL,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func newAnalysisResultResponse(r *domain.AnalysisResult) *AnalysisResultResponse {
+	if r == nil {
+		return nil
+	}
+
+	resp := &AnalysisResultResponse{
+		SchemaVersion: r.SchemaVersion,
+		Verdict:       r.Verdict,
+		Confidence:    r.Confidence,
+		FinalURL:      r.FinalURL,
+		FinalDomain:   r.FinalDomain,
+		RedirectChain: r.RedirectChain,
+		PageTitle:     r.PageTitle,
+		DetectedBrand: r.DetectedBrand,
+	}
+	for _, a := range r.Artifacts {
+		resp.Artifacts = append(resp.Artifacts, ArtifactResponse{Kind: a.Kind, URI: a.URI})
+	}
+	for _, i := range r.Indicators {
+		resp.Indicators = append(resp.Indicators, IndicatorResponse{Type: i.Type, Value: i.Value, Description: i.Description})
+	}
+	return resp
+}
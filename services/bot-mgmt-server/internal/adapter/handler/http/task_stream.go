@@ -0,0 +1,203 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// streamHeartbeat keeps idle streams from being closed by proxies
+const streamHeartbeat = 15 * time.Second
+
+// Stream message types: "status" for every change, "result" for the final one
+const (
+	streamStatus = "status"
+	streamResult = "result"
+)
+
+// TaskChangeResponse is one message of a task event stream
+type TaskChangeResponse struct {
+	Task       TaskResponse        `json:"task"`
+	Transition *TransitionResponse `json:"transition,omitempty"` // Absent for the initial snapshot
+}
+
+// TransitionResponse is the status transition that caused a TaskChangeResponse
+type TransitionResponse struct {
+	FromStatus domain.TaskStatus `json:"from_status"`
+	ToStatus   domain.TaskStatus `json:"to_status"`
+	Actor      domain.TaskActor  `json:"actor"`
+	Reason     string            `json:"reason,omitempty"`
+	At         time.Time         `json:"at"`
+}
+
+// WebSocketMessage wraps a TaskChangeResponse on the WebSocket stream
+type WebSocketMessage struct {
+	Type string             `json:"type"` // "status" or "result"
+	Data TaskChangeResponse `json:"data"`
+}
+
+func newTaskChangeResponse(change usecase.TaskChange) (string, TaskChangeResponse) {
+	resp := TaskChangeResponse{Task: newTaskResponse(change.Task)}
+	if e := change.Event; e != nil {
+		resp.Transition = &TransitionResponse{
+			FromStatus: e.FromStatus,
+			ToStatus:   e.ToStatus,
+			Actor:      e.Actor,
+			Reason:     e.Reason,
+			At:         e.CreatedAt,
+		}
+	}
+	if change.Task.Status.IsTerminal() {
+		return streamResult, resp
+	}
+	return streamStatus, resp
+}
+
+// watchTask starts watching the task in the :id path parameter, or writes the error response
+func (h *TaskHandler) watchTask(ctx context.Context, c echo.Context) (<-chan usecase.TaskChange, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid task ID"})
+	}
+
+	changes, err := h.usecase.WatchTask(ctx, id, OwnerUID(c))
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return nil, c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return changes, nil
+}
+
+// StreamTaskEvents godoc
+// @Summary Stream task status (SSE)
+// @Description Server-Sent Events stream of a task: the current state, then a "status" event for every change.
+// @Description The last event is "result" once the task reaches a terminal status; the stream then ends.
+// @Description Comment lines are sent as heartbeats. Requires the Authorization header, so browsers need an
+// @Description EventSource implementation that can set headers.
+// @Tags tasks
+// @Produce text/event-stream
+// @Param id path string true "Task ID" format(uuid)
+// @Security BearerAuth
+// @Success 200 {object} TaskChangeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id}/events [get]
+func (h *TaskHandler) StreamTaskEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	changes, err := h.watchTask(ctx, c)
+	if changes == nil {
+		return err
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable response buffering in nginx
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case change, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			event, resp := newTaskChangeResponse(change)
+			data, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+				return nil
+			}
+		}
+		w.Flush()
+	}
+}
+
+// StreamTaskEventsWS godoc
+// @Summary Stream task status (WebSocket)
+// @Description WebSocket alternative to /tasks/{id}/events. Each text message is a WebSocketMessage;
+// @Description the server closes the connection after the "result" message. Client messages are ignored.
+// @Tags tasks
+// @Param id path string true "Task ID" format(uuid)
+// @Security BearerAuth
+// @Success 101 {object} WebSocketMessage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id}/ws [get]
+func (h *TaskHandler) StreamTaskEventsWS(c echo.Context) error {
+	// The request context is not cancelled when a hijacked client disconnects, so the
+	// watch is ended by the reader below instead
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.Request().Context()))
+	defer cancel()
+
+	changes, err := h.watchTask(ctx, c)
+	if changes == nil {
+		return err
+	}
+
+	// Authentication is by bearer token rather than cookies, so cross-origin
+	// connections carry no ambient credentials and the origin is not checked
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		// Reading is the only way to notice the client going away
+		go func() {
+			var discard string
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+			cancel()
+		}()
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-heartbeat.C:
+				ws.PayloadType = websocket.PingFrame
+				if _, err := ws.Write(nil); err != nil {
+					return
+				}
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				event, resp := newTaskChangeResponse(change)
+				if err := websocket.JSON.Send(ws, WebSocketMessage{Type: event, Data: resp}); err != nil {
+					return
+				}
+			}
+		}
+	}}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/hmacauth"
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBody bounds the webhook body read for signature verification
+const maxWebhookBody = 1 << 20
+
+// WebhookAuthMiddleware verifies the HMAC signature headers of bot webhook requests.
+// The signing secret is derived from the task ID in the body, so the body is read here
+// and restored for the handler. A nil verifier rejects every request.
+func WebhookAuthMiddleware(verifier *hmacauth.Verifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if verifier == nil {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Webhook signing is not configured"})
+			}
+
+			req := c.Request()
+			body, err := io.ReadAll(io.LimitReader(req.Body, maxWebhookBody+1))
+			if err != nil || len(body) > maxWebhookBody {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid webhook payload"})
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			var payload struct {
+				TaskID string `json:"task_id"`
+			}
+			if err := json.Unmarshal(body, &payload); err != nil || payload.TaskID == "" {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid webhook payload"})
+			}
+
+			if err := verifier.Verify(req.Context(), payload.TaskID, req.Header, body); err != nil {
+				if !errors.Is(err, hmacauth.ErrInvalidSignature) {
+					return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+				}
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid webhook signature"})
+			}
+			return next(c)
+		}
+	}
+}
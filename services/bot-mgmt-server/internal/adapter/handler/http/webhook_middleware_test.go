@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	httpHandler "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/handler/http"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/hmacauth"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookAuthMiddleware(t *testing.T) {
+	keyring, err := hmacauth.NewKeyring(map[string]string{"k1": "secret"}, "k1")
+	require.NoError(t, err)
+
+	newServer := func(verifier *hmacauth.Verifier) *echo.Echo {
+		e := echo.New()
+		e.POST("/webhook", func(c echo.Context) error {
+			// The handler must still be able to bind the body after verification
+			var req httpHandler.WebhookRequest
+			if err := c.Bind(&req); err != nil {
+				return err
+			}
+			return c.String(http.StatusOK, req.TaskID)
+		}, httpHandler.WebhookAuthMiddleware(verifier))
+		return e
+	}
+
+	body := `{"task_id":"task-1","status":"COMPLETED"}`
+	send := func(e *echo.Echo, nonce string, sign bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if sign {
+			secret, _ := keyring.TaskSecret("k1", "task-1")
+			ts := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set(hmacauth.HeaderKeyID, "k1")
+			req.Header.Set(hmacauth.HeaderTimestamp, ts)
+			req.Header.Set(hmacauth.HeaderNonce, nonce)
+			req.Header.Set(hmacauth.HeaderSignature, hmacauth.Sign(secret, ts, nonce, []byte(body)))
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	nonces := new(mocks.MockNonceRepository)
+	nonces.On("Remember", mock.Anything, "k1", "n1", mock.Anything, mock.Anything).Return(true, nil).Once()
+	nonces.On("Remember", mock.Anything, "k1", "n1", mock.Anything, mock.Anything).Return(false, nil)
+	nonces.On("Remember", mock.Anything, "k1", "n4", mock.Anything, mock.Anything).Return(false, errors.New("connection refused"))
+	e := newServer(hmacauth.NewVerifier(keyring, nonces, time.Minute))
+
+	rec := send(e, "n1", true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "task-1", rec.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, send(e, "n1", true).Code, "replayed nonce")
+	assert.Equal(t, http.StatusUnauthorized, send(e, "n2", false).Code, "unsigned")
+	assert.Equal(t, http.StatusUnauthorized, send(newServer(nil), "n3", true).Code, "signing not configured")
+	assert.Equal(t, http.StatusInternalServerError, send(e, "n4", true).Code, "nonce store unavailable")
+}
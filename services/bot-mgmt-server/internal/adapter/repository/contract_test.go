@@ -0,0 +1,686 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/adapter/repository"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// The contract tests run the same cases against every database the repositories support.
+// SQLite always runs; Postgres and MySQL run when a DSN for a disposable database is set:
+//
+//	BOT_MGMT_TEST_POSTGRES_DSN="host=localhost user=postgres password=password dbname=bot_test sslmode=disable"
+//	BOT_MGMT_TEST_MYSQL_DSN="root:password@tcp(localhost:3306)/bot_test?parseTime=True"
+//
+// Their tables are emptied before every case.
+
+const maxRetries = 3
+
+// tables in deletion order, children first
+var tables = []string{"analysis_results", "task_events", "task_batch_items", "task_batches",
+	"callback_deliveries", "leader_leases", "webhook_nonces", "analysis_tasks"}
+
+type backend struct {
+	name string
+	open func(t *testing.T) *gorm.DB
+}
+
+func backends() []backend {
+	bs := []backend{{name: "sqlite", open: func(t *testing.T) *gorm.DB {
+		database, err := db.OpenSQLite(":memory:")
+		require.NoError(t, err)
+		migrate(t, database)
+		return database
+	}}}
+
+	if dsn := os.Getenv("BOT_MGMT_TEST_POSTGRES_DSN"); dsn != "" {
+		bs = append(bs, backend{name: "postgres", open: sharedDB(postgres.Open(dsn))})
+	}
+	if dsn := os.Getenv("BOT_MGMT_TEST_MYSQL_DSN"); dsn != "" {
+		bs = append(bs, backend{name: "mysql", open: sharedDB(mysql.Open(dsn))})
+	}
+	return bs
+}
+
+func sharedDB(dialector gorm.Dialector) func(t *testing.T) *gorm.DB {
+	return func(t *testing.T) *gorm.DB {
+		database, err := gorm.Open(dialector, &gorm.Config{})
+		require.NoError(t, err)
+		migrate(t, database)
+		for _, table := range tables {
+			require.NoError(t, database.Exec("DELETE FROM "+table).Error)
+		}
+		return database
+	}
+}
+
+func migrate(t *testing.T, database *gorm.DB) {
+	t.Helper()
+	m, err := db.NewMigrator(database, zap.NewNop())
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+}
+
+// eachBackend runs fn as a subtest against a fresh database of every backend
+func eachBackend(t *testing.T, fn func(t *testing.T, database *gorm.DB)) {
+	for _, b := range backends() {
+		t.Run(b.name, func(t *testing.T) {
+			fn(t, b.open(t))
+		})
+	}
+}
+
+// base is a fixed instant in a zone other than UTC, so that time comparisons are
+// exercised across offsets
+var base = time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("KST", 9*60*60))
+
+func newTask(url string, status domain.TaskStatus, createdAt time.Time) *domain.AnalysisTask {
+	return &domain.AnalysisTask{
+		ID:               uuid.New(),
+		URL:              url,
+		CanonicalURL:     url,
+		RegisteredDomain: "example.com",
+		OwnerUID:         "owner-1",
+		Status:           status,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}
+}
+
+func createTasks(t *testing.T, repo domain.TaskRepository, tasks ...*domain.AnalysisTask) {
+	t.Helper()
+	for _, task := range tasks {
+		require.NoError(t, repo.Create(context.Background(), task))
+	}
+}
+
+func ids(tasks []*domain.AnalysisTask) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, t.ID)
+	}
+	return out
+}
+
+func TestTaskRepository_CreateAndGet(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		task := newTask("https://example.com/a", domain.TaskStatusPending, base)
+		task.TraceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+		createTasks(t, repo, task)
+
+		got, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, task.URL, got.URL)
+		assert.Equal(t, domain.TaskStatusPending, got.Status)
+		assert.Equal(t, task.TraceParent, got.TraceParent)
+		assert.True(t, base.Equal(got.CreatedAt), "created_at %s", got.CreatedAt)
+		assert.Nil(t, got.AnalysisResult)
+
+		_, err = repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+	})
+}
+
+func TestTaskRepository_SaveTransition(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+		callbacks := repository.NewGormCallbackRepository(database)
+
+		task := newTask("https://example.com/a", domain.TaskStatusRunning, base)
+		task.CallbackURL = "https://hooks.example.net/done"
+		createTasks(t, repo, task)
+
+		stale := *task
+		event, err := task.Transition(domain.TaskStatusCompleted, domain.ActorWebhook, "done")
+		require.NoError(t, err)
+		task.AnalysisResult = &domain.AnalysisResult{
+			SchemaVersion: domain.AnalysisResultSchemaVersion,
+			Verdict:       domain.VerdictPhishing,
+			Confidence:    0.9,
+			RedirectChain: []string{"https://example.com/a", "https://login.example.net/"},
+		}
+		require.NoError(t, repo.SaveTransition(ctx, task, event))
+
+		got, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCompleted, got.Status)
+		require.NotNil(t, got.AnalysisResult)
+		assert.Equal(t, domain.VerdictPhishing, got.AnalysisResult.Verdict)
+		assert.Equal(t, task.AnalysisResult.RedirectChain, got.AnalysisResult.RedirectChain)
+
+		var events []domain.TaskEvent
+		require.NoError(t, database.Where("task_id = ?", task.ID).Find(&events).Error)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.TaskStatusRunning, events[0].FromStatus)
+
+		last, err := repo.GetLastEvent(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, last)
+		assert.Equal(t, domain.ActorWebhook, last.Actor)
+		last, err = repo.GetLastEvent(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Nil(t, last)
+
+		deliveries, err := callbacks.ListByTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, task.CallbackURL, deliveries[0].URL)
+
+		// A writer holding the old status loses the compare-and-set
+		event, err = stale.Transition(domain.TaskStatusFailed, domain.ActorPoller, "lost")
+		require.NoError(t, err)
+		assert.ErrorIs(t, repo.SaveTransition(ctx, &stale, event), domain.ErrTransitionConflict)
+	})
+}
+
+func TestTaskRepository_MirrorAndHandOver(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		leader := newTask("https://example.com/a", domain.TaskStatusRunning, base)
+		leader.ExternalID = "arn:task/1"
+		first := newTask(leader.URL, domain.TaskStatusRunning, base.Add(time.Second))
+		first.OwnerUID, first.SharedTaskID = "owner-2", &leader.ID
+		second := newTask(leader.URL, domain.TaskStatusRunning, base.Add(2*time.Second))
+		second.OwnerUID, second.SharedTaskID = "owner-3", &leader.ID
+		createTasks(t, repo, leader, first, second)
+
+		event, err := leader.Transition(domain.TaskStatusCancelled, domain.ActorAPI, "cancelled by user")
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveTransition(ctx, leader, event))
+
+		got, err := repo.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusRunning, got.Status, "cancellation is not mirrored")
+
+		successor, err := repo.HandOverSharedTask(ctx, leader)
+		require.NoError(t, err)
+		require.NotNil(t, successor)
+		assert.Equal(t, first.ID, successor.ID, "the oldest follower takes over")
+		assert.Nil(t, successor.SharedTaskID)
+		assert.Equal(t, "arn:task/1", successor.ExternalID)
+
+		got, err = repo.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.SharedTaskID)
+		assert.Equal(t, first.ID, *got.SharedTaskID)
+
+		// The remaining follower now mirrors the successor
+		event, err = successor.Transition(domain.TaskStatusFailed, domain.ActorPoller, "exit 1")
+		require.NoError(t, err)
+		successor.Result = "exit 1"
+		require.NoError(t, repo.SaveTransition(ctx, successor, event))
+
+		got, err = repo.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusFailed, got.Status)
+		assert.Equal(t, "exit 1", got.Result)
+	})
+}
+
+func TestTaskRepository_HandOverBeforeLaunch(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		dispatchedAt := base.Add(time.Minute)
+		leader := newTask("https://example.com/a", domain.TaskStatusDispatching, base)
+		leader.DispatchAttempts, leader.LastDispatchedAt = 1, &dispatchedAt
+		first := newTask(leader.URL, domain.TaskStatusDispatching, base.Add(time.Second))
+		first.OwnerUID, first.SharedTaskID = "owner-2", &leader.ID
+		second := newTask(leader.URL, domain.TaskStatusDispatching, base.Add(2*time.Second))
+		second.OwnerUID, second.SharedTaskID = "owner-3", &leader.ID
+		createTasks(t, repo, leader, first, second)
+
+		event, err := leader.Transition(domain.TaskStatusCancelled, domain.ActorAPI, "cancelled by user")
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveTransition(ctx, leader, event))
+
+		successor, err := repo.HandOverSharedTask(ctx, leader)
+		require.NoError(t, err)
+		require.NotNil(t, successor)
+		assert.Equal(t, first.ID, successor.ID)
+		assert.Equal(t, domain.TaskStatusPending, successor.Status)
+
+		got, err := repo.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusPending, got.Status, "the dispatcher launches the successor again")
+		assert.Nil(t, got.SharedTaskID)
+		assert.Empty(t, got.ExternalID)
+		assert.Zero(t, got.DispatchAttempts)
+		assert.Nil(t, got.LastDispatchedAt)
+
+		last, err := repo.GetLastEvent(ctx, first.ID)
+		require.NoError(t, err)
+		require.NotNil(t, last)
+		assert.Equal(t, domain.TaskStatusDispatching, last.FromStatus)
+		assert.Equal(t, domain.TaskStatusPending, last.ToStatus)
+
+		got, err = repo.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.SharedTaskID)
+		assert.Equal(t, first.ID, *got.SharedTaskID)
+	})
+}
+
+func TestTaskRepository_ClaimPendingTasks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		running := newTask("https://example.com/running", domain.TaskStatusRunning, base)
+		oldest := newTask("https://example.com/1", domain.TaskStatusPending, base.Add(time.Second))
+		capped := newTask("https://example.com/2", domain.TaskStatusPending, base.Add(2*time.Second))
+		other := newTask("https://example.com/3", domain.TaskStatusPending, base.Add(3*time.Second))
+		other.OwnerUID = "owner-2"
+		follower := newTask("https://example.com/1", domain.TaskStatusPending, base)
+		follower.OwnerUID, follower.SharedTaskID = "owner-3", &oldest.ID
+		createTasks(t, repo, running, oldest, capped, other, follower)
+
+		// owner-1 already runs one task, so only one more of theirs fits under the cap of 2
+		claimed, err := repo.ClaimPendingTasks(ctx, domain.ClaimOptions{Limit: 10, MaxInFlight: 10, MaxPerUser: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{oldest.ID, other.ID}, ids(claimed))
+		for _, task := range claimed {
+			assert.Equal(t, domain.TaskStatusDispatching, task.Status)
+			assert.Equal(t, 1, task.DispatchAttempts)
+		}
+
+		got, err := repo.GetByID(ctx, oldest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusDispatching, got.Status)
+		assert.Equal(t, 1, got.DispatchAttempts)
+		assert.NotNil(t, got.LastDispatchedAt)
+
+		// Three tasks are in flight now
+		claimed, err = repo.ClaimPendingTasks(ctx, domain.ClaimOptions{Limit: 10, MaxInFlight: 3})
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		counts, err := repo.CountByStatus(ctx, []domain.TaskStatus{domain.TaskStatusPending, domain.TaskStatusDispatching, domain.TaskStatusRunning})
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.TaskStatus]int{
+			domain.TaskStatusPending:     1,
+			domain.TaskStatusDispatching: 2,
+			domain.TaskStatusRunning:     1,
+		}, counts, "followers are not counted")
+	})
+}
+
+func TestTaskRepository_ClaimPendingTasks_Concurrent(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		var tasks []*domain.AnalysisTask
+		for i := 0; i < 12; i++ {
+			task := newTask(fmt.Sprintf("https://example.com/%d", i), domain.TaskStatusPending, base.Add(time.Duration(i)*time.Second))
+			task.OwnerUID = fmt.Sprintf("owner-%d", i%3)
+			tasks = append(tasks, task)
+		}
+		createTasks(t, repo, tasks...)
+
+		// Dispatchers on several replicas claiming at once must not exceed the caps together
+		const claimers = 4
+		opts := domain.ClaimOptions{Limit: 10, MaxInFlight: 5, MaxPerUser: 2}
+		results := make([][]*domain.AnalysisTask, claimers)
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < claimers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				claimed, err := repo.ClaimPendingTasks(ctx, opts)
+				assert.NoError(t, err)
+				results[i] = claimed
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		total := 0
+		perUser := map[string]int{}
+		for _, claimed := range results {
+			total += len(claimed)
+			for _, task := range claimed {
+				perUser[task.OwnerUID]++
+			}
+		}
+		assert.Equal(t, 5, total)
+		for owner, n := range perUser {
+			assert.LessOrEqual(t, n, 2, owner)
+		}
+	})
+}
+
+func TestTaskRepository_GetFailedTasks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		due := newTask("https://example.com/due", domain.TaskStatusFailed, base)
+		due.NextAttemptAt = ptr(base.Add(time.Minute))
+		later := newTask("https://example.com/later", domain.TaskStatusTimedOut, base)
+		later.NextAttemptAt = ptr(base.Add(time.Hour))
+		exhausted := newTask("https://example.com/exhausted", domain.TaskStatusFailed, base)
+		exhausted.RetryCount = maxRetries
+		legacy := newTask("https://example.com/legacy", domain.TaskStatusTimedOut, base)
+		createTasks(t, repo, due, later, exhausted, legacy)
+
+		// now is given in UTC while the stored times carry another offset
+		tasks, err := repo.GetFailedTasks(ctx, base.Add(2*time.Minute).UTC())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{due.ID, legacy.ID}, ids(tasks))
+	})
+}
+
+func TestTaskRepository_GetOverdueTasks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		stuckDispatch := newTask("https://example.com/1", domain.TaskStatusDispatching, base)
+		stuckDispatch.LastDispatchedAt = ptr(base)
+		freshDispatch := newTask("https://example.com/2", domain.TaskStatusDispatching, base)
+		freshDispatch.LastDispatchedAt = ptr(base.Add(time.Hour))
+		noExternalID := newTask("https://example.com/3", domain.TaskStatusRunning, base)
+		longRunning := newTask("https://example.com/4", domain.TaskStatusRunning, base)
+		longRunning.ExternalID, longRunning.StartedAt = "arn:task/4", ptr(base)
+		recentRunning := newTask("https://example.com/5", domain.TaskStatusRunning, base.Add(time.Hour))
+		recentRunning.ExternalID, recentRunning.StartedAt = "arn:task/5", ptr(base.Add(time.Hour))
+		createTasks(t, repo, stuckDispatch, freshDispatch, noExternalID, longRunning, recentRunning)
+
+		tasks, err := repo.GetOverdueTasks(ctx, base.Add(time.Minute), base.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{stuckDispatch.ID, noExternalID.ID, longRunning.ID}, ids(tasks))
+	})
+}
+
+func TestTaskRepository_ActiveTaskLookups(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		const url = "https://example.com/a"
+		completed := newTask(url, domain.TaskStatusCompleted, base)
+		exhausted := newTask(url, domain.TaskStatusFailed, base)
+		exhausted.RetryCount = maxRetries
+		follower := newTask(url, domain.TaskStatusPending, base)
+		follower.OwnerUID, follower.SharedTaskID = "owner-2", &completed.ID
+		createTasks(t, repo, completed, exhausted, follower)
+
+		// Neither finished tasks nor followers count as the active task running the bot
+		got, err := repo.GetActiveTaskByURL(ctx, url)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		// The follower is still active for its own owner
+		got, err = repo.GetOwnerActiveTaskByURL(ctx, "owner-2", url)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, follower.ID, got.ID)
+
+		retryable := newTask(url, domain.TaskStatusTimedOut, base.Add(time.Minute))
+		retryable.RetryCount = maxRetries - 1
+		createTasks(t, repo, retryable)
+
+		got, err = repo.GetActiveTaskByURL(ctx, url)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, retryable.ID, got.ID)
+
+		got, err = repo.GetActiveTaskByDomain(ctx, "example.com")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, retryable.ID, got.ID)
+
+		got, err = repo.GetActiveTaskByURL(ctx, "https://example.com/other")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTaskRepository_GetRecentCompletedTask(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		const url = "https://example.com/a"
+		stale := newTask(url, domain.TaskStatusCompleted, base)
+		fresh := newTask(url, domain.TaskStatusCompleted, base.Add(time.Hour))
+		fresh.AnalysisResult = &domain.AnalysisResult{SchemaVersion: domain.AnalysisResultSchemaVersion, Verdict: domain.VerdictBenign}
+		createTasks(t, repo, stale, fresh)
+
+		got, err := repo.GetRecentCompletedTask(ctx, url, "example.com", base.Add(time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, fresh.ID, got.ID)
+		require.NotNil(t, got.AnalysisResult)
+		assert.Equal(t, domain.VerdictBenign, got.AnalysisResult.Verdict)
+
+		got, err = repo.GetRecentCompletedTask(ctx, url, "example.com", base.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTaskRepository_List(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		var created []*domain.AnalysisTask
+		for i := 0; i < 5; i++ {
+			task := newTask("https://example.com/page_"+string(rune('a'+i)), domain.TaskStatusPending, base.Add(time.Duration(i)*time.Minute))
+			created = append(created, task)
+		}
+		// "_" and "%" in the filter are literal, not LIKE wildcards
+		decoy := newTask("https://example.com/pageXa%", domain.TaskStatusPending, base)
+		other := newTask("https://example.com/page_z", domain.TaskStatusPending, base)
+		other.OwnerUID = "owner-2"
+		createTasks(t, repo, append(created, decoy, other)...)
+
+		filter := domain.TaskFilter{OwnerUID: "owner-1", URLContains: "PAGE_"}
+		var listed []uuid.UUID
+		page := domain.Page{Limit: 2}
+		for {
+			result, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			listed = append(listed, ids(result.Tasks)...)
+			if result.NextCursor == "" {
+				break
+			}
+			page.Cursor = result.NextCursor
+		}
+		// Newest first by default
+		assert.Equal(t, []uuid.UUID{created[4].ID, created[3].ID, created[2].ID, created[1].ID, created[0].ID}, listed)
+
+		result, err := repo.List(ctx, domain.TaskFilter{URLContains: "a%"}, domain.Page{})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{decoy.ID}, ids(result.Tasks))
+
+		from := base.Add(time.Minute).UTC()
+		result, err = repo.List(ctx, domain.TaskFilter{OwnerUID: "owner-1", CreatedFrom: &from}, domain.Page{SortBy: domain.SortByCreatedAt})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{created[1].ID, created[2].ID, created[3].ID, created[4].ID}, ids(result.Tasks))
+	})
+}
+
+func TestTaskRepository_BatchProgress(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormTaskRepository(database, maxRetries)
+
+		pending := newTask("https://example.com/1", domain.TaskStatusPending, base)
+		retryable := newTask("https://example.com/2", domain.TaskStatusFailed, base)
+		exhausted := newTask("https://example.com/3", domain.TaskStatusFailed, base)
+		exhausted.RetryCount = maxRetries
+		createTasks(t, repo, pending, retryable, exhausted)
+
+		batch := &domain.TaskBatch{ID: uuid.New(), OwnerUID: "owner-1", CreatedAt: base}
+		require.NoError(t, repo.CreateBatch(ctx, batch, []uuid.UUID{pending.ID, retryable.ID, exhausted.ID}))
+
+		progress, err := repo.GetBatchProgress(ctx, batch.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, progress.Total)
+		assert.Equal(t, 2, progress.Active)
+		assert.Equal(t, map[domain.TaskStatus]int{domain.TaskStatusPending: 1, domain.TaskStatusFailed: 2}, progress.StatusCounts)
+
+		_, err = repo.GetBatchProgress(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrBatchNotFound)
+	})
+}
+
+func TestCallbackRepository_ClaimDueDeliveries(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		tasks := repository.NewGormTaskRepository(database, maxRetries)
+		repo := repository.NewGormCallbackRepository(database)
+
+		task := newTask("https://example.com/a", domain.TaskStatusCompleted, base)
+		createTasks(t, tasks, task)
+
+		delivery := func(status domain.CallbackStatus, next time.Time) *domain.CallbackDelivery {
+			d := &domain.CallbackDelivery{ID: uuid.New(), TaskID: task.ID, URL: "https://hooks.example.net/",
+				Status: status, NextAttemptAt: &next, CreatedAt: base, UpdatedAt: base}
+			require.NoError(t, repo.Create(ctx, d))
+			return d
+		}
+		second := delivery(domain.CallbackPending, base.Add(2*time.Second))
+		first := delivery(domain.CallbackPending, base.Add(time.Second))
+		delivery(domain.CallbackPending, base.Add(time.Hour))
+		delivery(domain.CallbackDelivered, base)
+
+		now := base.Add(time.Minute)
+		claimed, err := repo.ClaimDueDeliveries(ctx, now, 10, 5*time.Minute)
+		require.NoError(t, err)
+		require.Len(t, claimed, 2)
+		assert.Equal(t, first.ID, claimed[0].ID)
+		assert.Equal(t, second.ID, claimed[1].ID)
+
+		// Claimed deliveries are leased into the future
+		claimed, err = repo.ClaimDueDeliveries(ctx, now, 10, 5*time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		deliveries, err := repo.ListByTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 4)
+	})
+}
+
+func TestLeaseRepository(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormLeaseRepository(database)
+		const name = "workers"
+
+		ok, err := repo.TryAcquire(ctx, name, "a", base, base.Add(15*time.Second))
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.TryAcquire(ctx, name, "b", base.Add(5*time.Second), base.Add(20*time.Second))
+		require.NoError(t, err)
+		assert.False(t, ok, "the lease is held by a")
+
+		holder, err := repo.GetHolder(ctx, name, base.Add(5*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, "a", holder)
+
+		// Renewal by the holder
+		ok, err = repo.TryAcquire(ctx, name, "a", base.Add(10*time.Second), base.Add(25*time.Second))
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		// Takeover once expired
+		ok, err = repo.TryAcquire(ctx, name, "b", base.Add(30*time.Second).UTC(), base.Add(45*time.Second))
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, repo.Release(ctx, name, "b"))
+		holder, err = repo.GetHolder(ctx, name, base.Add(31*time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, holder)
+	})
+}
+
+func TestNonceRepository(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		ctx := context.Background()
+		repo := repository.NewGormNonceRepository(database)
+		expiresAt := base.Add(5 * time.Minute)
+
+		ok, err := repo.Remember(ctx, "k1", "n1", base, expiresAt)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.Remember(ctx, "k1", "n1", base.Add(time.Minute), expiresAt)
+		require.NoError(t, err)
+		assert.False(t, ok, "replayed nonce")
+
+		ok, err = repo.Remember(ctx, "k2", "n1", base, expiresAt)
+		require.NoError(t, err)
+		assert.True(t, ok, "nonces are per key")
+
+		// Reusable once expired, even before it is purged
+		ok, err = repo.Remember(ctx, "k1", "n1", expiresAt.UTC(), expiresAt.Add(5*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, repo.DeleteExpired(ctx, expiresAt))
+		var left []domain.WebhookNonce
+		require.NoError(t, database.Order("key_id").Find(&left).Error)
+		require.Len(t, left, 1)
+		assert.Equal(t, "k1", left[0].KeyID)
+	})
+}
+
+func TestNonceRepository_ConcurrentReplays(t *testing.T) {
+	eachBackend(t, func(t *testing.T, database *gorm.DB) {
+		repo := repository.NewGormNonceRepository(database)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		accepted := 0
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ok, err := repo.Remember(context.Background(), "k1", "n1", base, base.Add(time.Minute))
+				assert.NoError(t, err)
+				if ok {
+					mu.Lock()
+					accepted++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, accepted)
+	})
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type gormCallbackRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCallbackRepository creates a new gormCallbackRepository
+func NewGormCallbackRepository(db *gorm.DB) domain.CallbackRepository {
+	return &gormCallbackRepository{db: db}
+}
+
+func (r *gormCallbackRepository) Create(ctx context.Context, d *domain.CallbackDelivery) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *gormCallbackRepository) Update(ctx context.Context, d *domain.CallbackDelivery) error {
+	return r.db.WithContext(ctx).Save(d).Error
+}
+
+func (r *gormCallbackRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.CallbackDelivery, error) {
+	var claimed []*domain.CallbackDelivery
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Rows locked by another delivery worker are skipped instead of waited on
+		if err := tx.Scopes(skipLocked).
+			Where("status = ? AND next_attempt_at <= ?", domain.CallbackPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&claimed).Error; err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(claimed))
+		for _, d := range claimed {
+			ids = append(ids, d.ID)
+		}
+		leaseEnd := now.Add(lease)
+		return tx.Model(&domain.CallbackDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseEnd).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *gormCallbackRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.CallbackDelivery, error) {
+	var deliveries []*domain.CallbackDelivery
+	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("created_at").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// enqueueCallback creates the callback delivery of a task that has just reached a terminal
+// status. It runs in the transaction of the status change so that no outcome is lost.
+func enqueueCallback(tx *gorm.DB, task *domain.AnalysisTask) error {
+	if task.CallbackURL == "" || !task.Status.IsTerminal() {
+		return nil
+	}
+	return tx.Create(domain.NewCallbackDelivery(task)).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormLeaseRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLeaseRepository creates a new gormLeaseRepository
+func NewGormLeaseRepository(db *gorm.DB) domain.LeaseRepository {
+	return &gormLeaseRepository{db: db}
+}
+
+func (r *gormLeaseRepository) TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+
+	// The first replica ever to campaign creates the row; everyone else competes on the update
+	lease := &domain.LeaderLease{Name: name, Holder: holder, ExpiresAt: expiresAt}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(lease).Error; err != nil {
+		return false, err
+	}
+
+	// A single conditional update both renews our own lease and takes over an expired one,
+	// so two replicas can never both succeed
+	result := db.Model(&domain.LeaderLease{}).
+		Where("name = ? AND (holder = ? OR expires_at <= ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": expiresAt, "updated_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormLeaseRepository) Release(ctx context.Context, name, holder string) error {
+	return r.db.WithContext(ctx).Model(&domain.LeaderLease{}).
+		Where("name = ? AND holder = ?", name, holder).
+		Update("expires_at", time.Unix(0, 0)).Error
+}
+
+func (r *gormLeaseRepository) GetHolder(ctx context.Context, name string, now time.Time) (string, error) {
+	var lease domain.LeaderLease
+	err := r.db.WithContext(ctx).Where("name = ? AND expires_at > ?", name, now).First(&lease).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return lease.Holder, nil
+}
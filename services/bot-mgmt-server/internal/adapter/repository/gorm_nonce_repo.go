@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormNonceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNonceRepository creates a new gormNonceRepository
+func NewGormNonceRepository(db *gorm.DB) domain.NonceRepository {
+	return &gormNonceRepository{db: db}
+}
+
+func (r *gormNonceRepository) Remember(ctx context.Context, keyID, nonce string, now, expiresAt time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+
+	// An expired nonce may be reused; it just has not been purged yet
+	if err := db.Where("key_id = ? AND nonce = ? AND expires_at <= ?", keyID, nonce, now).
+		Delete(&domain.WebhookNonce{}).Error; err != nil {
+		return false, err
+	}
+
+	// The primary key makes the insert the single point where concurrent replays are decided
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.WebhookNonce{KeyID: keyID, Nonce: nonce, ExpiresAt: expiresAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormNonceRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.WebhookNonce{}).Error
+}
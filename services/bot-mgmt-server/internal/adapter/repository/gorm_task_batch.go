@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *gormTaskRepository) CreateBatch(ctx context.Context, batch *domain.TaskBatch, taskIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(batch).Error; err != nil {
+			return err
+		}
+		if len(taskIDs) == 0 {
+			return nil
+		}
+		items := make([]domain.TaskBatchItem, 0, len(taskIDs))
+		for _, id := range taskIDs {
+			items = append(items, domain.TaskBatchItem{BatchID: batch.ID, TaskID: id})
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+func (r *gormTaskRepository) GetBatchProgress(ctx context.Context, batchID uuid.UUID) (*domain.BatchProgress, error) {
+	db := r.db.WithContext(ctx)
+
+	var batch domain.TaskBatch
+	if err := db.First(&batch, "id = ?", batchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBatchNotFound
+		}
+		return nil, err
+	}
+
+	batchTasks := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&domain.AnalysisTask{}).
+			Where("id IN (?)", r.db.Model(&domain.TaskBatchItem{}).Select("task_id").Where("batch_id = ?", batchID))
+	}
+
+	var rows []struct {
+		Status domain.TaskStatus
+		Count  int
+	}
+	if err := db.Scopes(batchTasks).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var active int64
+	if err := db.Scopes(batchTasks, r.active).Count(&active).Error; err != nil {
+		return nil, err
+	}
+
+	progress := &domain.BatchProgress{Batch: &batch, Active: int(active), StatusCounts: make(map[domain.TaskStatus]int)}
+	for _, row := range rows {
+		progress.StatusCounts[row.Status] = row.Count
+		progress.Total += row.Count
+	}
+	return progress, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// likeEscaper escapes the LIKE wildcards in user input. The escape character is named
+// explicitly because SQLite has no default one, and "!" needs no quoting in any dialect,
+// unlike a backslash in MySQL string literals.
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+// listCursor is the position after the last task of a page. It records the sort order
+// it was issued for so it cannot be replayed against a different one.
+type listCursor struct {
+	SortBy domain.TaskSortField `json:"s"`
+	Desc   bool                 `json:"d"`
+	Value  string               `json:"v"` // Sort column value of the last task
+	ID     uuid.UUID            `json:"id"`
+}
+
+func (r *gormTaskRepository) List(ctx context.Context, filter domain.TaskFilter, page domain.Page) (*domain.TaskPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := r.db.WithContext(ctx).Preload("AnalysisResult").Scopes(fromReplica, taskFilter(filter))
+
+	col := string(page.SortBy)
+	dir, cmp := "ASC", ">"
+	if page.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	if page.Cursor != "" {
+		cur, value, err := decodeCursor(page)
+		if err != nil {
+			return nil, err
+		}
+		// Keyset pagination on (col, id); expanded instead of a row comparison so the
+		// planner can use the index on col in every supported database
+		q = q.Where(r.db.Where(col+" "+cmp+" ?", value).Or(col+" = ? AND id "+cmp+" ?", value, cur.ID))
+	}
+
+	var tasks []*domain.AnalysisTask
+	// One extra row tells whether another page follows
+	if err := q.Order(col + " " + dir).Order("id " + dir).Limit(page.Limit + 1).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	result := &domain.TaskPage{Tasks: tasks}
+	if len(tasks) > page.Limit {
+		result.Tasks = tasks[:page.Limit]
+		result.NextCursor = encodeCursor(page, result.Tasks[page.Limit-1])
+	}
+	return result, nil
+}
+
+// taskFilter applies the non-empty fields of f
+func taskFilter(f domain.TaskFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if f.OwnerUID != "" {
+			db = db.Where("owner_uid = ?", f.OwnerUID)
+		}
+		if len(f.Statuses) > 0 {
+			db = db.Where("status IN ?", f.Statuses)
+		}
+		if f.URLContains != "" {
+			db = db.Where("LOWER(url) LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(strings.ToLower(f.URLContains))+"%")
+		}
+		if f.AnalysisID != "" {
+			db = db.Where("analysis_id = ?", f.AnalysisID)
+		}
+		if f.CreatedFrom != nil {
+			db = db.Where("created_at >= ?", *f.CreatedFrom)
+		}
+		if f.CreatedTo != nil {
+			db = db.Where("created_at < ?", *f.CreatedTo)
+		}
+		if f.MinRetryCount != nil {
+			db = db.Where("retry_count >= ?", *f.MinRetryCount)
+		}
+		if f.MaxRetryCount != nil {
+			db = db.Where("retry_count <= ?", *f.MaxRetryCount)
+		}
+		return db
+	}
+}
+
+func encodeCursor(page domain.Page, last *domain.AnalysisTask) string {
+	cur := listCursor{SortBy: page.SortBy, Desc: page.Desc, ID: last.ID}
+	switch page.SortBy {
+	case domain.SortByUpdatedAt:
+		cur.Value = last.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case domain.SortByRetryCount:
+		cur.Value = strconv.Itoa(last.RetryCount)
+	default:
+		cur.Value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses page.Cursor and returns it with its sort value typed for the query
+func decodeCursor(page domain.Page) (*listCursor, any, error) {
+	invalid := fmt.Errorf("%w: malformed cursor", domain.ErrInvalidQuery)
+
+	b, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return nil, nil, invalid
+	}
+	var cur listCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return nil, nil, invalid
+	}
+	if cur.SortBy != page.SortBy || cur.Desc != page.Desc {
+		return nil, nil, fmt.Errorf("%w: cursor was issued for a different sort order", domain.ErrInvalidQuery)
+	}
+
+	var value any
+	if page.SortBy == domain.SortByRetryCount {
+		value, err = strconv.Atoi(cur.Value)
+	} else {
+		value, err = time.Parse(time.RFC3339Nano, cur.Value)
+	}
+	if err != nil {
+		return nil, nil, invalid
+	}
+	return &cur, value, nil
+}
@@ -2,12 +2,55 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/db"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// claimCandidateFactor controls how many extra PENDING rows are locked per claim
+// so that tasks of other users can still be picked when some users are at their cap.
+const claimCandidateFactor = 4
+
+// claimLockName names the database lock serializing claims across replicas
+const claimLockName = "bot-mgmt-claim"
+
+// claimLockTimeoutSeconds is how long a MySQL claim waits for another replica's claim
+const claimLockTimeoutSeconds = 10
+
+// inFlightStatuses are the statuses counted against the concurrency caps.
+var inFlightStatuses = []domain.TaskStatus{domain.TaskStatusDispatching, domain.TaskStatusRunning}
+
+// retryableStatuses are the statuses picked up by the retry worker while retries remain.
+var retryableStatuses = []domain.TaskStatus{domain.TaskStatusFailed, domain.TaskStatusTimedOut}
+
+// leadersOnly restricts a query to tasks that own their bot run. Tasks sharing another
+// task's analysis only mirror it and must never be dispatched, polled, retried or reaped.
+func leadersOnly(db *gorm.DB) *gorm.DB {
+	return db.Where("shared_task_id IS NULL")
+}
+
+// fromReplica reads from a read replica if any are configured. Only for listings and
+// statistics: replicas lag behind, so a task just written may be missing or outdated.
+func fromReplica(tx *gorm.DB) *gorm.DB {
+	return tx.Clauses(dbresolver.Use(db.ReplicaResolver))
+}
+
+// skipLocked locks the selected rows for the rest of the transaction, skipping rows
+// locked by another one. SQLite has no row locks; its single writer serializes claims.
+func skipLocked(db *gorm.DB) *gorm.DB {
+	if db.Dialector.Name() == "sqlite" {
+		return db
+	}
+	return db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+}
+
 type gormTaskRepository struct {
 	db         *gorm.DB
 	maxRetries int
@@ -22,12 +65,21 @@ func NewGormTaskRepository(db *gorm.DB, maxRetries int) domain.TaskRepository {
 }
 
 func (r *gormTaskRepository) Create(ctx context.Context, task *domain.AnalysisTask) error {
-	return r.db.WithContext(ctx).Create(task).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		// Tasks created from a cached result are finished from the start
+		return enqueueCallback(tx, task)
+	})
 }
 
 func (r *gormTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.AnalysisTask, error) {
 	var task domain.AnalysisTask
-	if err := r.db.WithContext(ctx).First(&task, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("AnalysisResult").First(&task, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTaskNotFound
+		}
 		return nil, err
 	}
 	return &task, nil
@@ -37,18 +89,341 @@ func (r *gormTaskRepository) Update(ctx context.Context, task *domain.AnalysisTa
 	return r.db.WithContext(ctx).Save(task).Error
 }
 
+func (r *gormTaskRepository) SaveTransition(ctx context.Context, task *domain.AnalysisTask, event *domain.TaskEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Compare-and-set on the previous status so concurrent writers cannot overwrite each other
+		res := tx.Model(task).Where("status = ?", event.FromStatus).Select("*").Omit(clause.Associations).Updates(task)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrTransitionConflict
+		}
+
+		if task.AnalysisResult != nil {
+			task.AnalysisResult.TaskID = task.ID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "task_id"}},
+				UpdateAll: true,
+			}).Create(task.AnalysisResult).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := mirrorToFollowers(tx, task, event); err != nil {
+			return err
+		}
+
+		// Same-status saves (e.g. attaching a late result) are not transitions
+		if event.FromStatus == event.ToStatus {
+			return nil
+		}
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		return enqueueCallback(tx, task)
+	})
+}
+
+func (r *gormTaskRepository) GetLastEvent(ctx context.Context, taskID uuid.UUID) (*domain.TaskEvent, error) {
+	var events []*domain.TaskEvent
+	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("created_at DESC").Limit(1).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return events[0], nil
+}
+
+// mirrorToFollowers copies the leader's status and results to the active tasks sharing its
+// analysis. Followers track the leader's already-validated path, so the state machine is not
+// re-checked for them. Cancellation is per requester and is never mirrored.
+func mirrorToFollowers(tx *gorm.DB, leader *domain.AnalysisTask, event *domain.TaskEvent) error {
+	if leader.SharedTaskID != nil || event.ToStatus == domain.TaskStatusCancelled {
+		return nil
+	}
+
+	var followers []*domain.AnalysisTask
+	if err := tx.Where("shared_task_id = ? AND status NOT IN ?", leader.ID, domain.TerminalStatuses()).
+		Find(&followers).Error; err != nil {
+		return err
+	}
+
+	for _, f := range followers {
+		from := f.Status
+		f.Status = leader.Status
+		f.RetryCount = leader.RetryCount
+		f.StartedAt = leader.StartedAt
+		f.NextAttemptAt = leader.NextAttemptAt
+		f.Result = leader.Result
+		f.UpdatedAt = leader.UpdatedAt
+		if err := tx.Model(f).
+			Select("status", "retry_count", "started_at", "next_attempt_at", "result", "updated_at").
+			Updates(f).Error; err != nil {
+			return err
+		}
+
+		if leader.AnalysisResult != nil {
+			result := *leader.AnalysisResult
+			result.TaskID = f.ID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "task_id"}},
+				UpdateAll: true,
+			}).Create(&result).Error; err != nil {
+				return err
+			}
+		}
+
+		if from == f.Status {
+			continue
+		}
+		if err := tx.Create(&domain.TaskEvent{
+			ID:         uuid.New(),
+			TaskID:     f.ID,
+			FromStatus: from,
+			ToStatus:   f.Status,
+			Actor:      event.Actor,
+			Reason:     fmt.Sprintf("shared task %s: %s", leader.ID, event.Reason),
+			CreatedAt:  event.CreatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := enqueueCallback(tx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormTaskRepository) HandOverSharedTask(ctx context.Context, leader *domain.AnalysisTask) (*domain.AnalysisTask, error) {
+	var successor *domain.AnalysisTask
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var candidate domain.AnalysisTask
+		if err := tx.Where("shared_task_id = ? AND status NOT IN ?", leader.ID, domain.TerminalStatuses()).
+			Order("created_at").
+			First(&candidate).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := tx.Model(&domain.AnalysisTask{}).
+			Where("shared_task_id = ? AND id <> ?", leader.ID, candidate.ID).
+			Update("shared_task_id", candidate.ID).Error; err != nil {
+			return err
+		}
+		candidate.SharedTaskID = nil
+		successor = &candidate
+
+		// Followers mirror the leader, so the candidate has the status the leader had before cancellation
+		switch {
+		case candidate.Status == domain.TaskStatusRunning && leader.ExternalID != "":
+			return handOverBotRun(tx, leader, &candidate)
+		case candidate.Status == domain.TaskStatusDispatching || candidate.Status == domain.TaskStatusRunning:
+			return relaunch(tx, leader, &candidate)
+		default:
+			// PENDING, FAILED and TIMED_OUT tasks have no bot; the dispatcher or retry worker picks them up
+			return tx.Model(&candidate).Update("shared_task_id", nil).Error
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return successor, nil
+}
+
+// handOverBotRun lets successor take over leader's bot, so pollers and the webhook now act on it
+func handOverBotRun(tx *gorm.DB, leader, successor *domain.AnalysisTask) error {
+	if err := tx.Model(successor).Updates(map[string]interface{}{
+		"shared_task_id":     nil,
+		"external_id":        leader.ExternalID,
+		"dispatch_attempts":  leader.DispatchAttempts,
+		"last_dispatched_at": leader.LastDispatchedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	// Copied before the update below clears leader.ExternalID
+	successor.ExternalID = leader.ExternalID
+	successor.DispatchAttempts = leader.DispatchAttempts
+	successor.LastDispatchedAt = leader.LastDispatchedAt
+	return tx.Model(leader).Update("external_id", "").Error
+}
+
+// relaunch returns successor to PENDING when leader was cancelled before its bot launched.
+// The dispatcher stops the leader's bot once RunBot returns, so the successor needs its own.
+func relaunch(tx *gorm.DB, leader, successor *domain.AnalysisTask) error {
+	from := successor.Status
+	successor.Status = domain.TaskStatusPending
+	successor.ExternalID = ""
+	successor.DispatchAttempts = 0
+	successor.LastDispatchedAt = nil
+	successor.StartedAt = nil
+	successor.UpdatedAt = time.Now()
+	if err := tx.Model(successor).
+		Select("shared_task_id", "status", "external_id", "dispatch_attempts", "last_dispatched_at", "started_at", "updated_at").
+		Updates(successor).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&domain.TaskEvent{
+		ID:         uuid.New(),
+		TaskID:     successor.ID,
+		FromStatus: from,
+		ToStatus:   successor.Status,
+		Actor:      domain.ActorAPI,
+		Reason:     fmt.Sprintf("shared task %s cancelled before its bot launched", leader.ID),
+		CreatedAt:  successor.UpdatedAt,
+	}).Error
+}
+
 func (r *gormTaskRepository) GetPendingTasks(ctx context.Context) ([]*domain.AnalysisTask, error) {
 	var tasks []*domain.AnalysisTask
-	if err := r.db.WithContext(ctx).Where("status = ?", domain.TaskStatusPending).Find(&tasks).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(leadersOnly).Where("status = ?", domain.TaskStatusPending).Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-func (r *gormTaskRepository) GetFailedTasks(ctx context.Context) ([]*domain.AnalysisTask, error) {
+func (r *gormTaskRepository) ClaimPendingTasks(ctx context.Context, opts domain.ClaimOptions) ([]*domain.AnalysisTask, error) {
+	var claimed []*domain.AnalysisTask
+
+	err := r.claimTransaction(ctx, func(tx *gorm.DB) error {
+		available := opts.Limit
+		if opts.MaxInFlight > 0 {
+			var inFlight int64
+			if err := tx.Model(&domain.AnalysisTask{}).Scopes(leadersOnly).Where("status IN ?", inFlightStatuses).Count(&inFlight).Error; err != nil {
+				return err
+			}
+			if remaining := opts.MaxInFlight - int(inFlight); remaining < available {
+				available = remaining
+			}
+		}
+		if available <= 0 {
+			return nil
+		}
+
+		perUser := map[string]int{}
+		if opts.MaxPerUser > 0 {
+			var counts []struct {
+				OwnerUID string
+				Count    int
+			}
+			if err := tx.Model(&domain.AnalysisTask{}).Scopes(leadersOnly).
+				Select("owner_uid, COUNT(*) AS count").
+				Where("status IN ?", inFlightStatuses).
+				Group("owner_uid").
+				Scan(&counts).Error; err != nil {
+				return err
+			}
+			for _, c := range counts {
+				perUser[c.OwnerUID] = c.Count
+			}
+		}
+
+		// Rows locked by another dispatcher are skipped instead of waited on
+		var candidates []*domain.AnalysisTask
+		if err := tx.Scopes(skipLocked, leadersOnly).
+			Where("status = ?", domain.TaskStatusPending).
+			Order("created_at").
+			Limit(available * claimCandidateFactor).
+			Find(&candidates).Error; err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, available)
+		for _, task := range candidates {
+			if len(claimed) == available {
+				break
+			}
+			// Tasks without an owner (legacy rows) are not subject to the per-user cap
+			if opts.MaxPerUser > 0 && task.OwnerUID != "" {
+				if perUser[task.OwnerUID] >= opts.MaxPerUser {
+					continue
+				}
+				perUser[task.OwnerUID]++
+			}
+			claimed = append(claimed, task)
+			ids = append(ids, task.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&domain.AnalysisTask{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":             domain.TaskStatusDispatching,
+			"dispatch_attempts":  gorm.Expr("dispatch_attempts + 1"),
+			"last_dispatched_at": now,
+			"updated_at":         now,
+		}).Error; err != nil {
+			return err
+		}
+
+		events := make([]*domain.TaskEvent, 0, len(claimed))
+		for _, task := range claimed {
+			event, err := task.Transition(domain.TaskStatusDispatching, domain.ActorDispatcher, "claimed by dispatcher")
+			if err != nil {
+				return err
+			}
+			task.DispatchAttempts++
+			task.LastDispatchedAt = &now
+			events = append(events, event)
+		}
+		return tx.Create(&events).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// claimTransaction runs fn in a transaction that excludes the claims of other dispatchers
+// until it commits. Otherwise replicas counting in-flight tasks at the same time would each
+// fill the same remaining capacity. SQLite needs no lock: it allows a single writer.
+func (r *gormTaskRepository) claimTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	db := r.db.WithContext(ctx)
+	switch db.Dialector.Name() {
+	case "postgres":
+		return db.Transaction(func(tx *gorm.DB) error {
+			// Released by the commit
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", claimLockName).Error; err != nil {
+				return fmt.Errorf("failed to lock claims: %w", err)
+			}
+			return fn(tx)
+		})
+	case "mysql":
+		// GET_LOCK belongs to the session, so it is taken on the connection running the
+		// transaction and released only after the commit
+		return db.Connection(func(conn *gorm.DB) error {
+			conn = conn.Session(&gorm.Session{})
+			var acquired int
+			if err := conn.Raw("SELECT GET_LOCK(?, ?)", claimLockName, claimLockTimeoutSeconds).Scan(&acquired).Error; err != nil {
+				return fmt.Errorf("failed to lock claims: %w", err)
+			}
+			if acquired != 1 {
+				return errors.New("timed out waiting for another dispatcher to claim tasks")
+			}
+			// Even on shutdown: the connection goes back to the pool still holding the lock otherwise
+			defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT RELEASE_LOCK(?)", claimLockName)
+			return conn.Transaction(fn)
+		})
+	default:
+		return db.Transaction(fn)
+	}
+}
+
+func (r *gormTaskRepository) GetFailedTasks(ctx context.Context, now time.Time) ([]*domain.AnalysisTask, error) {
 	var tasks []*domain.AnalysisTask
-	// RetryCount < maxRetries and Status = FAILED
-	if err := r.db.WithContext(ctx).Where("status = ? AND retry_count < ?", domain.TaskStatusFailed, r.maxRetries).Find(&tasks).Error; err != nil {
+	// RetryCount < maxRetries and Status = FAILED or TIMED_OUT and backoff elapsed
+	if err := r.db.WithContext(ctx).Scopes(leadersOnly).
+		Where("status IN ? AND retry_count < ?", retryableStatuses, r.maxRetries).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
@@ -56,21 +431,111 @@ func (r *gormTaskRepository) GetFailedTasks(ctx context.Context) ([]*domain.Anal
 
 func (r *gormTaskRepository) GetRunningTasks(ctx context.Context) ([]*domain.AnalysisTask, error) {
 	var tasks []*domain.AnalysisTask
-	if err := r.db.WithContext(ctx).Where("status = ?", domain.TaskStatusRunning).Find(&tasks).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(leadersOnly).Where("status = ?", domain.TaskStatusRunning).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *gormTaskRepository) CountByStatus(ctx context.Context, statuses []domain.TaskStatus) (map[domain.TaskStatus]int, error) {
+	var rows []struct {
+		Status domain.TaskStatus
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.AnalysisTask{}).Scopes(fromReplica, leadersOnly).
+		Where("status IN ?", statuses).
+		Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.TaskStatus]int, len(statuses))
+	for _, s := range statuses {
+		counts[s] = 0
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *gormTaskRepository) GetOverdueTasks(ctx context.Context, dispatchedBefore, startedBefore time.Time) ([]*domain.AnalysisTask, error) {
+	var tasks []*domain.AnalysisTask
+	// Rows without started_at predate the column, so fall back to created_at
+	overdue := r.db.
+		Where("status = ? AND last_dispatched_at < ?", domain.TaskStatusDispatching, dispatchedBefore).
+		Or("status = ? AND external_id = ? AND updated_at < ?", domain.TaskStatusRunning, "", dispatchedBefore).
+		Or("status = ? AND COALESCE(started_at, created_at) < ?", domain.TaskStatusRunning, startedBefore)
+	if err := r.db.WithContext(ctx).Scopes(leadersOnly).Where(overdue).Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-func (r *gormTaskRepository) GetActiveTaskByURL(ctx context.Context, url string) (*domain.AnalysisTask, error) {
+// active restricts a query to tasks that are in flight or will still be retried:
+// PENDING, DISPATCHING or RUNNING or (FAILED/TIMED_OUT and retry_count < maxRetries).
+// The disjunction is built as a group condition so it is parenthesized as a whole
+// whatever other conditions the query is combined with.
+func (r *gormTaskRepository) active(db *gorm.DB) *gorm.DB {
+	cond := db.Session(&gorm.Session{NewDB: true})
+	return db.Where(cond.
+		Where("status IN ?", []domain.TaskStatus{domain.TaskStatusPending, domain.TaskStatusDispatching, domain.TaskStatusRunning}).
+		Or(cond.Where("status IN ?", retryableStatuses).Where("retry_count < ?", r.maxRetries)))
+}
+
+// GetActiveTaskByURL leaves canonical_url unindexed and relies on the status index:
+// active tasks are few, and MySQL cannot index a text column without a prefix length.
+func (r *gormTaskRepository) GetActiveTaskByURL(ctx context.Context, canonicalURL string) (*domain.AnalysisTask, error) {
+	var task domain.AnalysisTask
+	if err := r.db.WithContext(ctx).
+		Scopes(leadersOnly, r.active).
+		Where("canonical_url = ?", canonicalURL).
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // No active task found
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *gormTaskRepository) GetActiveTaskByDomain(ctx context.Context, registeredDomain string) (*domain.AnalysisTask, error) {
+	var task domain.AnalysisTask
+	if err := r.db.WithContext(ctx).
+		Scopes(leadersOnly, r.active).
+		Where("registered_domain = ?", registeredDomain).
+		Order("created_at").
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // No active task found
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *gormTaskRepository) GetRecentCompletedTask(ctx context.Context, canonicalURL, registeredDomain string, completedAfter time.Time) (*domain.AnalysisTask, error) {
+	var task domain.AnalysisTask
+	// COMPLETED is terminal, so updated_at is the completion time
+	if err := r.db.WithContext(ctx).
+		Preload("AnalysisResult").
+		Scopes(leadersOnly).
+		Where("registered_domain = ? AND canonical_url = ?", registeredDomain, canonicalURL).
+		Where("status = ? AND updated_at > ?", domain.TaskStatusCompleted, completedAfter).
+		Order("updated_at DESC").
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Nothing fresh enough
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *gormTaskRepository) GetOwnerActiveTaskByURL(ctx context.Context, ownerUID, canonicalURL string) (*domain.AnalysisTask, error) {
 	var task domain.AnalysisTask
-	// Active status: PENDING or RUNNING or (FAILED and retry_count < maxRetries)
 	if err := r.db.WithContext(ctx).
-		Where("url = ? AND (status IN ? OR (status = ? AND retry_count < ?))",
-			url,
-			[]domain.TaskStatus{domain.TaskStatusPending, domain.TaskStatusRunning},
-			domain.TaskStatusFailed,
-			r.maxRetries).
+		Scopes(r.active).
+		Where("canonical_url = ? AND owner_uid = ?", canonicalURL, ownerUID).
 		First(&task).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil // No active task found
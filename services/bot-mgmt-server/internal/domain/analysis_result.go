@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalysisResultSchemaVersion is the current version of the AnalysisResult schema
+const AnalysisResultSchemaVersion = 1
+
+// Verdict is the bot's conclusion about the analysed URL
+type Verdict string
+
+const (
+	VerdictPhishing   Verdict = "phishing"
+	VerdictSuspicious Verdict = "suspicious"
+	VerdictBenign     Verdict = "benign"
+	VerdictUnknown    Verdict = "unknown" // The bot could not reach a conclusion (e.g. page did not load)
+)
+
+// ArtifactKind is the type of a file captured during the analysis
+type ArtifactKind string
+
+const (
+	ArtifactScreenshot ArtifactKind = "screenshot"
+	ArtifactHAR        ArtifactKind = "har"
+)
+
+// ResultArtifact references a file stored outside the database (e.g. an S3 object)
+type ResultArtifact struct {
+	Kind ArtifactKind `json:"kind"`
+	URI  string       `json:"uri"`
+}
+
+// ResultIndicator is a single piece of evidence behind the verdict
+type ResultIndicator struct {
+	Type        string `json:"type"`  // e.g. "brand_impersonation", "credential_form", "blocklist"
+	Value       string `json:"value"` // What matched (a brand name, a form action URL, a list name, ...)
+	Description string `json:"description,omitempty"`
+}
+
+// AnalysisResult is the structured outcome of a completed analysis.
+// Lists are stored as JSON columns; the scalar fields are plain columns so they can be queried.
+type AnalysisResult struct {
+	TaskID        uuid.UUID         `gorm:"primary_key" json:"-"`
+	SchemaVersion int               `gorm:"not null" json:"schema_version"`
+	Verdict       Verdict           `gorm:"not null;index" json:"verdict"`
+	Confidence    float64           `json:"confidence"` // 0.0 - 1.0
+	FinalURL      string            `gorm:"type:text" json:"final_url,omitempty"`
+	FinalDomain   string            `gorm:"index" json:"final_domain,omitempty"` // Host of FinalURL, derived on validation
+	RedirectChain []string          `gorm:"serializer:json;type:json" json:"redirect_chain,omitempty"`
+	PageTitle     string            `gorm:"type:text" json:"page_title,omitempty"`
+	DetectedBrand string            `gorm:"index" json:"detected_brand,omitempty"`
+	Artifacts     []ResultArtifact  `gorm:"serializer:json;type:json" json:"artifacts,omitempty"`
+	Indicators    []ResultIndicator `gorm:"serializer:json;type:json" json:"indicators,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// ErrInvalidResult is returned when a reported AnalysisResult does not match the schema
+var ErrInvalidResult = errors.New("invalid analysis result")
+
+// Validate checks the result against the schema and fills in derived fields.
+// A missing schema version is taken to be the current one.
+func (r *AnalysisResult) Validate() error {
+	if r.SchemaVersion == 0 {
+		r.SchemaVersion = AnalysisResultSchemaVersion
+	}
+	if r.SchemaVersion != AnalysisResultSchemaVersion {
+		return fmt.Errorf("%w: unsupported schema_version %d", ErrInvalidResult, r.SchemaVersion)
+	}
+
+	switch r.Verdict {
+	case VerdictPhishing, VerdictSuspicious, VerdictBenign, VerdictUnknown:
+	default:
+		return fmt.Errorf("%w: unknown verdict %q", ErrInvalidResult, r.Verdict)
+	}
+
+	if r.Confidence < 0 || r.Confidence > 1 {
+		return fmt.Errorf("%w: confidence %v is outside [0, 1]", ErrInvalidResult, r.Confidence)
+	}
+
+	r.FinalDomain = ""
+	if r.FinalURL != "" {
+		u, err := url.Parse(r.FinalURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("%w: final_url %q is not an absolute URL", ErrInvalidResult, r.FinalURL)
+		}
+		r.FinalDomain = strings.ToLower(u.Hostname())
+	}
+
+	for i, a := range r.Artifacts {
+		switch a.Kind {
+		case ArtifactScreenshot, ArtifactHAR:
+		default:
+			return fmt.Errorf("%w: artifacts[%d] has unknown kind %q", ErrInvalidResult, i, a.Kind)
+		}
+		if a.URI == "" {
+			return fmt.Errorf("%w: artifacts[%d] has no uri", ErrInvalidResult, i)
+		}
+	}
+
+	for i, ind := range r.Indicators {
+		if ind.Type == "" {
+			return fmt.Errorf("%w: indicators[%d] has no type", ErrInvalidResult, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisResult_Validate(t *testing.T) {
+	valid := func() *domain.AnalysisResult {
+		return &domain.AnalysisResult{
+			Verdict:    domain.VerdictSuspicious,
+			Confidence: 0.6,
+			FinalURL:   "https://Example.com:8443/login",
+			Artifacts:  []domain.ResultArtifact{{Kind: domain.ArtifactScreenshot, URI: "s3://bucket/shot.png"}},
+			Indicators: []domain.ResultIndicator{{Type: "credential_form", Value: "/login"}},
+		}
+	}
+
+	r := valid()
+	assert.NoError(t, r.Validate())
+	assert.Equal(t, domain.AnalysisResultSchemaVersion, r.SchemaVersion)
+	assert.Equal(t, "example.com", r.FinalDomain)
+
+	cases := map[string]func(r *domain.AnalysisResult){
+		"future schema version": func(r *domain.AnalysisResult) { r.SchemaVersion = 99 },
+		"unknown verdict":       func(r *domain.AnalysisResult) { r.Verdict = "maybe" },
+		"confidence above 1":    func(r *domain.AnalysisResult) { r.Confidence = 1.5 },
+		"relative final url":    func(r *domain.AnalysisResult) { r.FinalURL = "/login" },
+		"unknown artifact kind": func(r *domain.AnalysisResult) { r.Artifacts[0].Kind = "video" },
+		"artifact without uri":  func(r *domain.AnalysisResult) { r.Artifacts[0].URI = "" },
+		"indicator without type": func(r *domain.AnalysisResult) {
+			r.Indicators[0].Type = ""
+		},
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := valid()
+			mutate(r)
+			assert.ErrorIs(t, r.Validate(), domain.ErrInvalidResult)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "sort"
+
+// EnvVar is a single environment variable passed to a bot container
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// BotEnv returns the environment every executor passes to a bot: the task fields
+// followed by extra (e.g. webhook credentials), sorted by name.
+func BotEnv(task *AnalysisTask, extra map[string]string) []EnvVar {
+	env := []EnvVar{
+		{Name: "TASK_ID", Value: task.ID.String()},
+		{Name: "TARGET_URL", Value: task.URL},
+		{Name: "USER_ID", Value: task.RequestUUID},
+		{Name: "PRIMARY_KEY", Value: task.AnalysisID},
+	}
+
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, EnvVar{Name: name, Value: extra[name]})
+	}
+	return env
+}
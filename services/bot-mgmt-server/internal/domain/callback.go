@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallbackStatus is the state of one callback delivery
+type CallbackStatus string
+
+const (
+	CallbackPending   CallbackStatus = "PENDING" // Waiting for its next attempt
+	CallbackDelivered CallbackStatus = "DELIVERED"
+	CallbackFailed    CallbackStatus = "FAILED" // Given up; can still be redelivered manually
+)
+
+// CallbackDelivery records the delivery of a finished task's outcome to its CallbackURL.
+// A delivery is created when the task reaches a terminal status and on every manual redelivery.
+type CallbackDelivery struct {
+	ID             uuid.UUID      `gorm:"primary_key;" json:"id"`
+	TaskID         uuid.UUID      `gorm:"index;not null" json:"task_id"`
+	URL            string         `gorm:"type:text;not null" json:"url"`
+	Status         CallbackStatus `gorm:"not null;index" json:"status"`
+	Attempts       int            `gorm:"default:0" json:"attempts"`
+	NextAttemptAt  *time.Time     `gorm:"index" json:"next_attempt_at,omitempty"` // Set while PENDING
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	ResponseStatus int            `json:"response_status,omitempty"` // HTTP status of the last attempt, 0 if none was received
+	LastError      string         `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// NewCallbackDelivery returns a PENDING delivery to task's CallbackURL, due immediately
+func NewCallbackDelivery(task *AnalysisTask) *CallbackDelivery {
+	now := time.Now()
+	return &CallbackDelivery{
+		ID:            uuid.New(),
+		TaskID:        task.ID,
+		URL:           task.CallbackURL,
+		Status:        CallbackPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// CallbackPayload is the body POSTed to a callback URL
+type CallbackPayload struct {
+	DeliveryID     uuid.UUID       `json:"delivery_id"` // Same across retries of a delivery, for deduplication by the receiver
+	TaskID         uuid.UUID       `json:"task_id"`
+	AnalysisID     string          `json:"analysis_id"`
+	Status         TaskStatus      `json:"status"`
+	Result         string          `json:"result,omitempty"`
+	AnalysisResult *AnalysisResult `json:"analysis_result,omitempty"`
+	FinishedAt     time.Time       `json:"finished_at"`
+}
+
+// NewCallbackPayload describes the outcome of the finished task for delivery d
+func NewCallbackPayload(d *CallbackDelivery, task *AnalysisTask) CallbackPayload {
+	return CallbackPayload{
+		DeliveryID:     d.ID,
+		TaskID:         task.ID,
+		AnalysisID:     task.AnalysisID,
+		Status:         task.Status,
+		Result:         task.Result,
+		AnalysisResult: task.AnalysisResult,
+		FinishedAt:     task.UpdatedAt,
+	}
+}
+
+// ErrInvalidCallbackURL is returned for a callback URL that is malformed or whose host is not allowed
+var ErrInvalidCallbackURL = errors.New("invalid callback URL")
+
+// ErrCallbackNotDeliverable is returned when redelivering the callback of a task that
+// has no callback URL or has not finished yet
+var ErrCallbackNotDeliverable = errors.New("callback cannot be delivered")
+
+// CallbackAllowlist holds the hosts callbacks may be sent to. An entry "*.example.com"
+// matches any subdomain of example.com, but not example.com itself.
+type CallbackAllowlist []string
+
+// Check returns ErrInvalidCallbackURL unless raw is an absolute http(s) URL without
+// credentials whose host is on the allowlist
+func (l CallbackAllowlist) Check(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: %q is not an absolute http(s) URL", ErrInvalidCallbackURL, raw)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials are not allowed in the URL", ErrInvalidCallbackURL)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	for _, entry := range l {
+		entry = strings.ToLower(entry)
+		if suffix, ok := strings.CutPrefix(entry, "*"); ok {
+			if strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return nil
+			}
+		} else if host == entry {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: host %q is not allowed", ErrInvalidCallbackURL, host)
+}
+
+// CallbackRepository defines the interface for callback delivery persistence.
+// Deliveries for tasks reaching a terminal status are created by TaskRepository
+// in the same transaction as the status change.
+type CallbackRepository interface {
+	Create(ctx context.Context, d *CallbackDelivery) error
+	Update(ctx context.Context, d *CallbackDelivery) error
+	// ClaimDueDeliveries returns up to limit PENDING deliveries due at now, oldest first, and
+	// moves their next attempt lease into the future so that other replicas skip them meanwhile
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*CallbackDelivery, error)
+	// ListByTask returns the deliveries of a task, oldest first
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]*CallbackDelivery, error)
+}
+
+// CallbackSender delivers signed callback payloads
+type CallbackSender interface {
+	// Send POSTs body to url and returns the HTTP status of the response (0 if none was
+	// received). Any status other than 2xx is returned as an error.
+	Send(ctx context.Context, url string, deliveryID uuid.UUID, body []byte) (int, error)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LeaderLease is a named lease held by at most one replica at a time. Its holder runs the
+// background workers that must not run concurrently, and keeps renewing it until it stops.
+type LeaderLease struct {
+	Name      string    `gorm:"primaryKey;size:128" json:"name"`
+	Holder    string    `gorm:"size:255;not null" json:"holder"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"` // The lease is free from then on unless renewed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LeaseRepository stores leader leases
+type LeaseRepository interface {
+	// TryAcquire gives the lease to holder until expiresAt if it is unheld, expired at now,
+	// or already held by holder, and reports whether holder holds it afterwards.
+	TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error)
+	// Release frees the lease if holder still holds it, so that another replica can take over at once
+	Release(ctx context.Context, name, holder string) error
+	// GetHolder returns the holder of the lease if it is unexpired at now, or ""
+	GetHolder(ctx context.Context, name string, now time.Time) (string, error)
+}
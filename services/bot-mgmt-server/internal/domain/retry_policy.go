@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// FailureClass tells whether retrying a failed task can succeed
+type FailureClass string
+
+const (
+	FailureTransient FailureClass = "transient" // e.g. ECS capacity, API throttling, OOM
+	FailurePermanent FailureClass = "permanent" // e.g. invalid URL, NXDOMAIN, rejected task definition
+)
+
+// PermanentError marks an executor error that retrying will not fix
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so that RetryPolicy classifies it as a permanent failure
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// RetryPolicy decides whether and when a failed task is retried
+type RetryPolicy struct {
+	BaseDelay          time.Duration // Delay before the first retry
+	MaxDelay           time.Duration // Upper bound on the delay
+	Multiplier         float64       // Growth factor per retry
+	Jitter             float64       // Fraction of the delay randomised in both directions (0-1)
+	PermanentExitCodes []int         // Bot exit codes that mean retrying cannot help
+}
+
+// DefaultRetryPolicy retries after 30s, 1m, 2m, ... up to 30m with ±20% jitter
+var DefaultRetryPolicy = RetryPolicy{
+	BaseDelay:  30 * time.Second,
+	MaxDelay:   30 * time.Minute,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// Backoff returns the delay before retry number retryCount+1
+func (p RetryPolicy) Backoff(retryCount int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(multiplier, float64(retryCount))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	// Spread retries of tasks that failed together (e.g. a capacity outage) over time
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// ClassifyError classifies an error returned by BotExecutor.RunBot
+func (p RetryPolicy) ClassifyError(err error) FailureClass {
+	var permanent *PermanentError
+	if errors.As(err, &permanent) {
+		return FailurePermanent
+	}
+	return FailureTransient
+}
+
+// ClassifyExitCode classifies the exit code of a failed bot. A missing exit code is transient.
+func (p RetryPolicy) ClassifyExitCode(exitCode *int) FailureClass {
+	if exitCode != nil && slices.Contains(p.PermanentExitCodes, *exitCode) {
+		return FailurePermanent
+	}
+	return FailureTransient
+}
@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := domain.RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second, Multiplier: 2}
+
+	assert.Equal(t, time.Second, p.Backoff(0))
+	assert.Equal(t, 2*time.Second, p.Backoff(1))
+	assert.Equal(t, 8*time.Second, p.Backoff(3))
+	assert.Equal(t, 10*time.Second, p.Backoff(10), "delay is capped at MaxDelay")
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	p := domain.RetryPolicy{BaseDelay: 10 * time.Second, Multiplier: 2, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		d := p.Backoff(1)
+		assert.GreaterOrEqual(t, d, 10*time.Second)
+		assert.LessOrEqual(t, d, 30*time.Second)
+	}
+}
+
+func TestRetryPolicy_Classify(t *testing.T) {
+	p := domain.RetryPolicy{PermanentExitCodes: []int{2, 3}}
+
+	assert.Equal(t, domain.FailureTransient, p.ClassifyError(errors.New("throttled")))
+	assert.Equal(t, domain.FailurePermanent, p.ClassifyError(fmt.Errorf("run bot: %w", domain.Permanent(errors.New("invalid parameter")))))
+
+	code := func(c int) *int { return &c }
+	assert.Equal(t, domain.FailurePermanent, p.ClassifyExitCode(code(3)))
+	assert.Equal(t, domain.FailureTransient, p.ClassifyExitCode(code(137)))
+	assert.Equal(t, domain.FailureTransient, p.ClassifyExitCode(nil))
+}
@@ -11,40 +11,113 @@ import (
 type TaskStatus string
 
 const (
-	TaskStatusPending   TaskStatus = "PENDING"
-	TaskStatusRunning   TaskStatus = "RUNNING"
-	TaskStatusCompleted TaskStatus = "COMPLETED"
-	TaskStatusFailed    TaskStatus = "FAILED"
+	TaskStatusPending     TaskStatus = "PENDING"
+	TaskStatusDispatching TaskStatus = "DISPATCHING" // Claimed by the dispatcher, RunBot in progress
+	TaskStatusRunning     TaskStatus = "RUNNING"
+	TaskStatusCompleted   TaskStatus = "COMPLETED"
+	TaskStatusFailed      TaskStatus = "FAILED"
+	TaskStatusTimedOut    TaskStatus = "TIMED_OUT"
+	TaskStatusCancelled   TaskStatus = "CANCELLED"
+	TaskStatusDeadLetter  TaskStatus = "DEAD_LETTER" // Failed permanently; never retried
 )
 
 // AnalysisTask represents a smishing analysis task
 type AnalysisTask struct {
-	ID            uuid.UUID  `gorm:"primary_key;" json:"id"`
-	RequestUUID   string     `gorm:"index" json:"request_uuid"`       // External User/Request UUID (Deprecated/Legacy use)
-	AnalysisID    string     `gorm:"index" json:"analysis_id"`        // Search Server's DB PK
-	FirebaseToken string     `gorm:"type:text" json:"firebase_token"` // Firebase User Token
-	ExternalID    string     `gorm:"index" json:"external_id"`        // AWS Task ARN or similar
-	URL           string     `gorm:"not null" json:"url"`
-	Status        TaskStatus `gorm:"default:'PENDING'" json:"status"`
-	RetryCount    int        `gorm:"default:0" json:"retry_count"`
-	Result        string     `gorm:"type:text" json:"result,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID               uuid.UUID       `gorm:"primary_key;" json:"id"`
+	RequestUUID      string          `gorm:"index" json:"request_uuid"`                                          // External User/Request UUID (Deprecated/Legacy use)
+	AnalysisID       string          `gorm:"index" json:"analysis_id"`                                           // Search Server's DB PK
+	OwnerUID         string          `gorm:"index:idx_analysis_tasks_owner_created,priority:1" json:"owner_uid"` // Verified Firebase UID of the requester
+	SharedTaskID     *uuid.UUID      `gorm:"index" json:"shared_task_id,omitempty"`                              // Task whose bot run this task mirrors (same URL, other owner)
+	FromCache        bool            `gorm:"default:false" json:"from_cache"`                                    // Created COMPLETED with the result of the recently completed SharedTaskID
+	ExternalID       string          `gorm:"index" json:"external_id"`                                           // AWS Task ARN or similar
+	URL              string          `gorm:"not null" json:"url"`
+	CanonicalURL     string          `gorm:"type:text" json:"canonical_url"`          // URL normalized for deduplication
+	RegisteredDomain string          `gorm:"size:255;index" json:"registered_domain"` // eTLD+1 of the URL host, e.g. "example.co.uk"
+	CallbackURL      string          `gorm:"type:text" json:"callback_url,omitempty"` // Receives the outcome once the task reaches a terminal status
+	TraceParent      string          `gorm:"size:55" json:"-"`                        // W3C traceparent of the submitting request; the bot joins this trace
+	Status           TaskStatus      `gorm:"default:'PENDING';index" json:"status"`
+	RetryCount       int             `gorm:"default:0" json:"retry_count"`
+	DispatchAttempts int             `gorm:"default:0" json:"dispatch_attempts"` // Number of times the dispatcher claimed this task
+	LastDispatchedAt *time.Time      `json:"last_dispatched_at,omitempty"`
+	StartedAt        *time.Time      `json:"started_at,omitempty"`                                                // When the bot was launched (entered RUNNING)
+	NextAttemptAt    *time.Time      `gorm:"index" json:"next_attempt_at,omitempty"`                              // Earliest time the retry worker may retry a FAILED/TIMED_OUT task
+	Result           string          `gorm:"type:text" json:"result,omitempty"`                                   // Free-form result or failure reason
+	AnalysisResult   *AnalysisResult `gorm:"foreignKey:TaskID" json:"analysis_result,omitempty"`                  // Structured result of a COMPLETED task
+	CreatedAt        time.Time       `gorm:"index:idx_analysis_tasks_owner_created,priority:2" json:"created_at"` // Owner + created_at backs the default task listing
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// ClaimOptions bounds how many PENDING tasks a single claim may hand out
+type ClaimOptions struct {
+	Limit       int // Maximum number of tasks to claim in one call
+	MaxInFlight int // Global cap on DISPATCHING + RUNNING tasks (0 = unlimited)
+	MaxPerUser  int // Per-owner cap on DISPATCHING + RUNNING tasks (0 = unlimited)
 }
 
 // TaskRepository defines the interface for task persistence
 type TaskRepository interface {
+	// Create stores a new task, with a CallbackDelivery if it is created already finished
 	Create(ctx context.Context, task *AnalysisTask) error
 	GetByID(ctx context.Context, id uuid.UUID) (*AnalysisTask, error)
 	Update(ctx context.Context, task *AnalysisTask) error
+	// SaveTransition persists the task and its status event atomically. It fails with
+	// ErrTransitionConflict if the stored status no longer equals event.FromStatus.
+	// Changes to a task are mirrored to the active tasks sharing it, except cancellation.
+	// Tasks with a CallbackURL that reach a terminal status get a CallbackDelivery.
+	SaveTransition(ctx context.Context, task *AnalysisTask, event *TaskEvent) error
+	// GetLastEvent returns the most recent status event of the task, or nil if it has none
+	GetLastEvent(ctx context.Context, taskID uuid.UUID) (*TaskEvent, error)
+	// HandOverSharedTask moves leader's bot run to the oldest active task sharing it and
+	// returns that task, or nil if no active task shares leader. If leader's bot has not
+	// launched yet, that task returns to PENDING to be dispatched again.
+	HandOverSharedTask(ctx context.Context, leader *AnalysisTask) (*AnalysisTask, error)
 	GetPendingTasks(ctx context.Context) ([]*AnalysisTask, error)
-	GetFailedTasks(ctx context.Context) ([]*AnalysisTask, error)
+	// ClaimPendingTasks atomically moves up to opts.Limit PENDING tasks to DISPATCHING,
+	// oldest first, without exceeding the in-flight caps.
+	ClaimPendingTasks(ctx context.Context, opts ClaimOptions) ([]*AnalysisTask, error)
+	// GetFailedTasks returns FAILED and TIMED_OUT tasks with retries left whose next attempt is due at now
+	GetFailedTasks(ctx context.Context, now time.Time) ([]*AnalysisTask, error)
 	GetRunningTasks(ctx context.Context) ([]*AnalysisTask, error)
-	GetActiveTaskByURL(ctx context.Context, url string) (*AnalysisTask, error)
+	// GetOverdueTasks returns DISPATCHING tasks claimed before dispatchedBefore, RUNNING tasks
+	// without an external ID last updated before dispatchedBefore, and RUNNING tasks started before startedBefore.
+	GetOverdueTasks(ctx context.Context, dispatchedBefore, startedBefore time.Time) ([]*AnalysisTask, error)
+	// GetActiveTaskByURL returns the in-flight or retryable task running the bot for canonicalURL, or nil
+	GetActiveTaskByURL(ctx context.Context, canonicalURL string) (*AnalysisTask, error)
+	// GetActiveTaskByDomain returns the oldest in-flight or retryable task running a bot for a URL
+	// under registeredDomain, or nil
+	GetActiveTaskByDomain(ctx context.Context, registeredDomain string) (*AnalysisTask, error)
+	// GetRecentCompletedTask returns the most recently completed task that ran a bot for
+	// canonicalURL and completed after completedAfter, with its AnalysisResult, or nil.
+	// registeredDomain must be the domain of canonicalURL; it narrows the search through its index.
+	GetRecentCompletedTask(ctx context.Context, canonicalURL, registeredDomain string, completedAfter time.Time) (*AnalysisTask, error)
+	// GetOwnerActiveTaskByURL returns ownerUID's own in-flight or retryable task for canonicalURL, or nil
+	GetOwnerActiveTaskByURL(ctx context.Context, ownerUID, canonicalURL string) (*AnalysisTask, error)
+	// List returns one page of the tasks matching filter. It fails with ErrInvalidQuery
+	// if page.Cursor was not issued for the same sort order.
+	List(ctx context.Context, filter TaskFilter, page Page) (*TaskPage, error)
+	// CountByStatus returns the number of tasks running their own bot in each of statuses
+	CountByStatus(ctx context.Context, statuses []TaskStatus) (map[TaskStatus]int, error)
+	// CreateBatch stores batch together with its links to taskIDs
+	CreateBatch(ctx context.Context, batch *TaskBatch, taskIDs []uuid.UUID) error
+	// GetBatchProgress returns the batch and the status counts of its tasks, or ErrBatchNotFound
+	GetBatchProgress(ctx context.Context, batchID uuid.UUID) (*BatchProgress, error)
+}
+
+// BotStatus is the state of a bot run as reported by a BotExecutor
+type BotStatus struct {
+	Status   TaskStatus
+	ExitCode *int   // Exit code of the bot container, once it has exited
+	Reason   string // Executor-specific detail on failure (e.g. OOMKilled)
 }
 
 // BotExecutor defines the interface for running and checking bot tasks
 type BotExecutor interface {
-	RunBot(ctx context.Context, task *AnalysisTask) (string, error) // Returns external task ID (e.g., ARN)
-	GetBotStatus(ctx context.Context, externalID string) (TaskStatus, error)
+	// RunBot launches a bot with BotEnv(task, env) and returns the external task ID (e.g., ARN).
+	// Errors that retrying cannot fix are wrapped with Permanent.
+	RunBot(ctx context.Context, task *AnalysisTask, env map[string]string) (string, error)
+	GetBotStatus(ctx context.Context, externalID string) (BotStatus, error)
+	StopBot(ctx context.Context, externalID, reason string) error // Stopping an already finished bot is not an error
+	// ReleaseBot frees what a finished bot left behind (e.g. its container) once its outcome
+	// is stored. Releasing a bot that is already gone is not an error.
+	ReleaseBot(ctx context.Context, externalID string) error
 }
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskBatch groups the tasks created by one batch submission
+type TaskBatch struct {
+	ID        uuid.UUID `gorm:"primary_key;" json:"id"`
+	OwnerUID  string    `gorm:"index" json:"owner_uid"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskBatchItem links a batch to one of its tasks. A task already active for the owner
+// when the batch was submitted can belong to several batches.
+type TaskBatchItem struct {
+	BatchID uuid.UUID `gorm:"primaryKey" json:"batch_id"`
+	TaskID  uuid.UUID `gorm:"primaryKey;index" json:"task_id"`
+}
+
+// BatchProgress aggregates the statuses of a batch's tasks
+type BatchProgress struct {
+	Batch        *TaskBatch
+	Total        int
+	Active       int // Tasks still in flight or due to be retried
+	StatusCounts map[TaskStatus]int
+}
+
+// Done reports whether every task of the batch has reached its final outcome
+func (p *BatchProgress) Done() bool {
+	return p.Active == 0
+}
+
+// ErrBatchNotFound is returned when no batch exists with the requested ID
+var ErrBatchNotFound = errors.New("batch not found")
+
+// ErrInvalidBatch is returned for batch submissions that cannot be processed at all
+var ErrInvalidBatch = errors.New("invalid batch")
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TaskFilter selects tasks for TaskRepository.List. Zero-valued fields do not filter.
+type TaskFilter struct {
+	OwnerUID      string
+	Statuses      []TaskStatus
+	URLContains   string // Case-insensitive substring of the URL, e.g. a domain
+	AnalysisID    string
+	CreatedFrom   *time.Time // Inclusive
+	CreatedTo     *time.Time // Exclusive
+	MinRetryCount *int
+	MaxRetryCount *int
+}
+
+// TaskSortField is a column tasks can be listed by
+type TaskSortField string
+
+const (
+	SortByCreatedAt  TaskSortField = "created_at"
+	SortByUpdatedAt  TaskSortField = "updated_at"
+	SortByRetryCount TaskSortField = "retry_count"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Page selects one page of a task listing. Ties on the sort field are broken by task ID.
+type Page struct {
+	SortBy TaskSortField
+	Desc   bool
+	Limit  int
+	Cursor string // Opaque NextCursor of the previous page; empty for the first page
+}
+
+// TaskPage is one page of a task listing
+type TaskPage struct {
+	Tasks      []*AnalysisTask
+	NextCursor string // Empty on the last page
+}
+
+// ErrInvalidQuery is returned for listing requests with an invalid filter, sort or cursor
+var ErrInvalidQuery = errors.New("invalid task query")
+
+// Validate checks the page and fills in defaults. The default order is newest first.
+func (p *Page) Validate() error {
+	switch p.SortBy {
+	case "":
+		p.SortBy = SortByCreatedAt
+		p.Desc = true
+	case SortByCreatedAt, SortByUpdatedAt, SortByRetryCount:
+	default:
+		return fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, p.SortBy)
+	}
+
+	switch {
+	case p.Limit == 0:
+		p.Limit = DefaultPageLimit
+	case p.Limit < 0 || p.Limit > MaxPageLimit:
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxPageLimit)
+	}
+	return nil
+}
+
+// Validate checks the filter for unknown statuses and empty ranges
+func (f *TaskFilter) Validate() error {
+	for _, s := range f.Statuses {
+		if !s.IsValid() {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidQuery, s)
+		}
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidQuery)
+	}
+	if f.MinRetryCount != nil && f.MaxRetryCount != nil && *f.MinRetryCount > *f.MaxRetryCount {
+		return fmt.Errorf("%w: min_retry_count must not exceed max_retry_count", ErrInvalidQuery)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage_Validate(t *testing.T) {
+	p := domain.Page{}
+	assert.NoError(t, p.Validate())
+	assert.Equal(t, domain.SortByCreatedAt, p.SortBy)
+	assert.True(t, p.Desc, "newest first by default")
+	assert.Equal(t, domain.DefaultPageLimit, p.Limit)
+
+	p = domain.Page{SortBy: domain.SortByRetryCount, Limit: 10}
+	assert.NoError(t, p.Validate())
+	assert.False(t, p.Desc, "an explicit sort keeps its order")
+
+	p = domain.Page{SortBy: "url"}
+	assert.ErrorIs(t, p.Validate(), domain.ErrInvalidQuery)
+
+	p = domain.Page{Limit: domain.MaxPageLimit + 1}
+	assert.ErrorIs(t, p.Validate(), domain.ErrInvalidQuery)
+}
+
+func TestTaskFilter_Validate(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	one, two := 1, 2
+
+	valid := domain.TaskFilter{
+		Statuses:      []domain.TaskStatus{domain.TaskStatusFailed, domain.TaskStatusDeadLetter},
+		CreatedFrom:   &earlier,
+		CreatedTo:     &now,
+		MinRetryCount: &one,
+		MaxRetryCount: &two,
+	}
+	assert.NoError(t, valid.Validate())
+
+	cases := map[string]domain.TaskFilter{
+		"unknown status":       {Statuses: []domain.TaskStatus{"DONE"}},
+		"empty created range":  {CreatedFrom: &now, CreatedTo: &earlier},
+		"inverted retry range": {MinRetryCount: &two, MaxRetryCount: &one},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, f.Validate(), domain.ErrInvalidQuery)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskActor identifies who caused a task status transition
+type TaskActor string
+
+const (
+	ActorAPI         TaskActor = "api"
+	ActorWebhook     TaskActor = "webhook"
+	ActorPoller      TaskActor = "poller"
+	ActorRetryWorker TaskActor = "retry_worker"
+	ActorDispatcher  TaskActor = "dispatcher"
+	ActorReaper      TaskActor = "reaper"
+)
+
+// transitions lists the legal target statuses for each status.
+// Statuses without outgoing transitions are terminal.
+var transitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending:     {TaskStatusDispatching, TaskStatusCancelled},
+	TaskStatusDispatching: {TaskStatusPending, TaskStatusRunning, TaskStatusCompleted, TaskStatusFailed, TaskStatusTimedOut, TaskStatusCancelled, TaskStatusDeadLetter},
+	TaskStatusRunning:     {TaskStatusCompleted, TaskStatusFailed, TaskStatusTimedOut, TaskStatusCancelled, TaskStatusDeadLetter},
+	TaskStatusFailed:      {TaskStatusPending, TaskStatusCancelled},
+	TaskStatusTimedOut:    {TaskStatusPending, TaskStatusCancelled},
+	TaskStatusCompleted:   {},
+	TaskStatusCancelled:   {},
+	TaskStatusDeadLetter:  {},
+}
+
+// BotReportableStatuses returns the statuses a bot may report about its own run
+func BotReportableStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusRunning, TaskStatusCompleted, TaskStatusFailed}
+}
+
+// IsValid reports whether s is a known status
+func (s TaskStatus) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from s
+func (s TaskStatus) IsTerminal() bool {
+	next, ok := transitions[s]
+	return ok && len(next) == 0
+}
+
+// TerminalStatuses returns every status without outgoing transitions
+func TerminalStatuses() []TaskStatus {
+	var statuses []TaskStatus
+	for s := range transitions {
+		if s.IsTerminal() {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// ActiveStatuses returns every status with outgoing transitions
+func ActiveStatuses() []TaskStatus {
+	var statuses []TaskStatus
+	for s := range transitions {
+		if !s.IsTerminal() {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// CanTransition reports whether moving from one status to another is legal
+func CanTransition(from, to TaskStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrTaskNotFound is returned when no task exists with the requested ID
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrUnreportableStatus is returned when a bot reports a status that only the server sets
+var ErrUnreportableStatus = errors.New("status cannot be reported by the bot")
+
+// ErrTransitionConflict is returned when the task changed status between
+// being read and being written, so the transition was not applied.
+var ErrTransitionConflict = errors.New("task status changed concurrently")
+
+// InvalidTransitionError is returned for a transition the state machine does not allow
+type InvalidTransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid task status transition from %s to %s", e.From, e.To)
+}
+
+// TaskEvent records a single status transition of an AnalysisTask
+type TaskEvent struct {
+	ID         uuid.UUID  `gorm:"primary_key;" json:"id"`
+	TaskID     uuid.UUID  `gorm:"index;not null" json:"task_id"`
+	FromStatus TaskStatus `json:"from_status"`
+	ToStatus   TaskStatus `gorm:"not null" json:"to_status"`
+	Actor      TaskActor  `gorm:"not null" json:"actor"`
+	Reason     string     `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
+
+// Transition moves the task to status `to` and returns the event describing it.
+// The task is only modified if the transition is legal.
+func (t *AnalysisTask) Transition(to TaskStatus, actor TaskActor, reason string) (*TaskEvent, error) {
+	from := t.Status
+	if !CanTransition(from, to) {
+		return nil, &InvalidTransitionError{From: from, To: to}
+	}
+
+	now := time.Now()
+	t.Status = to
+	t.UpdatedAt = now
+
+	return &TaskEvent{
+		ID:         uuid.New(),
+		TaskID:     t.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      actor,
+		Reason:     reason,
+		CreatedAt:  now,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransition_Legal(t *testing.T) {
+	task := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusRunning}
+
+	event, err := task.Transition(domain.TaskStatusCompleted, domain.ActorPoller, "reported by executor")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	assert.Equal(t, task.ID, event.TaskID)
+	assert.Equal(t, domain.TaskStatusRunning, event.FromStatus)
+	assert.Equal(t, domain.TaskStatusCompleted, event.ToStatus)
+	assert.Equal(t, domain.ActorPoller, event.Actor)
+}
+
+func TestTransition_Illegal(t *testing.T) {
+	task := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusCompleted}
+
+	event, err := task.Transition(domain.TaskStatusRunning, domain.ActorWebhook, "")
+
+	var invalid *domain.InvalidTransitionError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, domain.TaskStatusCompleted, invalid.From)
+	assert.Equal(t, domain.TaskStatusRunning, invalid.To)
+	assert.Nil(t, event)
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status, "task must not change on an illegal transition")
+}
+
+func TestTaskStatus_IsTerminal(t *testing.T) {
+	assert.True(t, domain.TaskStatusCompleted.IsTerminal())
+	assert.True(t, domain.TaskStatusCancelled.IsTerminal())
+	assert.False(t, domain.TaskStatusFailed.IsTerminal())
+	assert.False(t, domain.TaskStatusRunning.IsTerminal())
+	assert.False(t, domain.TaskStatus("BOGUS").IsTerminal())
+	assert.False(t, domain.TaskStatus("BOGUS").IsValid())
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookNonce is the nonce of a verified webhook request. It is kept until the request's
+// timestamp leaves the accepted skew window, after which a replay is rejected as stale anyway.
+type WebhookNonce struct {
+	KeyID     string    `gorm:"primaryKey;size:64" json:"key_id"`
+	Nonce     string    `gorm:"primaryKey;size:128" json:"nonce"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// NonceRepository stores webhook nonces shared by all replicas, so that a signed request
+// accepted by one replica cannot be replayed to another
+type NonceRepository interface {
+	// Remember records the nonce until expiresAt and reports whether it was unused at now.
+	// Of concurrent calls with the same nonce, exactly one reports true.
+	Remember(ctx context.Context, keyID, nonce string, now, expiresAt time.Time) (bool, error)
+	// DeleteExpired removes the nonces that expired at now
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
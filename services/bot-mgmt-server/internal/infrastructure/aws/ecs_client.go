@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -34,7 +35,12 @@ func NewECSClient(cfg aws.Config, cluster, taskDef, containerName string, subnet
 	}
 }
 
-func (c *ECSClient) RunBot(ctx context.Context, task *domain.AnalysisTask) (string, error) {
+func (c *ECSClient) RunBot(ctx context.Context, task *domain.AnalysisTask, env map[string]string) (string, error) {
+	var environment []types.KeyValuePair
+	for _, v := range domain.BotEnv(task, env) {
+		environment = append(environment, types.KeyValuePair{Name: aws.String(v.Name), Value: aws.String(v.Value)})
+	}
+
 	// Prepare environment overrides or command overrides if needed
 	// Passing URL and UUID as environment variables
 	runTaskInput := &ecs.RunTaskInput{
@@ -52,12 +58,8 @@ func (c *ECSClient) RunBot(ctx context.Context, task *domain.AnalysisTask) (stri
 		Overrides: &types.TaskOverride{
 			ContainerOverrides: []types.ContainerOverride{
 				{
-					Name: aws.String(c.containerName),
-					Environment: []types.KeyValuePair{
-						{Name: aws.String("TARGET_URL"), Value: aws.String(task.URL)},
-						{Name: aws.String("USER_ID"), Value: aws.String(task.RequestUUID)},
-						{Name: aws.String("PRIMARY_KEY"), Value: aws.String(task.AnalysisID)},
-					},
+					Name:        aws.String(c.containerName),
+					Environment: environment,
 				},
 			},
 		},
@@ -65,9 +67,23 @@ func (c *ECSClient) RunBot(ctx context.Context, task *domain.AnalysisTask) (stri
 
 	out, err := c.client.RunTask(ctx, runTaskInput)
 	if err != nil {
+		// A rejected task definition or network config fails the same way on every retry
+		var invalid *types.InvalidParameterException
+		if errors.As(err, &invalid) {
+			return "", domain.Permanent(err)
+		}
 		return "", err
 	}
 
+	// Capacity problems are reported as failures rather than an error
+	if len(out.Tasks) == 0 {
+		reason := "no task started"
+		if len(out.Failures) > 0 && out.Failures[0].Reason != nil {
+			reason = *out.Failures[0].Reason
+		}
+		return "", fmt.Errorf("ecs run task failed: %s", reason)
+	}
+
 	taskARN := *out.Tasks[0].TaskArn
 	c.logger.Info("Task started successfully",
 		zap.String("task_arn", taskARN),
@@ -78,43 +94,76 @@ func (c *ECSClient) RunBot(ctx context.Context, task *domain.AnalysisTask) (stri
 	return taskARN, nil
 }
 
-func (c *ECSClient) GetBotStatus(ctx context.Context, externalID string) (domain.TaskStatus, error) {
+func (c *ECSClient) GetBotStatus(ctx context.Context, externalID string) (domain.BotStatus, error) {
 	out, err := c.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
 		Cluster: aws.String(c.cluster),
 		Tasks:   []string{externalID},
 	})
 	if err != nil {
-		return "", err
+		return domain.BotStatus{}, err
 	}
 
 	if len(out.Tasks) == 0 {
-		return "", fmt.Errorf("task not found")
+		return domain.BotStatus{}, fmt.Errorf("task not found")
 	}
 
 	task := out.Tasks[0]
 	// Map AWS status to Domain status
 	switch *task.LastStatus {
 	case "PROVISIONING", "PENDING", "ACTIVATING":
-		return domain.TaskStatusRunning, nil // Treat provisioning as running so we keep polling it
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil // Treat provisioning as running so we keep polling it
 	case "RUNNING":
-		return domain.TaskStatusRunning, nil
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil
 	case "DEACTIVATING", "STOPPING", "DEPROVISIONING":
-		return domain.TaskStatusRunning, nil // Still shutting down
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil // Still shutting down
 	case "STOPPED":
 		// Check container exit code
 		for _, container := range task.Containers {
 			// If we can't find exit code, it's suspicious, but if it is present and 0, success.
 			if container.ExitCode != nil && *container.ExitCode != 0 {
-				return domain.TaskStatusFailed, nil
+				exitCode := int(*container.ExitCode)
+				return domain.BotStatus{
+					Status:   domain.TaskStatusFailed,
+					ExitCode: &exitCode,
+					Reason:   aws.ToString(task.StoppedReason),
+				}, nil
 			}
 		}
 		// If all containers have exit code 0 (or no exit code but stopped?), we consider it completed?
 		// Usually stopped with no exit code means infrastructure issue or user stop.
 		// Let's rely on ExitCode != 0 check for failure.
 
-		return domain.TaskStatusCompleted, nil
+		return domain.BotStatus{Status: domain.TaskStatusCompleted}, nil
 
 	default:
-		return domain.TaskStatusPending, nil
+		return domain.BotStatus{Status: domain.TaskStatusPending}, nil
+	}
+}
+
+// ReleaseBot is a no-op: ECS keeps stopped tasks for a while and then forgets them
+func (c *ECSClient) ReleaseBot(ctx context.Context, externalID string) error {
+	return nil
+}
+
+// maxStopReasonLen is the ECS limit for StopTask reasons
+const maxStopReasonLen = 255
+
+func (c *ECSClient) StopBot(ctx context.Context, externalID, reason string) error {
+	if len(reason) > maxStopReasonLen {
+		reason = reason[:maxStopReasonLen]
+	}
+
+	_, err := c.client.StopTask(ctx, &ecs.StopTaskInput{
+		Cluster: aws.String(c.cluster),
+		Task:    aws.String(externalID),
+		Reason:  aws.String(reason),
+	})
+	if err != nil {
+		return err
 	}
+
+	c.logger.Info("Task stop requested",
+		zap.String("task_arn", externalID),
+		zap.String("reason", reason))
+	return nil
 }
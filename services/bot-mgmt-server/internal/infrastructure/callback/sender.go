@@ -0,0 +1,75 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/hmacauth"
+	"github.com/google/uuid"
+)
+
+// HeaderDeliveryID carries the delivery ID, which stays the same across retries
+const HeaderDeliveryID = "X-Callback-Delivery-Id"
+
+// maxResponseBody bounds how much of a response is read before the connection is reused
+const maxResponseBody = 64 << 10
+
+// HTTPSender POSTs callback payloads signed like bot webhooks (see hmacauth.Sign) with the
+// current key of its keyring. Receivers verify them with the key named in X-Webhook-Key-Id.
+type HTTPSender struct {
+	client  *http.Client
+	keyring *hmacauth.Keyring
+	now     func() time.Time
+}
+
+// NewHTTPSender creates an HTTPSender whose requests time out after timeout.
+// Redirects are not followed, so a callback can never reach a host outside the allowlist.
+func NewHTTPSender(keyring *hmacauth.Keyring, timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		keyring: keyring,
+		now:     time.Now,
+	}
+}
+
+var _ domain.CallbackSender = (*HTTPSender)(nil)
+
+func (s *HTTPSender) Send(ctx context.Context, url string, deliveryID uuid.UUID, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	keyID, secret := s.keyring.CurrentKey()
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	nonce := uuid.NewString()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, deliveryID.String())
+	req.Header.Set(hmacauth.HeaderKeyID, keyID)
+	req.Header.Set(hmacauth.HeaderTimestamp, timestamp)
+	req.Header.Set(hmacauth.HeaderNonce, nonce)
+	req.Header.Set(hmacauth.HeaderSignature, hmacauth.Sign(secret, timestamp, nonce, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("callback endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,70 @@
+package callback
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/hmacauth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_SignsPayload(t *testing.T) {
+	keyring, err := hmacauth.NewKeyring(map[string]string{"k1": "secret"}, "")
+	require.NoError(t, err)
+
+	deliveryID := uuid.New()
+	body := []byte(`{"status":"COMPLETED"}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, got)
+		assert.Equal(t, deliveryID.String(), r.Header.Get(HeaderDeliveryID))
+		assert.Equal(t, "k1", r.Header.Get(hmacauth.HeaderKeyID))
+
+		want := hmacauth.Sign([]byte("secret"), r.Header.Get(hmacauth.HeaderTimestamp), r.Header.Get(hmacauth.HeaderNonce), got)
+		assert.Equal(t, want, r.Header.Get(hmacauth.HeaderSignature))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	code, err := NewHTTPSender(keyring, time.Second).Send(context.Background(), srv.URL, deliveryID, body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+}
+
+func TestHTTPSender_Failures(t *testing.T) {
+	keyring, err := hmacauth.NewKeyring(map[string]string{"k1": "secret"}, "")
+	require.NoError(t, err)
+	sender := NewHTTPSender(keyring, time.Second)
+
+	t.Run("error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		code, err := sender.Send(context.Background(), srv.URL, uuid.New(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+
+	t.Run("redirect is not followed", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("redirect was followed")
+		}))
+		defer target.Close()
+		srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+		defer srv.Close()
+
+		code, err := sender.Send(context.Background(), srv.URL, uuid.New(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, code)
+	})
+}
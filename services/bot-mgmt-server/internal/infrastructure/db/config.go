@@ -0,0 +1,222 @@
+package db
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// TLSMode selects how connections are encrypted and how the server is verified. The
+// names follow the sslmode values of libpq.
+type TLSMode string
+
+const (
+	TLSDisable    TLSMode = "disable"
+	TLSRequire    TLSMode = "require"     // Encrypt, but trust any server certificate
+	TLSVerifyCA   TLSMode = "verify-ca"   // Also check the certificate was issued by the CA
+	TLSVerifyFull TLSMode = "verify-full" // Also check the certificate matches the host name
+)
+
+// TLSConfig holds the certificates for encrypted connections. The files are PEM encoded.
+type TLSConfig struct {
+	Mode     TLSMode
+	CAFile   string // CA bundle to verify the server with; the system roots when empty
+	CertFile string // Client certificate, for servers that authenticate clients by certificate
+	KeyFile  string // Private key of CertFile
+}
+
+// PoolConfig limits the connections kept by each connection pool (the primary and every
+// replica have their own)
+type PoolConfig struct {
+	MaxOpenConns    int           // Zero means unlimited
+	MaxIdleConns    int           // Must not exceed MaxOpenConns
+	ConnMaxLifetime time.Duration // Zero keeps connections forever
+	ConnMaxIdleTime time.Duration // Zero keeps idle connections until ConnMaxLifetime
+}
+
+// Config holds the settings of the database connection
+type Config struct {
+	Driver   string // postgres, mysql or sqlite
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string // Database name, or the database file for sqlite
+
+	// TimeZone is the session time zone on Postgres and the location times are read in on
+	// MySQL. Empty keeps the historical defaults, Asia/Seoul and Local respectively.
+	TimeZone string
+	// StatementTimeout aborts statements that run longer. MySQL only applies it to SELECT
+	// statements. Zero disables it.
+	StatementTimeout time.Duration
+	ConnectTimeout   time.Duration // Zero waits for the operating system to give up
+
+	TLS  TLSConfig
+	Pool PoolConfig
+
+	// Replicas are "host" or "host:port" addresses of read replicas, reached with the
+	// credentials and options of the primary. Only queries that tolerate replication lag
+	// read from them (see ReplicaResolver).
+	Replicas []string
+
+	LogLevel      gormlogger.LogLevel
+	SlowThreshold time.Duration // Queries that run longer are logged as warnings; zero disables it
+
+	// ConnectAttempts is how often NewDB tries to reach the database, so that the server
+	// survives starting before the database does. The wait between attempts starts at
+	// ConnectBackoff and doubles up to maxConnectBackoff.
+	ConnectAttempts int
+	ConnectBackoff  time.Duration
+}
+
+// DefaultConfig is a pool sized for a single replica of the server that waits up to about
+// half a minute for the database on start
+var DefaultConfig = Config{
+	TLS: TLSConfig{Mode: TLSDisable},
+	Pool: PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+	},
+	ConnectTimeout:  10 * time.Second,
+	LogLevel:        gormlogger.Warn,
+	SlowThreshold:   500 * time.Millisecond,
+	ConnectAttempts: 5,
+	ConnectBackoff:  2 * time.Second,
+}
+
+const maxConnectBackoff = 30 * time.Second
+
+// Validate reports settings that cannot work, before any connection is attempted
+func (c Config) Validate() error {
+	switch c.Driver {
+	case "postgres", "mysql":
+	case "sqlite":
+		if len(c.Replicas) > 0 {
+			return errors.New("sqlite does not support read replicas")
+		}
+	case "":
+		return errors.New("database driver is not specified in config (db.driver)")
+	default:
+		return fmt.Errorf("unsupported database driver: %s", c.Driver)
+	}
+
+	switch c.TLS.Mode {
+	case TLSDisable, TLSRequire, TLSVerifyCA, TLSVerifyFull:
+	default:
+		return fmt.Errorf("unsupported TLS mode %q", c.TLS.Mode)
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("TLS client certificate and key must be set together")
+	}
+	if c.TLS.Mode == TLSDisable && (c.TLS.CAFile != "" || c.TLS.CertFile != "") {
+		return errors.New("TLS certificates are set but TLS is disabled")
+	}
+
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone: %w", err)
+		}
+	}
+	if c.StatementTimeout < 0 || c.ConnectTimeout < 0 || c.SlowThreshold < 0 {
+		return errors.New("timeouts must not be negative")
+	}
+
+	p := c.Pool
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 || p.ConnMaxLifetime < 0 || p.ConnMaxIdleTime < 0 {
+		return errors.New("pool settings must not be negative")
+	}
+	if p.MaxOpenConns > 0 && p.MaxIdleConns > p.MaxOpenConns {
+		return fmt.Errorf("max idle connections (%d) exceed max open connections (%d)", p.MaxIdleConns, p.MaxOpenConns)
+	}
+
+	for _, addr := range c.Replicas {
+		if addr == "" {
+			return errors.New("replica address is empty")
+		}
+	}
+
+	if c.ConnectAttempts < 1 {
+		return errors.New("connect attempts must be at least 1")
+	}
+	if c.ConnectBackoff < 0 {
+		return errors.New("connect backoff must not be negative")
+	}
+	return nil
+}
+
+// location is where MySQL times are read in
+func (c Config) location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.TimeZone)
+}
+
+// tlsConfig builds the crypto/tls settings for drivers that take them as a struct. It
+// returns nil when TLS is disabled.
+func (t TLSConfig) tlsConfig(serverName string) (*tls.Config, error) {
+	if t.Mode == TLSDisable || t.Mode == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA file: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", t.CAFile)
+		}
+	}
+
+	switch t.Mode {
+	case TLSRequire:
+		cfg.InsecureSkipVerify = true
+	case TLSVerifyCA:
+		// crypto/tls cannot verify the chain without the host name, so do it ourselves
+		cfg.InsecureSkipVerify = true
+		roots := cfg.RootCAs
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyChain(rawCerts, roots)
+		}
+	}
+	return cfg, nil
+}
+
+// verifyChain checks that the peer certificate was issued by one of roots (the system
+// roots if nil), ignoring the host name
+func verifyChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server sent no certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse server certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func testConfig(driver string) Config {
+	cfg := DefaultConfig
+	cfg.Driver = driver
+	cfg.Host = "db.internal"
+	cfg.Port = "5432"
+	cfg.User = "bot"
+	cfg.Password = "secret"
+	cfg.Name = "bot_db"
+	return cfg
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{"default", func(c *Config) {}, ""},
+		{"no driver", func(c *Config) { c.Driver = "" }, "not specified"},
+		{"unknown driver", func(c *Config) { c.Driver = "oracle" }, "unsupported database driver"},
+		{"sqlite replicas", func(c *Config) { c.Driver = "sqlite"; c.Replicas = []string{"r1"} }, "does not support read replicas"},
+		{"unknown TLS mode", func(c *Config) { c.TLS.Mode = "prefer" }, "unsupported TLS mode"},
+		{"cert without key", func(c *Config) { c.TLS.Mode = TLSVerifyFull; c.TLS.CertFile = "client.pem" }, "set together"},
+		{"CA with TLS disabled", func(c *Config) { c.TLS.CAFile = "ca.pem" }, "TLS is disabled"},
+		{"unknown time zone", func(c *Config) { c.TimeZone = "Mars/Olympus" }, "invalid time zone"},
+		{"negative timeout", func(c *Config) { c.StatementTimeout = -time.Second }, "must not be negative"},
+		{"idle above open", func(c *Config) { c.Pool.MaxOpenConns = 5; c.Pool.MaxIdleConns = 10 }, "exceed max open"},
+		{"idle with unlimited open", func(c *Config) { c.Pool.MaxOpenConns = 0; c.Pool.MaxIdleConns = 10 }, ""},
+		{"empty replica", func(c *Config) { c.Replicas = []string{""} }, "replica address is empty"},
+		{"no attempts", func(c *Config) { c.ConnectAttempts = 0 }, "at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig("postgres")
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostgresDSN(t *testing.T) {
+	cfg := testConfig("postgres")
+	assert.Equal(t,
+		"host=db.internal port=5432 user=bot password=secret dbname=bot_db sslmode=disable TimeZone=Asia/Seoul connect_timeout=10",
+		postgresDSN(cfg, cfg.Host, cfg.Port))
+
+	cfg.Password = `it's a \secret`
+	cfg.TimeZone = "UTC"
+	cfg.ConnectTimeout = 1500 * time.Millisecond
+	cfg.StatementTimeout = 30 * time.Second
+	cfg.TLS = TLSConfig{Mode: TLSVerifyFull, CAFile: "/etc/ssl/db ca.pem", CertFile: "client.pem", KeyFile: "client.key"}
+	assert.Equal(t,
+		`host=replica-1 port=6432 user=bot password='it\'s a \\secret' dbname=bot_db sslmode=verify-full `+
+			`sslrootcert='/etc/ssl/db ca.pem' sslcert=client.pem sslkey=client.key TimeZone=UTC connect_timeout=2 statement_timeout=30000`,
+		postgresDSN(cfg, "replica-1", "6432"))
+}
+
+func TestMySQLConfig(t *testing.T) {
+	cfg := testConfig("mysql")
+	c, err := mysqlConfig(cfg, cfg.Host, "")
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal:3306", c.Addr)
+	assert.Equal(t, time.Local, c.Loc)
+	assert.True(t, c.ParseTime)
+	assert.Nil(t, c.TLS)
+	assert.Equal(t, map[string]string{"charset": "utf8mb4"}, c.Params)
+
+	cfg.TimeZone = "Asia/Seoul"
+	cfg.StatementTimeout = 5 * time.Second
+	cfg.TLS.Mode = TLSVerifyFull
+	c, err = mysqlConfig(cfg, "replica-1", "3307")
+	require.NoError(t, err)
+	assert.Equal(t, "replica-1:3307", c.Addr)
+	assert.Equal(t, "Asia/Seoul", c.Loc.String())
+	assert.Equal(t, "5000", c.Params["max_execution_time"])
+	require.NotNil(t, c.TLS)
+	assert.Equal(t, "replica-1", c.TLS.ServerName)
+	assert.False(t, c.TLS.InsecureSkipVerify)
+}
+
+func TestTLSConfig(t *testing.T) {
+	cfg, err := TLSConfig{Mode: TLSDisable}.tlsConfig("db")
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = TLSConfig{Mode: TLSRequire}.tlsConfig("db")
+	require.NoError(t, err)
+	assert.True(t, cfg.InsecureSkipVerify)
+	assert.Nil(t, cfg.VerifyPeerCertificate)
+
+	// verify-ca checks the chain itself instead of crypto/tls, which would also check the host name
+	cfg, err = TLSConfig{Mode: TLSVerifyCA}.tlsConfig("db")
+	require.NoError(t, err)
+	assert.True(t, cfg.InsecureSkipVerify)
+	require.NotNil(t, cfg.VerifyPeerCertificate)
+	assert.Error(t, cfg.VerifyPeerCertificate(nil, nil))
+
+	_, err = TLSConfig{Mode: TLSVerifyFull, CAFile: filepath.Join(t.TempDir(), "missing.pem")}.tlsConfig("db")
+	assert.ErrorContains(t, err, "CA file")
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	_, err = TLSConfig{Mode: TLSVerifyFull, CAFile: notPEM}.tlsConfig("db")
+	assert.ErrorContains(t, err, "no certificates")
+}
+
+func TestSplitAddr(t *testing.T) {
+	host, port := splitAddr("replica-1:6432", "5432")
+	assert.Equal(t, "replica-1", host)
+	assert.Equal(t, "6432", port)
+
+	host, port = splitAddr("replica-2", "5432")
+	assert.Equal(t, "replica-2", host)
+	assert.Equal(t, "5432", port)
+}
+
+func TestNewDB_SQLite(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Driver = "sqlite"
+	cfg.Name = ":memory:"
+	cfg.LogLevel = gormlogger.Error
+
+	database, err := NewDB(cfg, zap.NewNop())
+	require.NoError(t, err)
+	gormLogger, ok := database.Logger.(*logger.ZapGormLogger)
+	require.True(t, ok)
+	assert.Equal(t, gormlogger.Error, gormLogger.LogLevel)
+	assert.Equal(t, cfg.SlowThreshold, gormLogger.SlowThreshold)
+	assert.NoError(t, database.Exec("SELECT 1").Error)
+
+	cfg.Driver = ""
+	_, err = NewDB(cfg, zap.NewNop())
+	assert.Error(t, err)
+}
@@ -1,51 +1,220 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/SKD-fastcampus/bot-management/pkg/config"
+	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
-// NewDB creates a new database connection based on configuration
-func NewDB(cfg config.Config) (*gorm.DB, error) {
-	driver := cfg.GetString("db.driver")
-	dsn := ""
+// ReplicaResolver routes queries to the read replicas. Queries opt in with
+// Clauses(dbresolver.Use(ReplicaResolver)); all others use the primary, so that nothing
+// reads its own writes from a lagging replica. Without replicas the clause has no effect.
+const ReplicaResolver = "replicas"
 
-	var dialector gorm.Dialector
+// NewDB connects to the database described by cfg, retrying while it is unreachable.
+// Queries are logged through log.
+func NewDB(cfg Config, log *zap.Logger) (*gorm.DB, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	gormCfg := &gorm.Config{Logger: logger.NewGormLogger(log, cfg.LogLevel, cfg.SlowThreshold, true)}
 
-	switch driver {
-	case "mysql":
-		// refer https://github.com/go-sql-driver/mysql#dsn-data-source-name for details
-		dsn = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-			cfg.GetString("db.user"),
-			cfg.GetString("db.password"),
-			cfg.GetString("db.host"),
-			cfg.GetString("db.port"),
-			cfg.GetString("db.name"),
+	if cfg.Driver == "sqlite" {
+		return openSQLite(cfg.Name, gormCfg)
+	}
+
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		database, err := connect(cfg, gormCfg)
+		if err == nil {
+			return database, nil
+		}
+		if attempt >= cfg.ConnectAttempts {
+			return nil, fmt.Errorf("failed to connect to database using %s driver after %d attempts: %w", cfg.Driver, attempt, err)
+		}
+		log.Warn("Database is not reachable, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
 		)
-		dialector = mysql.Open(dsn)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxConnectBackoff)
+	}
+}
+
+// connect opens the pools of the primary and the replicas. gorm.Open pings each of them.
+func connect(cfg Config, gormCfg *gorm.Config) (*gorm.DB, error) {
+	primary, err := dialector(cfg, cfg.Host, cfg.Port)
+	if err != nil {
+		return nil, err
+	}
+	database, err := gorm.Open(primary, gormCfg)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Pool.apply(sqlDB)
+
+	if len(cfg.Replicas) == 0 {
+		return database, nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, addr := range cfg.Replicas {
+		host, port := splitAddr(addr, cfg.Port)
+		replica, err := dialector(cfg, host, port)
+		if err != nil {
+			sqlDB.Close()
+			return nil, err
+		}
+		replicas = append(replicas, replica)
+	}
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas}, ReplicaResolver)
+	resolver.Call(func(pool gorm.ConnPool) error {
+		if sqlDB, ok := pool.(*sql.DB); ok {
+			cfg.Pool.apply(sqlDB)
+		}
+		return nil
+	})
+	if err := database.Use(resolver); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	return database, nil
+}
+
+func (p PoolConfig) apply(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(p.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(p.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(p.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+// dialector returns the dialector of the server at host:port
+func dialector(cfg Config, host, port string) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "mysql":
+		dsnCfg, err := mysqlConfig(cfg, host, port)
+		if err != nil {
+			return nil, err
+		}
+		connector, err := mysqldriver.NewConnector(dsnCfg)
+		if err != nil {
+			return nil, err
+		}
+		// Opened from a connector because the TLS settings cannot be expressed in a DSN
+		return mysql.New(mysql.Config{Conn: sql.OpenDB(connector), DSNConfig: dsnCfg}), nil
 	case "postgres":
-		dsn = fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Seoul",
-			cfg.GetString("db.host"),
-			cfg.GetString("db.user"),
-			cfg.GetString("db.password"),
-			cfg.GetString("db.name"),
-			cfg.GetString("db.port"),
-		)
-		dialector = postgres.Open(dsn)
-	case "":
-		return nil, fmt.Errorf("database driver is not specified in config (db.driver)")
+		return postgres.Open(postgresDSN(cfg, host, port)), nil
 	default:
-		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
+	}
+}
+
+// postgresDSN builds a keyword/value connection string. pgx passes the keywords it does not
+// know, like TimeZone and statement_timeout, on to the server as session settings.
+func postgresDSN(cfg Config, host, port string) string {
+	tz := cfg.TimeZone
+	if tz == "" {
+		tz = "Asia/Seoul"
+	}
+	mode := cfg.TLS.Mode
+	if mode == "" {
+		mode = TLSDisable
+	}
+
+	params := [][2]string{
+		{"host", host},
+		{"port", port},
+		{"user", cfg.User},
+		{"password", cfg.Password},
+		{"dbname", cfg.Name},
+		{"sslmode", string(mode)},
+		{"sslrootcert", cfg.TLS.CAFile},
+		{"sslcert", cfg.TLS.CertFile},
+		{"sslkey", cfg.TLS.KeyFile},
+		{"TimeZone", tz},
+	}
+	if cfg.ConnectTimeout > 0 {
+		// Whole seconds only; round up so that a short timeout does not become "none"
+		params = append(params, [2]string{"connect_timeout", strconv.Itoa(int((cfg.ConnectTimeout + time.Second - 1) / time.Second))})
+	}
+	if cfg.StatementTimeout > 0 {
+		params = append(params, [2]string{"statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)})
+	}
+
+	var b strings.Builder
+	for _, p := range params {
+		if p[1] == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p[0])
+		b.WriteByte('=')
+		b.WriteString(quoteDSNValue(p[1]))
 	}
+	return b.String()
+}
+
+// quoteDSNValue quotes values with spaces, quotes or backslashes, e.g. passwords
+func quoteDSNValue(v string) string {
+	if !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
 
-	db, err := gorm.Open(dialector, &gorm.Config{})
+// mysqlConfig builds the go-sql-driver settings
+func mysqlConfig(cfg Config, host, port string) (*mysqldriver.Config, error) {
+	loc, err := cfg.location()
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database using %s driver: %w", driver, err)
+		return nil, err
+	}
+	if port == "" {
+		port = "3306"
+	}
+	tlsCfg, err := cfg.TLS.tlsConfig(host)
+	if err != nil {
+		return nil, err
 	}
 
-	return db, nil
+	c := mysqldriver.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(host, port)
+	c.DBName = cfg.Name
+	c.ParseTime = true
+	c.Loc = loc
+	c.Timeout = cfg.ConnectTimeout
+	c.TLS = tlsCfg
+	c.Params = map[string]string{"charset": "utf8mb4"}
+	if cfg.StatementTimeout > 0 {
+		c.Params["max_execution_time"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	return c, nil
+}
+
+// splitAddr splits a replica address, defaulting to the port of the primary
+func splitAddr(addr, defaultPort string) (host, port string) {
+	if h, p, err := net.SplitHostPort(addr); err == nil {
+		return h, p
+	}
+	return addr, defaultPort
 }
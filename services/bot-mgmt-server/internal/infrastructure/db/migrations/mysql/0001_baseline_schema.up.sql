@@ -0,0 +1,20 @@
+-- Schema as created by GORM AutoMigrate before migrations were versioned. Databases of
+-- that release are adopted at this version and upgraded by the migrations that follow.
+
+CREATE TABLE analysis_tasks (
+    id varchar(191) NOT NULL,
+    request_uuid varchar(191),
+    analysis_id varchar(191),
+    firebase_token text,
+    external_id varchar(191),
+    url longtext NOT NULL,
+    status varchar(191) DEFAULT 'PENDING',
+    retry_count bigint DEFAULT 0,
+    result text,
+    created_at datetime(3) NULL,
+    updated_at datetime(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_analysis_tasks_request_uuid (request_uuid),
+    INDEX idx_analysis_tasks_analysis_id (analysis_id),
+    INDEX idx_analysis_tasks_external_id (external_id)
+);
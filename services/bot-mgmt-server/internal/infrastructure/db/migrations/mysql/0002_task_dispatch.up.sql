@@ -0,0 +1,7 @@
+-- Owners and dispatch bookkeeping for the database-backed dispatcher
+ALTER TABLE analysis_tasks
+    ADD COLUMN owner_uid varchar(191),
+    ADD COLUMN dispatch_attempts bigint DEFAULT 0,
+    ADD COLUMN last_dispatched_at datetime(3) NULL,
+    ADD INDEX idx_analysis_tasks_owner_uid (owner_uid),
+    ADD INDEX idx_analysis_tasks_status (status);
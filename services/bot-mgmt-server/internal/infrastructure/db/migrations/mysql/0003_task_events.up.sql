@@ -0,0 +1,12 @@
+CREATE TABLE task_events (
+    id varchar(191) NOT NULL,
+    task_id varchar(191) NOT NULL,
+    from_status longtext,
+    to_status longtext NOT NULL,
+    actor longtext NOT NULL,
+    reason text,
+    created_at datetime(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_task_events_task_id (task_id),
+    INDEX idx_task_events_created_at (created_at)
+);
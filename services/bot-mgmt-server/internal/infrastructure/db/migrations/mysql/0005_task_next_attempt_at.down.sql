@@ -0,0 +1,3 @@
+ALTER TABLE analysis_tasks
+    DROP INDEX idx_analysis_tasks_next_attempt_at,
+    DROP COLUMN next_attempt_at;
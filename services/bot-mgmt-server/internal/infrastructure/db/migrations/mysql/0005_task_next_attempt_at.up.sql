@@ -0,0 +1,3 @@
+ALTER TABLE analysis_tasks
+    ADD COLUMN next_attempt_at datetime(3) NULL,
+    ADD INDEX idx_analysis_tasks_next_attempt_at (next_attempt_at);
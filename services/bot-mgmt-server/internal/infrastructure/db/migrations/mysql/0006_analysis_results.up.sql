@@ -0,0 +1,20 @@
+CREATE TABLE analysis_results (
+    task_id varchar(191) NOT NULL,
+    schema_version bigint NOT NULL,
+    verdict varchar(191) NOT NULL,
+    confidence double,
+    final_url text,
+    final_domain varchar(191),
+    redirect_chain json,
+    page_title text,
+    detected_brand varchar(191),
+    artifacts json,
+    indicators json,
+    created_at datetime(3) NULL,
+    updated_at datetime(3) NULL,
+    PRIMARY KEY (task_id),
+    INDEX idx_analysis_results_verdict (verdict),
+    INDEX idx_analysis_results_final_domain (final_domain),
+    INDEX idx_analysis_results_detected_brand (detected_brand),
+    CONSTRAINT fk_analysis_tasks_analysis_result FOREIGN KEY (task_id) REFERENCES analysis_tasks (id)
+);
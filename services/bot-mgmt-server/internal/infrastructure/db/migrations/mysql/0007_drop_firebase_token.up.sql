@@ -0,0 +1,5 @@
+-- Raw Firebase ID tokens are no longer stored. They are nulled before the column is
+-- dropped: an instant DROP COLUMN leaves the old values in the rows until they are
+-- rewritten, and this statement commits on its own should the drop fail.
+UPDATE analysis_tasks SET firebase_token = NULL WHERE firebase_token IS NOT NULL;
+ALTER TABLE analysis_tasks DROP COLUMN firebase_token;
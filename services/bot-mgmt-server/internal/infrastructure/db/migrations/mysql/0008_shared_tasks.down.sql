@@ -0,0 +1,3 @@
+ALTER TABLE analysis_tasks
+    DROP INDEX idx_analysis_tasks_shared_task_id,
+    DROP COLUMN shared_task_id;
@@ -0,0 +1,3 @@
+ALTER TABLE analysis_tasks
+    ADD COLUMN shared_task_id varchar(191),
+    ADD INDEX idx_analysis_tasks_shared_task_id (shared_task_id);
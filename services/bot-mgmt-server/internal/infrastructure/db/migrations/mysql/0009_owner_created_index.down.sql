@@ -0,0 +1,3 @@
+ALTER TABLE analysis_tasks
+    ADD INDEX idx_analysis_tasks_owner_uid (owner_uid),
+    DROP INDEX idx_analysis_tasks_owner_created;
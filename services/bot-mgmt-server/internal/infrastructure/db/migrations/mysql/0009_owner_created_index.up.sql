@@ -0,0 +1,4 @@
+-- Owner + created_at backs the default task listing and replaces the owner index
+ALTER TABLE analysis_tasks
+    ADD INDEX idx_analysis_tasks_owner_created (owner_uid, created_at),
+    DROP INDEX idx_analysis_tasks_owner_uid;
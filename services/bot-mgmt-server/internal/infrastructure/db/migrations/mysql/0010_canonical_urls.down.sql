@@ -0,0 +1,4 @@
+ALTER TABLE analysis_tasks
+    DROP INDEX idx_analysis_tasks_registered_domain,
+    DROP COLUMN registered_domain,
+    DROP COLUMN canonical_url;
@@ -0,0 +1 @@
+ALTER TABLE analysis_tasks ADD COLUMN from_cache boolean DEFAULT false;
@@ -0,0 +1,14 @@
+CREATE TABLE task_batches (
+    id varchar(191) NOT NULL,
+    owner_uid varchar(191),
+    created_at datetime(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_task_batches_owner_uid (owner_uid)
+);
+
+CREATE TABLE task_batch_items (
+    batch_id varchar(191) NOT NULL,
+    task_id varchar(191) NOT NULL,
+    PRIMARY KEY (batch_id, task_id),
+    INDEX idx_task_batch_items_task_id (task_id)
+);
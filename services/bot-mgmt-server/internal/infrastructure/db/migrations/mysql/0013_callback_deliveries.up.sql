@@ -0,0 +1,19 @@
+ALTER TABLE analysis_tasks ADD COLUMN callback_url text;
+
+CREATE TABLE callback_deliveries (
+    id varchar(191) NOT NULL,
+    task_id varchar(191) NOT NULL,
+    url text NOT NULL,
+    status varchar(191) NOT NULL,
+    attempts bigint DEFAULT 0,
+    next_attempt_at datetime(3) NULL,
+    last_attempt_at datetime(3) NULL,
+    response_status bigint,
+    last_error text,
+    created_at datetime(3) NULL,
+    updated_at datetime(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_callback_deliveries_task_id (task_id),
+    INDEX idx_callback_deliveries_status (status),
+    INDEX idx_callback_deliveries_next_attempt_at (next_attempt_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE analysis_tasks DROP COLUMN trace_parent;
@@ -0,0 +1,7 @@
+CREATE TABLE leader_leases (
+    name varchar(128) NOT NULL,
+    holder varchar(255) NOT NULL,
+    expires_at datetime(3) NOT NULL,
+    updated_at datetime(3) NULL,
+    PRIMARY KEY (name)
+);
@@ -0,0 +1,3 @@
+-- The dispatcher claims the oldest PENDING tasks that run their own bot. MySQL has no
+-- partial indexes, so index status and created_at together.
+CREATE INDEX idx_analysis_tasks_pending_claim ON analysis_tasks (status, created_at);
@@ -0,0 +1,20 @@
+-- Schema as created by GORM AutoMigrate before migrations were versioned. Databases of
+-- that release are adopted at this version and upgraded by the migrations that follow.
+
+CREATE TABLE analysis_tasks (
+    id text NOT NULL,
+    request_uuid text,
+    analysis_id text,
+    firebase_token text,
+    external_id text,
+    url text NOT NULL,
+    status text DEFAULT 'PENDING',
+    retry_count bigint DEFAULT 0,
+    result text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_analysis_tasks_request_uuid ON analysis_tasks (request_uuid);
+CREATE INDEX idx_analysis_tasks_analysis_id ON analysis_tasks (analysis_id);
+CREATE INDEX idx_analysis_tasks_external_id ON analysis_tasks (external_id);
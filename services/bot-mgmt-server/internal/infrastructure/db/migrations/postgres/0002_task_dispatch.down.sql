@@ -0,0 +1,6 @@
+DROP INDEX idx_analysis_tasks_status;
+DROP INDEX idx_analysis_tasks_owner_uid;
+ALTER TABLE analysis_tasks
+    DROP COLUMN last_dispatched_at,
+    DROP COLUMN dispatch_attempts,
+    DROP COLUMN owner_uid;
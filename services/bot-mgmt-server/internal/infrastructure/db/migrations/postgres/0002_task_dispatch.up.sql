@@ -0,0 +1,7 @@
+-- Owners and dispatch bookkeeping for the database-backed dispatcher
+ALTER TABLE analysis_tasks
+    ADD COLUMN owner_uid text,
+    ADD COLUMN dispatch_attempts bigint DEFAULT 0,
+    ADD COLUMN last_dispatched_at timestamptz;
+CREATE INDEX idx_analysis_tasks_owner_uid ON analysis_tasks (owner_uid);
+CREATE INDEX idx_analysis_tasks_status ON analysis_tasks (status);
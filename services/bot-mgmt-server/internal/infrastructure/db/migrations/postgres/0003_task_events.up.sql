@@ -0,0 +1,12 @@
+CREATE TABLE task_events (
+    id text NOT NULL,
+    task_id text NOT NULL,
+    from_status text,
+    to_status text NOT NULL,
+    actor text NOT NULL,
+    reason text,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_task_events_task_id ON task_events (task_id);
+CREATE INDEX idx_task_events_created_at ON task_events (created_at);
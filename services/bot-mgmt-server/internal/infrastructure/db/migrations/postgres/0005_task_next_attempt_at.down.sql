@@ -0,0 +1,2 @@
+DROP INDEX idx_analysis_tasks_next_attempt_at;
+ALTER TABLE analysis_tasks DROP COLUMN next_attempt_at;
@@ -0,0 +1,2 @@
+ALTER TABLE analysis_tasks ADD COLUMN next_attempt_at timestamptz;
+CREATE INDEX idx_analysis_tasks_next_attempt_at ON analysis_tasks (next_attempt_at);
@@ -0,0 +1,20 @@
+CREATE TABLE analysis_results (
+    task_id text NOT NULL,
+    schema_version bigint NOT NULL,
+    verdict text NOT NULL,
+    confidence decimal,
+    final_url text,
+    final_domain text,
+    redirect_chain json,
+    page_title text,
+    detected_brand text,
+    artifacts json,
+    indicators json,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (task_id),
+    CONSTRAINT fk_analysis_tasks_analysis_result FOREIGN KEY (task_id) REFERENCES analysis_tasks (id)
+);
+CREATE INDEX idx_analysis_results_verdict ON analysis_results (verdict);
+CREATE INDEX idx_analysis_results_final_domain ON analysis_results (final_domain);
+CREATE INDEX idx_analysis_results_detected_brand ON analysis_results (detected_brand);
@@ -0,0 +1,5 @@
+-- Raw Firebase ID tokens are no longer stored. They are nulled before the column is
+-- dropped: the drop only hides the column, and the old values would otherwise remain in
+-- the table files, and in backups taken from them, until the table is rewritten.
+UPDATE analysis_tasks SET firebase_token = NULL WHERE firebase_token IS NOT NULL;
+ALTER TABLE analysis_tasks DROP COLUMN firebase_token;
@@ -0,0 +1,2 @@
+ALTER TABLE analysis_tasks ADD COLUMN shared_task_id text;
+CREATE INDEX idx_analysis_tasks_shared_task_id ON analysis_tasks (shared_task_id);
@@ -0,0 +1,3 @@
+-- Owner + created_at backs the default task listing and replaces the owner index
+CREATE INDEX idx_analysis_tasks_owner_created ON analysis_tasks (owner_uid, created_at);
+DROP INDEX idx_analysis_tasks_owner_uid;
@@ -0,0 +1,4 @@
+DROP INDEX idx_analysis_tasks_registered_domain;
+ALTER TABLE analysis_tasks
+    DROP COLUMN registered_domain,
+    DROP COLUMN canonical_url;
@@ -0,0 +1,8 @@
+ALTER TABLE analysis_tasks
+    ADD COLUMN canonical_url text,
+    ADD COLUMN registered_domain varchar(255);
+CREATE INDEX idx_analysis_tasks_registered_domain ON analysis_tasks (registered_domain);
+
+-- Existing tasks keep their URL as is, like URLs that fail to canonicalize, so they
+-- still dedupe against identical submissions
+UPDATE analysis_tasks SET canonical_url = url, registered_domain = '' WHERE canonical_url IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE callback_deliveries;
+ALTER TABLE analysis_tasks DROP COLUMN callback_url;
@@ -0,0 +1,19 @@
+ALTER TABLE analysis_tasks ADD COLUMN callback_url text;
+
+CREATE TABLE callback_deliveries (
+    id text NOT NULL,
+    task_id text NOT NULL,
+    url text NOT NULL,
+    status text NOT NULL,
+    attempts bigint DEFAULT 0,
+    next_attempt_at timestamptz,
+    last_attempt_at timestamptz,
+    response_status bigint,
+    last_error text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_callback_deliveries_task_id ON callback_deliveries (task_id);
+CREATE INDEX idx_callback_deliveries_status ON callback_deliveries (status);
+CREATE INDEX idx_callback_deliveries_next_attempt_at ON callback_deliveries (next_attempt_at);
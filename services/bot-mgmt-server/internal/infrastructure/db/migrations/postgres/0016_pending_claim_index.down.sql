@@ -0,0 +1 @@
+DROP INDEX idx_analysis_tasks_pending_claim;
@@ -0,0 +1,8 @@
+-- Nonces of verified webhook requests, shared by all replicas to reject replays
+CREATE TABLE webhook_nonces (
+    key_id varchar(64) NOT NULL,
+    nonce varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (key_id, nonce)
+);
+CREATE INDEX idx_webhook_nonces_expires_at ON webhook_nonces (expires_at);
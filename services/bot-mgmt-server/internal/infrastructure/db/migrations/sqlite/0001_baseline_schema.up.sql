@@ -0,0 +1,19 @@
+-- Same tables and indexes as the Postgres and MySQL schema, for local development and tests
+
+CREATE TABLE analysis_tasks (
+    id text NOT NULL,
+    request_uuid text,
+    analysis_id text,
+    firebase_token text,
+    external_id text,
+    url text NOT NULL,
+    status text DEFAULT 'PENDING',
+    retry_count integer DEFAULT 0,
+    result text,
+    created_at datetime,
+    updated_at datetime,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_analysis_tasks_request_uuid ON analysis_tasks (request_uuid);
+CREATE INDEX idx_analysis_tasks_analysis_id ON analysis_tasks (analysis_id);
+CREATE INDEX idx_analysis_tasks_external_id ON analysis_tasks (external_id);
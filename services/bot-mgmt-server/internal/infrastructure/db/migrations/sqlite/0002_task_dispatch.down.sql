@@ -0,0 +1,6 @@
+-- SQLite cannot drop an indexed column, so the indexes go first
+DROP INDEX idx_analysis_tasks_status;
+DROP INDEX idx_analysis_tasks_owner_uid;
+ALTER TABLE analysis_tasks DROP COLUMN last_dispatched_at;
+ALTER TABLE analysis_tasks DROP COLUMN dispatch_attempts;
+ALTER TABLE analysis_tasks DROP COLUMN owner_uid;
@@ -0,0 +1,5 @@
+ALTER TABLE analysis_tasks ADD COLUMN owner_uid text;
+ALTER TABLE analysis_tasks ADD COLUMN dispatch_attempts integer DEFAULT 0;
+ALTER TABLE analysis_tasks ADD COLUMN last_dispatched_at datetime;
+CREATE INDEX idx_analysis_tasks_owner_uid ON analysis_tasks (owner_uid);
+CREATE INDEX idx_analysis_tasks_status ON analysis_tasks (status);
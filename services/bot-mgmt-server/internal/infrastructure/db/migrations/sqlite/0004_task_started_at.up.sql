@@ -0,0 +1 @@
+ALTER TABLE analysis_tasks ADD COLUMN started_at datetime;
@@ -0,0 +1,2 @@
+-- The scrubbed tokens cannot be restored; the column comes back empty
+ALTER TABLE analysis_tasks ADD COLUMN firebase_token text;
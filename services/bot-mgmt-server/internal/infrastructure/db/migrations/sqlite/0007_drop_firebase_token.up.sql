@@ -0,0 +1,4 @@
+-- Raw Firebase ID tokens are no longer stored. They are nulled before the column is
+-- dropped, as on the other databases.
+UPDATE analysis_tasks SET firebase_token = NULL WHERE firebase_token IS NOT NULL;
+ALTER TABLE analysis_tasks DROP COLUMN firebase_token;
@@ -0,0 +1,2 @@
+DROP INDEX idx_analysis_tasks_shared_task_id;
+ALTER TABLE analysis_tasks DROP COLUMN shared_task_id;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_analysis_tasks_owner_uid ON analysis_tasks (owner_uid);
+DROP INDEX idx_analysis_tasks_owner_created;
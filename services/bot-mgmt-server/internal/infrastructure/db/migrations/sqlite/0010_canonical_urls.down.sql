@@ -0,0 +1,3 @@
+DROP INDEX idx_analysis_tasks_registered_domain;
+ALTER TABLE analysis_tasks DROP COLUMN registered_domain;
+ALTER TABLE analysis_tasks DROP COLUMN canonical_url;
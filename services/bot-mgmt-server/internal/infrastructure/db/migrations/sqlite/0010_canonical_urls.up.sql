@@ -0,0 +1,6 @@
+ALTER TABLE analysis_tasks ADD COLUMN canonical_url text;
+ALTER TABLE analysis_tasks ADD COLUMN registered_domain text;
+CREATE INDEX idx_analysis_tasks_registered_domain ON analysis_tasks (registered_domain);
+
+-- Existing tasks keep their URL as is, like URLs that fail to canonicalize
+UPDATE analysis_tasks SET canonical_url = url, registered_domain = '' WHERE canonical_url IS NULL;
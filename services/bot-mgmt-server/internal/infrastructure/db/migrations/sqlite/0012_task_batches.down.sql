@@ -0,0 +1,2 @@
+DROP TABLE task_batch_items;
+DROP TABLE task_batches;
@@ -0,0 +1,14 @@
+CREATE TABLE task_batches (
+    id text NOT NULL,
+    owner_uid text,
+    created_at datetime,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_task_batches_owner_uid ON task_batches (owner_uid);
+
+CREATE TABLE task_batch_items (
+    batch_id text NOT NULL,
+    task_id text NOT NULL,
+    PRIMARY KEY (batch_id, task_id)
+);
+CREATE INDEX idx_task_batch_items_task_id ON task_batch_items (task_id);
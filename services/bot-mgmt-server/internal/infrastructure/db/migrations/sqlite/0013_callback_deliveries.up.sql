@@ -0,0 +1,19 @@
+ALTER TABLE analysis_tasks ADD COLUMN callback_url text;
+
+CREATE TABLE callback_deliveries (
+    id text NOT NULL,
+    task_id text NOT NULL,
+    url text NOT NULL,
+    status text NOT NULL,
+    attempts integer DEFAULT 0,
+    next_attempt_at datetime,
+    last_attempt_at datetime,
+    response_status integer,
+    last_error text,
+    created_at datetime,
+    updated_at datetime,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_callback_deliveries_task_id ON callback_deliveries (task_id);
+CREATE INDEX idx_callback_deliveries_status ON callback_deliveries (status);
+CREATE INDEX idx_callback_deliveries_next_attempt_at ON callback_deliveries (next_attempt_at);
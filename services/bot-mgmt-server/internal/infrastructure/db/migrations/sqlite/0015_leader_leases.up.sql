@@ -0,0 +1,7 @@
+CREATE TABLE leader_leases (
+    name text NOT NULL,
+    holder text NOT NULL,
+    expires_at datetime NOT NULL,
+    updated_at datetime,
+    PRIMARY KEY (name)
+);
@@ -0,0 +1,3 @@
+-- The dispatcher claims the oldest PENDING tasks that run their own bot
+CREATE INDEX idx_analysis_tasks_pending_claim ON analysis_tasks (created_at)
+    WHERE status = 'PENDING' AND shared_task_id IS NULL;
@@ -0,0 +1,8 @@
+-- Nonces of verified webhook requests, shared by all replicas to reject replays
+CREATE TABLE webhook_nonces (
+    key_id text NOT NULL,
+    nonce text NOT NULL,
+    expires_at datetime NOT NULL,
+    PRIMARY KEY (key_id, nonce)
+);
+CREATE INDEX idx_webhook_nonces_expires_at ON webhook_nonces (expires_at);
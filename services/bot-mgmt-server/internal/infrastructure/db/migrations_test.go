@@ -0,0 +1,146 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations_DialectsStayInStep(t *testing.T) {
+	postgres, err := LoadMigrations("postgres")
+	require.NoError(t, err)
+	require.NotEmpty(t, postgres)
+
+	for _, dialect := range []string{"mysql", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			migrations, err := LoadMigrations(dialect)
+			require.NoError(t, err)
+			require.Len(t, migrations, len(postgres))
+			for i := range postgres {
+				assert.Equal(t, int64(i+1), postgres[i].Version, "versions must be contiguous")
+				assert.Equal(t, postgres[i].ID(), migrations[i].ID())
+				assert.NotEmpty(t, splitStatements(migrations[i].Up))
+				assert.NotEmpty(t, splitStatements(migrations[i].Down))
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_UnknownDialect(t *testing.T) {
+	_, err := LoadMigrations("oracle")
+	assert.Error(t, err)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_second.up.sql":   {Data: []byte("CREATE INDEX b ON t (b);\n")},
+		"m/0002_second.down.sql": {Data: []byte("DROP INDEX b;\n")},
+		"m/0001_first.up.sql":    {Data: []byte("CREATE TABLE t (a int);\n")},
+		"m/0001_first.down.sql":  {Data: []byte("DROP TABLE t;\n")},
+	}
+
+	migrations, err := loadMigrations(fsys, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "0001_first", migrations[0].ID())
+	assert.Equal(t, "0002_second", migrations[1].ID())
+	assert.Equal(t, "DROP TABLE t;\n", migrations[0].Down)
+	assert.Equal(t, checksum("CREATE TABLE t (a int);\n"), migrations[0].Checksum)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{
+			"m/0001_first.up.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"conflicting names", fstest.MapFS{
+			"m/0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+			"m/0001_other.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"stray file", fstest.MapFS{
+			"m/0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+			"m/0001_first.down.sql": {Data: []byte("SELECT 1;")},
+			"m/README.md":           {Data: []byte("notes")},
+		}},
+		{"zero version", fstest.MapFS{
+			"m/0000_first.up.sql":   {Data: []byte("SELECT 1;")},
+			"m/0000_first.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(tt.files, "m")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- Leading comment
+CREATE TABLE t (
+    a text DEFAULT 'x;y',
+    b int
+);
+CREATE INDEX idx_t_a ON t (a)
+    WHERE b > 0;
+
+-- Trailing comment only
+`
+	assert.Equal(t, []string{
+		"-- Leading comment\nCREATE TABLE t (\n    a text DEFAULT 'x;y',\n    b int\n);",
+		"CREATE INDEX idx_t_a ON t (a)\n    WHERE b > 0;",
+	}, splitStatements(sql))
+	assert.Empty(t, splitStatements("-- nothing to do\n"))
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range []string{"postgres", "mysql"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, dialect), 0o755))
+	}
+	for _, f := range []string{"0001_first.up.sql", "0001_first.down.sql", "0002_second.up.sql", "0002_second.down.sql"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "postgres", f), []byte("SELECT 1;\n"), 0o644))
+	}
+
+	paths, err := CreateMigration(dir, "Add Task Labels!")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "mysql", "0003_add_task_labels.up.sql"),
+		filepath.Join(dir, "mysql", "0003_add_task_labels.down.sql"),
+		filepath.Join(dir, "postgres", "0003_add_task_labels.up.sql"),
+		filepath.Join(dir, "postgres", "0003_add_task_labels.down.sql"),
+	}, paths)
+
+	// The generated files load as a valid, empty migration
+	migrations, err := loadMigrations(os.DirFS(filepath.Join(dir, "mysql")), ".")
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, "0003_add_task_labels", migrations[0].ID())
+	assert.Empty(t, splitStatements(migrations[0].Up))
+
+	_, err = CreateMigration(dir, "  !! ")
+	assert.Error(t, err)
+}
+
+func TestLoadMigrations_FirebaseTokensScrubbedBeforeDrop(t *testing.T) {
+	for _, dialect := range []string{"postgres", "mysql", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			migrations, err := LoadMigrations(dialect)
+			require.NoError(t, err)
+			require.Equal(t, "0007_drop_firebase_token", migrations[6].ID())
+
+			statements := splitStatements(migrations[6].Up)
+			require.Len(t, statements, 2)
+			assert.Contains(t, statements[0], "SET firebase_token = NULL")
+			assert.Contains(t, statements[1], "DROP COLUMN firebase_token")
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrSchemaOutOfDate is returned by Migrator.Check when the database is missing
+// migrations known to this binary
+var ErrSchemaOutOfDate = errors.New("database schema is out of date")
+
+// migrationLockName identifies the advisory lock held while migrations run, so replicas
+// starting with db.migrate_on_start do not apply the same migration twice
+const migrationLockName = "bot_mgmt_schema_migrations"
+
+// baselineVersion is the migration matching the schema GORM AutoMigrate created in the
+// baseline release, before migrations were versioned
+const baselineVersion = 1
+
+// SchemaMigration is a row of schema_migrations: one applied migration
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	Checksum  string    `gorm:"size:64;not null"`
+	Dirty     bool      `gorm:"not null"` // Set while a migration runs on a database without transactional DDL
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus describes a migration known to this binary, the database, or both
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Dirty     bool
+	Modified  bool // Applied with a different checksum than the embedded file
+	Unknown   bool // Applied by a newer release; this binary has no file for it
+}
+
+// Migrator applies the embedded migrations of the database's dialect and records them in schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+	logger     *zap.Logger
+}
+
+func NewMigrator(db *gorm.DB, logger *zap.Logger) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	migrations, err := LoadMigrations(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported database dialect %s: %w", dialect, err)
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations, logger: logger}, nil
+}
+
+// Up applies all pending migrations in version order and returns them
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(tx *gorm.DB) error {
+		records, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			if records, err = m.adoptLegacySchema(tx); err != nil {
+				return err
+			}
+		}
+		if err := m.verify(records); err != nil {
+			return err
+		}
+
+		done := make(map[int64]bool, len(records))
+		for _, r := range records {
+			done[r.Version] = true
+		}
+		for _, mig := range m.migrations {
+			if done[mig.Version] {
+				continue
+			}
+			if err := m.apply(tx, mig); err != nil {
+				return err
+			}
+			m.logger.Info("Applied migration", zap.String("migration", mig.ID()))
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the latest `steps` applied migrations and returns them, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+
+	var reverted []Migration
+	err := m.locked(ctx, func(tx *gorm.DB) error {
+		records, err := m.applied(tx)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(records); err != nil {
+			return err
+		}
+
+		known := make(map[int64]Migration, len(m.migrations))
+		for _, mig := range m.migrations {
+			known[mig.Version] = mig
+		}
+		for i := len(records) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig, ok := known[records[i].Version]
+			if !ok {
+				return fmt.Errorf("migration %d was applied by a newer release; roll it back with that release", records[i].Version)
+			}
+			if err := m.revert(tx, mig); err != nil {
+				return err
+			}
+			m.logger.Info("Reverted migration", zap.String("migration", mig.ID()))
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every migration known to this binary or recorded in the database, in version order
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var records []SchemaMigration
+	tx := m.db.WithContext(ctx)
+	if tx.Migrator().HasTable(&SchemaMigration{}) {
+		if err := tx.Order("version").Find(&records).Error; err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+	}
+
+	byVersion := make(map[int64]SchemaMigration, len(records))
+	for _, r := range records {
+		byVersion[r.Version] = r
+	}
+	var statuses []MigrationStatus
+	for _, mig := range m.migrations {
+		s := MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if r, ok := byVersion[mig.Version]; ok {
+			appliedAt := r.AppliedAt
+			s.Applied, s.AppliedAt, s.Dirty = true, &appliedAt, r.Dirty
+			s.Modified = r.Checksum != mig.Checksum
+			delete(byVersion, mig.Version)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, r := range records {
+		if _, ok := byVersion[r.Version]; ok {
+			appliedAt := r.AppliedAt
+			statuses = append(statuses, MigrationStatus{Version: r.Version, Name: r.Name, Applied: true,
+				AppliedAt: &appliedAt, Dirty: r.Dirty, Unknown: true})
+		}
+	}
+	return statuses, nil
+}
+
+// Check returns an error unless every migration of this binary has been applied cleanly.
+// Migrations applied by a newer release are tolerated so that replicas of the previous
+// release keep running during a rolling deploy; migrations must stay backward compatible.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, s := range statuses {
+		id := fmt.Sprintf("%04d_%s", s.Version, s.Name)
+		switch {
+		case s.Dirty:
+			return fmt.Errorf("migration %s did not finish; repair the schema by hand and delete its schema_migrations row", id)
+		case s.Modified:
+			return fmt.Errorf("migration %s was changed after it was applied", id)
+		case !s.Applied:
+			pending = append(pending, id)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: pending migrations %s", ErrSchemaOutOfDate, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// applied returns the recorded migrations in version order, creating schema_migrations if needed
+func (m *Migrator) applied(tx *gorm.DB) ([]SchemaMigration, error) {
+	if !tx.Migrator().HasTable(&SchemaMigration{}) {
+		if err := tx.Migrator().CreateTable(&SchemaMigration{}); err != nil {
+			return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+	}
+
+	var records []SchemaMigration
+	if err := tx.Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return records, nil
+}
+
+// verify refuses to migrate past a dirty migration or one whose file changed after it was applied
+func (m *Migrator) verify(records []SchemaMigration) error {
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+	for _, r := range records {
+		if r.Dirty {
+			return fmt.Errorf("migration %04d_%s did not finish; repair the schema by hand and delete its schema_migrations row", r.Version, r.Name)
+		}
+		if mig, ok := known[r.Version]; ok && mig.Checksum != r.Checksum {
+			return fmt.Errorf("migration %s was changed after it was applied", mig.ID())
+		}
+	}
+	return nil
+}
+
+// adoptLegacySchema records the baseline migration as applied on a database created by
+// GORM AutoMigrate in the baseline release, so that Up applies every migration since.
+// Schemas of later AutoMigrate releases cannot be told apart reliably, so they are refused.
+func (m *Migrator) adoptLegacySchema(tx *gorm.DB) ([]SchemaMigration, error) {
+	schema := tx.Migrator()
+	if !schema.HasTable("analysis_tasks") {
+		return nil, nil
+	}
+
+	if !schema.HasColumn("analysis_tasks", "firebase_token") || schema.HasColumn("analysis_tasks", "owner_uid") {
+		return nil, errors.New("analysis_tasks exists but does not match the baseline schema; only baseline databases can be adopted")
+	}
+	if len(m.migrations) == 0 || m.migrations[0].Version != baselineVersion {
+		return nil, errors.New("analysis_tasks exists but the baseline migration is missing")
+	}
+
+	baseline := m.migrations[0]
+	record := SchemaMigration{Version: baseline.Version, Name: baseline.Name, Checksum: baseline.Checksum, AppliedAt: time.Now()}
+	if err := tx.Create(&record).Error; err != nil {
+		return nil, fmt.Errorf("failed to record migration %s: %w", baseline.ID(), err)
+	}
+	m.logger.Info("Adopted schema created by AutoMigrate", zap.String("migration", baseline.ID()))
+	return []SchemaMigration{record}, nil
+}
+
+// apply runs mig and records it. Postgres and SQLite run both in one transaction; MySQL commits
+// each DDL statement implicitly, so the row is marked dirty until every statement succeeded.
+func (m *Migrator) apply(tx *gorm.DB, mig Migration) error {
+	record := &SchemaMigration{Version: mig.Version, Name: mig.Name, Checksum: mig.Checksum, AppliedAt: time.Now()}
+	run := func(tx *gorm.DB) error {
+		if err := exec(tx, mig.Up); err != nil {
+			return fmt.Errorf("migration %s failed: %w", mig.ID(), err)
+		}
+		return nil
+	}
+
+	if m.transactionalDDL() {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			if err := run(tx); err != nil {
+				return err
+			}
+			return tx.Create(record).Error
+		})
+	}
+
+	record.Dirty = true
+	if err := tx.Create(record).Error; err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", mig.ID(), err)
+	}
+	if err := run(tx); err != nil {
+		return err
+	}
+	return tx.Model(record).Update("dirty", false).Error
+}
+
+// revert runs the down SQL of mig and removes its record, like apply in reverse
+func (m *Migrator) revert(tx *gorm.DB, mig Migration) error {
+	record := &SchemaMigration{Version: mig.Version}
+	run := func(tx *gorm.DB) error {
+		if err := exec(tx, mig.Down); err != nil {
+			return fmt.Errorf("reverting migration %s failed: %w", mig.ID(), err)
+		}
+		return nil
+	}
+
+	if m.transactionalDDL() {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			if err := run(tx); err != nil {
+				return err
+			}
+			return tx.Delete(record).Error
+		})
+	}
+
+	if err := tx.Model(record).Update("dirty", true).Error; err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", mig.ID(), err)
+	}
+	if err := run(tx); err != nil {
+		return err
+	}
+	return tx.Delete(record).Error
+}
+
+func (m *Migrator) transactionalDDL() bool {
+	return m.dialect == "postgres" || m.dialect == "sqlite"
+}
+
+func exec(tx *gorm.DB, sql string) error {
+	for _, stmt := range splitStatements(sql) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locked runs fn on a single connection holding the migration lock. SQLite needs none:
+// it allows a single writer.
+func (m *Migrator) locked(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// A session keeps each statement from leaking clauses into the next one
+		tx := conn.Session(&gorm.Session{})
+		switch m.dialect {
+		case "postgres":
+			if err := tx.Exec("SELECT pg_advisory_lock(hashtext(?))", migrationLockName).Error; err != nil {
+				return fmt.Errorf("failed to lock migrations: %w", err)
+			}
+			defer tx.Exec("SELECT pg_advisory_unlock(hashtext(?))", migrationLockName)
+		case "mysql":
+			var acquired int
+			if err := tx.Raw("SELECT GET_LOCK(?, 60)", migrationLockName).Scan(&acquired).Error; err != nil {
+				return fmt.Errorf("failed to lock migrations: %w", err)
+			}
+			if acquired != 1 {
+				return errors.New("timed out waiting for another replica to finish migrating")
+			}
+			defer tx.Exec("SELECT RELEASE_LOCK(?)", migrationLockName)
+		}
+		return fn(tx)
+	})
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// CreateMigration writes empty up/down files for the next version into every dialect
+// directory under dir and returns their paths
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name is empty")
+	}
+
+	dialects, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	// Versions are shared across dialects so that a change has the same number everywhere
+	var next int64 = 1
+	var dirs []string
+	for _, d := range dialects {
+		if !d.IsDir() {
+			continue
+		}
+		dialectDir := filepath.Join(dir, d.Name())
+		migrations, err := loadMigrations(os.DirFS(dialectDir), ".")
+		if err != nil {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version >= next {
+			next = migrations[n-1].Version + 1
+		}
+		dirs = append(dirs, dialectDir)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no dialect directories in %s", dir)
+	}
+
+	id := Migration{Version: next, Name: name}.ID()
+	var paths []string
+	for _, d := range dirs {
+		for _, direction := range []string{"up", "down"} {
+			p := filepath.Join(d, id+"."+direction+".sql")
+			body := fmt.Sprintf("-- %s (%s)\n", id, direction)
+			if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+				return nil, err
+			}
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
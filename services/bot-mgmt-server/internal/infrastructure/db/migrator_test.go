@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB) {
+	t.Helper()
+	database, err := OpenSQLite(":memory:")
+	require.NoError(t, err)
+	m, err := NewMigrator(database, zap.NewNop())
+	require.NoError(t, err)
+	return m, database
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+
+	assert.ErrorIs(t, m.Check(ctx), ErrSchemaOutOfDate)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(m.migrations))
+	assert.NoError(t, m.Check(ctx))
+	assert.True(t, database.Migrator().HasTable("analysis_tasks"))
+	assert.True(t, database.Migrator().HasIndex("analysis_tasks", "idx_analysis_tasks_pending_claim"))
+	assert.True(t, database.Migrator().HasTable("webhook_nonces"))
+
+	// Running again is a no-op
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, "0017_webhook_nonces", reverted[0].ID())
+	assert.False(t, database.Migrator().HasTable("webhook_nonces"))
+	assert.ErrorIs(t, m.Check(ctx), ErrSchemaOutOfDate)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(m.migrations))
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[len(statuses)-1].Applied)
+
+	// Every down migration runs back to an empty database
+	reverted, err = m.Down(ctx, len(m.migrations)+1)
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(m.migrations)-1)
+	assert.False(t, database.Migrator().HasTable("analysis_tasks"))
+
+	_, err = m.Down(ctx, 0)
+	assert.Error(t, err)
+}
+
+func TestMigrator_ModifiedMigration(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, database.Model(&SchemaMigration{}).Where("version = ?", 1).Update("checksum", "edited").Error)
+
+	assert.ErrorContains(t, m.Check(ctx), "changed after it was applied")
+	_, err = m.Up(ctx)
+	assert.ErrorContains(t, err, "changed after it was applied")
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+}
+
+func TestMigrator_DirtyMigration(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, database.Model(&SchemaMigration{}).Where("version = ?", 2).Update("dirty", true).Error)
+
+	assert.ErrorContains(t, m.Check(ctx), "did not finish")
+	_, err = m.Down(ctx, 1)
+	assert.ErrorContains(t, err, "did not finish")
+}
+
+func TestMigrator_NewerReleaseTolerated(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, database.Create(&SchemaMigration{Version: 99, Name: "future", Checksum: "x"}).Error)
+
+	assert.NoError(t, m.Check(ctx))
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[len(statuses)-1].Unknown)
+
+	_, err = m.Down(ctx, 1)
+	assert.ErrorContains(t, err, "newer release")
+}
+
+// baselineTask is domain.AnalysisTask as of the baseline release, whose schema GORM
+// AutoMigrate created
+type baselineTask struct {
+	ID            uuid.UUID `gorm:"primary_key;"`
+	RequestUUID   string    `gorm:"index"`
+	AnalysisID    string    `gorm:"index"`
+	FirebaseToken string    `gorm:"type:text"`
+	ExternalID    string    `gorm:"index"`
+	URL           string    `gorm:"not null"`
+	Status        string    `gorm:"default:'PENDING'"`
+	RetryCount    int       `gorm:"default:0"`
+	Result        string    `gorm:"type:text"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (baselineTask) TableName() string {
+	return "analysis_tasks"
+}
+
+// models are the tables of the current release, as last created by AutoMigrate
+var models = []any{&domain.AnalysisTask{}, &domain.TaskEvent{}, &domain.AnalysisResult{}, &domain.TaskBatch{},
+	&domain.TaskBatchItem{}, &domain.CallbackDelivery{}, &domain.LeaderLease{}, &domain.WebhookNonce{}}
+
+// assertSchemaMatchesModels checks that every column the current models map to exists
+func assertSchemaMatchesModels(t *testing.T, database *gorm.DB) {
+	t.Helper()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: database}
+		require.NoError(t, stmt.Parse(model))
+		for _, column := range stmt.Schema.DBNames {
+			assert.True(t, database.Migrator().HasColumn(model, column), "%s.%s is missing", stmt.Schema.Table, column)
+		}
+	}
+}
+
+func TestMigrator_SchemaMatchesModels(t *testing.T) {
+	m, database := newTestMigrator(t)
+	_, err := m.Up(context.Background())
+	require.NoError(t, err)
+
+	assertSchemaMatchesModels(t, database)
+}
+
+func TestMigrator_UpgradesBaselineSchema(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+
+	// A database of the baseline release: no schema_migrations and a task created back then
+	require.NoError(t, database.AutoMigrate(&baselineTask{}))
+	legacy := &baselineTask{ID: uuid.New(), URL: "https://example.com/login", Status: "COMPLETED"}
+	require.NoError(t, database.Create(legacy).Error)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, len(m.migrations)-1)
+	assert.Equal(t, "0002_task_dispatch", applied[0].ID())
+	assert.NoError(t, m.Check(ctx))
+	assertSchemaMatchesModels(t, database)
+
+	var task domain.AnalysisTask
+	require.NoError(t, database.First(&task, "id = ?", legacy.ID).Error)
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	assert.Equal(t, legacy.URL, task.CanonicalURL)
+}
+
+func TestMigrator_RejectsLaterLegacySchema(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+
+	require.NoError(t, database.Exec("CREATE TABLE analysis_tasks (id text PRIMARY KEY, firebase_token text, owner_uid text)").Error)
+
+	_, err := m.Up(ctx)
+	assert.ErrorContains(t, err, "does not match the baseline schema")
+}
+
+func TestMigrator_ScrubsFirebaseTokens(t *testing.T) {
+	ctx := context.Background()
+	m, database := newTestMigrator(t)
+
+	require.NoError(t, database.AutoMigrate(&baselineTask{}))
+	legacy := &baselineTask{ID: uuid.New(), URL: "https://example.com", FirebaseToken: "eyJhbGciOiJSUzI1NiJ9.raw-id-token"}
+	require.NoError(t, database.Create(legacy).Error)
+
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.False(t, database.Migrator().HasColumn("analysis_tasks", "firebase_token"))
+
+	// Rolling back past the drop brings the column back without the tokens
+	_, err = m.Down(ctx, len(m.migrations)-6)
+	require.NoError(t, err)
+	require.True(t, database.Migrator().HasColumn("analysis_tasks", "firebase_token"))
+	var tokens []sql.NullString
+	require.NoError(t, database.Table("analysis_tasks").Pluck("firebase_token", &tokens).Error)
+	assert.Equal(t, []sql.NullString{{}}, tokens)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// OpenSQLite opens the SQLite database at path, or a private in-memory database for
+// ":memory:". It needs no cgo and is meant for local development and tests.
+func OpenSQLite(path string) (*gorm.DB, error) {
+	return openSQLite(path, &gorm.Config{})
+}
+
+func openSQLite(path string, gormCfg *gorm.Config) (*gorm.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("database file is not specified in config (db.name)")
+	}
+
+	// Foreign keys are off by default in SQLite; the busy timeout makes writers wait
+	// for each other instead of failing with SQLITE_BUSY
+	sqlDB, err := sql.Open(sqlite.DriverName, path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+	// SQLite allows a single writer, and every connection to ":memory:" would get its
+	// own empty database
+	sqlDB.SetMaxOpenConns(1)
+
+	db, err := gorm.Open(&sqlite.Dialector{Conn: &utcConnPool{sqlDB}}, gormCfg)
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// utcConnPool binds time arguments in UTC. SQLite stores times as text and compares
+// them as strings, which only orders correctly when all values share one offset.
+type utcConnPool struct {
+	*sql.DB
+}
+
+func (p *utcConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.DB.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.DB.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.DB.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+// BeginTx returns a gorm.ConnPool rather than *sql.Tx so that statements in
+// transactions are converted as well
+func (p *utcConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &utcTx{tx}, nil
+}
+
+// GetDBConn exposes the underlying pool to gorm.DB.DB and gorm.DB.Connection
+func (p *utcConnPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+type utcTx struct {
+	*sql.Tx
+}
+
+func (t *utcTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.Tx.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.Tx.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.Tx.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func utcArgs(args []interface{}) []interface{} {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				args[i] = v.UTC()
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,347 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"go.uber.org/zap"
+)
+
+// apiVersion is the Docker Engine API version used for all requests.
+// 1.41 is supported by every Docker Engine release since 20.10.
+const apiVersion = "v1.41"
+
+// DefaultHost is the Docker Engine endpoint used when none is configured.
+const DefaultHost = "unix:///var/run/docker.sock"
+
+// reasonContainerNotFound is reported for a container that no longer exists
+const reasonContainerNotFound = "ContainerNotFound"
+
+// DockerClient runs bots as containers on a local Docker Engine.
+// It talks to the Engine REST API directly so no Docker SDK is required.
+type DockerClient struct {
+	httpClient *http.Client
+	baseURL    string
+	image      string
+	network    string
+	logger     *zap.Logger
+}
+
+// NewDockerClient creates a DockerClient for the given engine host
+// (unix:///path/to/docker.sock or tcp://host:port).
+func NewDockerClient(host, image, network string, logger *zap.Logger) (*DockerClient, error) {
+	if host == "" {
+		host = DefaultHost
+	}
+	if image == "" {
+		return nil, fmt.Errorf("docker image is not specified in config (docker.image)")
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %w", host, err)
+	}
+
+	c := &DockerClient{
+		image:   image,
+		network: network,
+		logger:  logger,
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socketPath := u.Path
+		c.httpClient = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		}
+		// Host part is ignored when dialing a unix socket
+		c.baseURL = "http://docker/" + apiVersion
+	case "tcp", "http":
+		c.httpClient = &http.Client{}
+		c.baseURL = "http://" + u.Host + "/" + apiVersion
+	default:
+		return nil, fmt.Errorf("unsupported docker host scheme: %s", u.Scheme)
+	}
+
+	return c, nil
+}
+
+type createContainerRequest struct {
+	Image      string            `json:"Image"`
+	Env        []string          `json:"Env"`
+	Labels     map[string]string `json:"Labels"`
+	HostConfig hostConfig        `json:"HostConfig"`
+}
+
+type hostConfig struct {
+	NetworkMode string `json:"NetworkMode,omitempty"`
+}
+
+type createContainerResponse struct {
+	ID string `json:"Id"`
+}
+
+type containerState struct {
+	Status    string `json:"Status"`
+	ExitCode  int    `json:"ExitCode"`
+	OOMKilled bool   `json:"OOMKilled"`
+	Error     string `json:"Error"`
+}
+
+type inspectContainerResponse struct {
+	ID    string         `json:"Id"`
+	State containerState `json:"State"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (c *DockerClient) RunBot(ctx context.Context, task *domain.AnalysisTask, env map[string]string) (string, error) {
+	var containerEnv []string
+	for _, v := range domain.BotEnv(task, env) {
+		containerEnv = append(containerEnv, v.Name+"="+v.Value)
+	}
+
+	body := createContainerRequest{
+		Image: c.image,
+		Env:   containerEnv,
+		Labels: map[string]string{
+			"bot-mgmt.task_id": task.ID.String(),
+		},
+		HostConfig: hostConfig{NetworkMode: c.network},
+	}
+
+	containerID, err := c.createContainer(ctx, body)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.do(ctx, http.MethodPost, "/containers/"+containerID+"/start", nil, nil); err != nil {
+		if rmErr := c.removeContainer(ctx, containerID); rmErr != nil {
+			c.logger.Warn("Failed to remove container that did not start",
+				zap.String("container_id", containerID),
+				zap.Error(rmErr))
+		}
+		return "", fmt.Errorf("failed to start container: %w", err)
+	}
+
+	c.logger.Info("Container started successfully",
+		zap.String("container_id", containerID),
+		zap.String("task_id", task.ID.String()),
+		zap.String("request_uuid", task.RequestUUID),
+		zap.String("url", task.URL))
+
+	return containerID, nil
+}
+
+// createContainer creates the bot container, pulling the image once if it is missing locally.
+func (c *DockerClient) createContainer(ctx context.Context, body createContainerRequest) (string, error) {
+	var out createContainerResponse
+	err := c.do(ctx, http.MethodPost, "/containers/create", body, &out)
+	if err == nil {
+		return out.ID, nil
+	}
+
+	if !isNotFound(err) {
+		return "", createError(err)
+	}
+
+	c.logger.Info("Image not found locally, pulling", zap.String("image", c.image))
+	if err := c.pullImage(ctx); err != nil {
+		// The image does not exist in the registry either
+		if isNotFound(err) {
+			return "", domain.Permanent(fmt.Errorf("failed to pull image %s: %w", c.image, err))
+		}
+		return "", fmt.Errorf("failed to pull image %s: %w", c.image, err)
+	}
+
+	if err := c.do(ctx, http.MethodPost, "/containers/create", body, &out); err != nil {
+		return "", createError(err)
+	}
+	return out.ID, nil
+}
+
+// createError wraps a container create failure. A 400 means the container config
+// itself was rejected, which no retry can fix.
+func createError(err error) error {
+	err = fmt.Errorf("failed to create container: %w", err)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.statusCode == http.StatusBadRequest {
+		return domain.Permanent(err)
+	}
+	return err
+}
+
+func (c *DockerClient) pullImage(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.baseURL+"/images/create?fromImage="+url.QueryEscape(c.image), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	// The pull only finishes once the progress stream is fully consumed
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+func (c *DockerClient) GetBotStatus(ctx context.Context, externalID string) (domain.BotStatus, error) {
+	var out inspectContainerResponse
+	if err := c.do(ctx, http.MethodGet, "/containers/"+externalID+"/json", nil, &out); err != nil {
+		if isNotFound(err) {
+			// Removed before its outcome was read, so it can no longer report success
+			return domain.BotStatus{Status: domain.TaskStatusFailed, Reason: reasonContainerNotFound}, nil
+		}
+		return domain.BotStatus{}, err
+	}
+
+	// Map Docker container state to Domain status
+	switch out.State.Status {
+	case "created", "restarting":
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil // Treat as provisioning so we keep polling it
+	case "running", "paused":
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil
+	case "removing":
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil // Still shutting down
+	case "exited", "dead":
+		// Kept until ReleaseBot, so that the outcome can be read again until it is stored
+		exitCode := out.State.ExitCode
+		if out.State.OOMKilled {
+			return domain.BotStatus{Status: domain.TaskStatusFailed, ExitCode: &exitCode, Reason: "OOMKilled"}, nil
+		}
+		if exitCode != 0 {
+			return domain.BotStatus{Status: domain.TaskStatusFailed, ExitCode: &exitCode, Reason: out.State.Error}, nil
+		}
+		return domain.BotStatus{Status: domain.TaskStatusCompleted, ExitCode: &exitCode}, nil
+	default:
+		return domain.BotStatus{Status: domain.TaskStatusPending}, nil
+	}
+}
+
+// stopTimeoutSeconds is how long Docker waits after SIGTERM before killing the container
+const stopTimeoutSeconds = 10
+
+func (c *DockerClient) StopBot(ctx context.Context, externalID, reason string) error {
+	path := fmt.Sprintf("/containers/%s/stop?t=%d", externalID, stopTimeoutSeconds)
+	if err := c.do(ctx, http.MethodPost, path, nil, nil); err != nil {
+		// Already removed
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	c.logger.Info("Container stopped",
+		zap.String("container_id", externalID),
+		zap.String("reason", reason))
+	return c.removeContainer(ctx, externalID)
+}
+
+func (c *DockerClient) ReleaseBot(ctx context.Context, externalID string) error {
+	return c.removeContainer(ctx, externalID)
+}
+
+// removeTimeout bounds the removal of a container, which also runs after ctx has ended
+const removeTimeout = 30 * time.Second
+
+// removeContainer deletes a bot container and its anonymous volumes. A container that is
+// already gone, or already being removed (409), counts as removed.
+func (c *DockerClient) removeContainer(ctx context.Context, containerID string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), removeTimeout)
+	defer cancel()
+
+	err := c.do(ctx, http.MethodDelete, "/containers/"+containerID+"?force=true&v=true", nil, nil)
+	var apiErr *apiError
+	if err == nil || isNotFound(err) || (errors.As(err, &apiErr) && apiErr.statusCode == http.StatusConflict) {
+		return nil
+	}
+	return fmt.Errorf("failed to remove container: %w", err)
+}
+
+type apiError struct {
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("docker api error (status %d): %s", e.statusCode, e.message)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound
+}
+
+// do sends a JSON request to the Docker Engine API and decodes the JSON response into out (if non-nil).
+func (c *DockerClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var reqBody io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 304 Not Modified means the container is already in the requested state
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func decodeError(resp *http.Response) error {
+	b, _ := io.ReadAll(resp.Body)
+	var errResp errorResponse
+	msg := strings.TrimSpace(string(b))
+	if err := json.Unmarshal(b, &errResp); err == nil && errResp.Message != "" {
+		msg = errResp.Message
+	}
+	return &apiError{statusCode: resp.StatusCode, message: msg}
+}
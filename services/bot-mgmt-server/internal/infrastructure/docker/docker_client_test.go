@@ -0,0 +1,213 @@
+package docker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/docker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *docker.DockerClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := docker.NewDockerClient("tcp://"+strings.TrimPrefix(srv.URL, "http://"), "bot:latest", "", zap.NewNop())
+	require.NoError(t, err)
+	return c
+}
+
+func TestRunBot_PassesEnvironment(t *testing.T) {
+	var env []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1.41/containers/create":
+			var body struct{ Env []string }
+			json.NewDecoder(r.Body).Decode(&body)
+			env = body.Env
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"Id":"container-1"}`))
+		case r.URL.Path == "/v1.41/containers/container-1/start":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", RequestUUID: "req-1", AnalysisID: "analysis-1"}
+	id, err := c.RunBot(t.Context(), task, map[string]string{"WEBHOOK_KEY_ID": "k1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "container-1", id)
+	assert.ElementsMatch(t, []string{
+		"TASK_ID=" + task.ID.String(),
+		"TARGET_URL=http://example.com",
+		"USER_ID=req-1",
+		"PRIMARY_KEY=analysis-1",
+		"WEBHOOK_KEY_ID=k1",
+	}, env)
+}
+
+func TestRunBot_MissingImageIsPermanent(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"No such image: bot:latest"}`))
+	})
+
+	_, err := c.RunBot(t.Context(), &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com"}, nil)
+
+	var permanent *domain.PermanentError
+	assert.True(t, errors.As(err, &permanent))
+}
+
+func TestGetBotStatus_MapsContainerState(t *testing.T) {
+	cases := []struct {
+		name  string
+		state string
+		want  domain.TaskStatus
+	}{
+		{"created", `{"Status":"created"}`, domain.TaskStatusRunning},
+		{"running", `{"Status":"running"}`, domain.TaskStatusRunning},
+		{"exited ok", `{"Status":"exited","ExitCode":0}`, domain.TaskStatusCompleted},
+		{"exited with error", `{"Status":"exited","ExitCode":2}`, domain.TaskStatusFailed},
+		{"oom killed", `{"Status":"exited","ExitCode":0,"OOMKilled":true}`, domain.TaskStatusFailed},
+		{"dead", `{"Status":"dead","ExitCode":137}`, domain.TaskStatusFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"Id":"container-1","State":` + tc.state + `}`))
+			})
+
+			status, err := c.GetBotStatus(t.Context(), "container-1")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, status.Status)
+		})
+	}
+}
+
+func TestGetBotStatus_NotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"No such container: container-1"}`))
+	})
+
+	status, err := c.GetBotStatus(t.Context(), "container-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.BotStatus{Status: domain.TaskStatusFailed, Reason: "ContainerNotFound"}, status)
+}
+
+func TestGetBotStatus_KeepsFinishedContainer(t *testing.T) {
+	var removed bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			removed = true
+		}
+		w.Write([]byte(`{"Id":"container-1","State":{"Status":"exited","ExitCode":0}}`))
+	})
+
+	// Polled again if storing the outcome fails, so the container must still be there
+	for i := 0; i < 2; i++ {
+		status, err := c.GetBotStatus(t.Context(), "container-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCompleted, status.Status)
+	}
+	assert.False(t, removed)
+}
+
+func TestReleaseBot_RemovesContainer(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"removed", http.StatusNoContent, false},
+		{"already gone", http.StatusNotFound, false},
+		{"removal in progress", http.StatusConflict, false},
+		{"engine error", http.StatusInternalServerError, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var removed bool
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, "/v1.41/containers/container-1", r.URL.Path)
+				assert.Equal(t, "true", r.URL.Query().Get("force"))
+				removed = true
+				w.WriteHeader(tc.status)
+			})
+
+			err := c.ReleaseBot(t.Context(), "container-1")
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.True(t, removed)
+		})
+	}
+}
+
+func TestStopBot_RemovesContainer(t *testing.T) {
+	cases := []struct {
+		name       string
+		stopStatus int
+		wantRemove bool
+	}{
+		{"running", http.StatusNoContent, true},
+		{"already stopped", http.StatusNotModified, true},
+		{"already removed", http.StatusNotFound, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				switch r.Method {
+				case http.MethodPost:
+					w.WriteHeader(tc.stopStatus)
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})
+
+			require.NoError(t, c.StopBot(t.Context(), "container-1", "cancelled by user"))
+
+			want := []string{"POST /v1.41/containers/container-1/stop"}
+			if tc.wantRemove {
+				want = append(want, "DELETE /v1.41/containers/container-1")
+			}
+			assert.Equal(t, want, calls)
+		})
+	}
+}
+
+func TestRunBot_RemovesContainerThatFailedToStart(t *testing.T) {
+	var removed bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1.41/containers/create":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"Id":"container-1"}`))
+		case r.URL.Path == "/v1.41/containers/container-1/start":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"network bots not found"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1.41/containers/container-1":
+			removed = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	_, err := c.RunBot(t.Context(), &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com"}, nil)
+	assert.ErrorContains(t, err, "failed to start container")
+	assert.True(t, removed)
+}
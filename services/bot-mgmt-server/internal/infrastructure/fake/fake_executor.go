@@ -0,0 +1,284 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/SKD-fastcampus/bot-management/pkg/config"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"go.uber.org/zap"
+)
+
+// OutcomeKind describes how a fake bot run behaves.
+type OutcomeKind string
+
+const (
+	// OutcomeSucceed reports COMPLETED once the run has been polled Polls times.
+	OutcomeSucceed OutcomeKind = "succeed"
+	// OutcomeFail reports FAILED with ExitCode once the run has been polled Polls times.
+	OutcomeFail OutcomeKind = "fail"
+	// OutcomeHang reports RUNNING forever.
+	OutcomeHang OutcomeKind = "hang"
+	// OutcomeRunError makes RunBot itself return an error.
+	OutcomeRunError OutcomeKind = "run_error"
+)
+
+// Outcome is the scripted behaviour of a single bot run.
+type Outcome struct {
+	Kind     OutcomeKind
+	Polls    int    // Number of GetBotStatus calls that still report RUNNING
+	ExitCode int    // Exit code reported for OutcomeFail
+	Message  string // Error message for OutcomeRunError
+	// Permanent marks the OutcomeRunError error as one retrying cannot fix
+	Permanent bool
+}
+
+// Rule applies an Outcome to every task whose URL matches Pattern.
+type Rule struct {
+	Pattern *regexp.Regexp
+	Outcome Outcome
+}
+
+type run struct {
+	task     domain.AnalysisTask
+	env      map[string]string
+	outcome  Outcome
+	polls    int
+	stopped  bool
+	released bool
+}
+
+// FakeExecutor is a deterministic in-process domain.BotExecutor.
+// Rules are evaluated in order; the first matching rule wins and
+// tasks matching no rule get the default outcome.
+type FakeExecutor struct {
+	mu             sync.Mutex
+	defaultOutcome Outcome
+	rules          []Rule
+	runs           map[string]*run
+	seq            int
+	logger         *zap.Logger
+}
+
+// NewFakeExecutor creates a FakeExecutor with the given default outcome and rules.
+func NewFakeExecutor(defaultOutcome Outcome, rules []Rule, logger *zap.Logger) *FakeExecutor {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &FakeExecutor{
+		defaultOutcome: defaultOutcome,
+		rules:          rules,
+		runs:           make(map[string]*run),
+		logger:         logger,
+	}
+}
+
+// NewFakeExecutorFromConfig creates a FakeExecutor from the fake.default and fake.rules config keys.
+//
+//	fake:
+//	  default: { outcome: succeed, polls: 1 }
+//	  rules:
+//	    - { pattern: "fail\\.example", outcome: fail, polls: 2, exit_code: 3 }
+//	    - { pattern: "bad\\.example", outcome: run_error, permanent: true }
+func NewFakeExecutorFromConfig(cfg config.Config, logger *zap.Logger) (*FakeExecutor, error) {
+	defaultOutcome := Outcome{Kind: OutcomeSucceed, Polls: 1}
+	if raw := cfg.GetStringMap("fake.default"); len(raw) > 0 {
+		o, err := parseOutcome(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fake.default: %w", err)
+		}
+		defaultOutcome = o
+	}
+
+	var rules []Rule
+	rawRules, _ := cfg.GetStringMap("fake")["rules"].([]interface{})
+	for i, raw := range rawRules {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid fake.rules[%d]: expected a map", i)
+		}
+		pattern, err := regexp.Compile(fmt.Sprint(m["pattern"]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fake.rules[%d].pattern: %w", i, err)
+		}
+		o, err := parseOutcome(m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fake.rules[%d]: %w", i, err)
+		}
+		rules = append(rules, Rule{Pattern: pattern, Outcome: o})
+	}
+
+	return NewFakeExecutor(defaultOutcome, rules, logger), nil
+}
+
+func parseOutcome(m map[string]interface{}) (Outcome, error) {
+	o := Outcome{Kind: OutcomeKind(fmt.Sprint(m["outcome"]))}
+	switch o.Kind {
+	case OutcomeSucceed, OutcomeFail, OutcomeHang, OutcomeRunError:
+	default:
+		return o, fmt.Errorf("unknown outcome %q", o.Kind)
+	}
+
+	var err error
+	if v, ok := m["polls"]; ok {
+		if o.Polls, err = strconv.Atoi(fmt.Sprint(v)); err != nil {
+			return o, fmt.Errorf("invalid polls: %w", err)
+		}
+	}
+	if v, ok := m["exit_code"]; ok {
+		if o.ExitCode, err = strconv.Atoi(fmt.Sprint(v)); err != nil {
+			return o, fmt.Errorf("invalid exit_code: %w", err)
+		}
+	}
+	if v, ok := m["message"]; ok {
+		o.Message = fmt.Sprint(v)
+	}
+	if v, ok := m["permanent"]; ok {
+		if o.Permanent, err = strconv.ParseBool(fmt.Sprint(v)); err != nil {
+			return o, fmt.Errorf("invalid permanent: %w", err)
+		}
+	}
+	return o, nil
+}
+
+func (e *FakeExecutor) outcomeFor(url string) Outcome {
+	for _, r := range e.rules {
+		if r.Pattern.MatchString(url) {
+			return r.Outcome
+		}
+	}
+	return e.defaultOutcome
+}
+
+func (e *FakeExecutor) RunBot(ctx context.Context, task *domain.AnalysisTask, env map[string]string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	outcome := e.outcomeFor(task.URL)
+	if outcome.Kind == OutcomeRunError {
+		msg := outcome.Message
+		if msg == "" {
+			msg = "fake executor: run error"
+		}
+		if outcome.Permanent {
+			return "", domain.Permanent(fmt.Errorf("%s", msg))
+		}
+		return "", fmt.Errorf("%s", msg)
+	}
+
+	e.seq++
+	externalID := fmt.Sprintf("fake-%d", e.seq)
+	e.runs[externalID] = &run{task: *task, env: env, outcome: outcome}
+
+	e.logger.Info("Fake bot started",
+		zap.String("external_id", externalID),
+		zap.String("task_id", task.ID.String()),
+		zap.String("outcome", string(outcome.Kind)),
+		zap.String("url", task.URL))
+
+	return externalID, nil
+}
+
+func (e *FakeExecutor) GetBotStatus(ctx context.Context, externalID string) (domain.BotStatus, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r, ok := e.runs[externalID]
+	if !ok {
+		return domain.BotStatus{}, fmt.Errorf("task not found")
+	}
+
+	// A stopped run reports FAILED, like a container killed before exiting cleanly
+	if r.stopped {
+		return domain.BotStatus{Status: domain.TaskStatusFailed, Reason: "stopped"}, nil
+	}
+
+	if r.outcome.Kind == OutcomeHang || r.polls < r.outcome.Polls {
+		r.polls++
+		return domain.BotStatus{Status: domain.TaskStatusRunning}, nil
+	}
+
+	if r.outcome.Kind == OutcomeFail {
+		exitCode := r.outcome.ExitCode
+		return domain.BotStatus{Status: domain.TaskStatusFailed, ExitCode: &exitCode}, nil
+	}
+	return domain.BotStatus{Status: domain.TaskStatusCompleted}, nil
+}
+
+func (e *FakeExecutor) StopBot(ctx context.Context, externalID, reason string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if r, ok := e.runs[externalID]; ok {
+		r.stopped = true
+	}
+	return nil
+}
+
+func (e *FakeExecutor) ReleaseBot(ctx context.Context, externalID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if r, ok := e.runs[externalID]; ok {
+		r.released = true
+	}
+	return nil
+}
+
+// Released reports whether ReleaseBot was called for the given run.
+func (e *FakeExecutor) Released(externalID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r, ok := e.runs[externalID]
+	return ok && r.released
+}
+
+// Stopped reports whether StopBot was called for the given run.
+func (e *FakeExecutor) Stopped(externalID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r, ok := e.runs[externalID]
+	return ok && r.stopped
+}
+
+// Env returns the extra environment the given run was started with.
+func (e *FakeExecutor) Env(externalID string) map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if r, ok := e.runs[externalID]; ok {
+		return r.env
+	}
+	return nil
+}
+
+// RunCount returns how many runs were started for tasks with the given URL.
+func (e *FakeExecutor) RunCount(url string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := 0
+	for _, r := range e.runs {
+		if r.task.URL == url {
+			n++
+		}
+	}
+	return n
+}
+
+// ExitCode returns the scripted exit code of a finished run.
+func (e *FakeExecutor) ExitCode(externalID string) (int, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r, ok := e.runs[externalID]
+	if !ok || r.outcome.Kind != OutcomeFail || r.polls < r.outcome.Polls {
+		return 0, false
+	}
+	return r.outcome.ExitCode, true
+}
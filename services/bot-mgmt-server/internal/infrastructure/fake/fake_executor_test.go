@@ -0,0 +1,92 @@
+package fake_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/fake"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExecutor() *fake.FakeExecutor {
+	return fake.NewFakeExecutor(
+		fake.Outcome{Kind: fake.OutcomeSucceed, Polls: 2},
+		[]fake.Rule{
+			{Pattern: regexp.MustCompile(`fail\.example`), Outcome: fake.Outcome{Kind: fake.OutcomeFail, Polls: 1, ExitCode: 3}},
+			{Pattern: regexp.MustCompile(`hang\.example`), Outcome: fake.Outcome{Kind: fake.OutcomeHang}},
+			{Pattern: regexp.MustCompile(`error\.example`), Outcome: fake.Outcome{Kind: fake.OutcomeRunError, Message: "capacity"}},
+		},
+		nil,
+	)
+}
+
+func newTask(url string) *domain.AnalysisTask {
+	return &domain.AnalysisTask{ID: uuid.New(), URL: url}
+}
+
+func TestFakeExecutor_SucceedAfterPolls(t *testing.T) {
+	e := newExecutor()
+
+	id, err := e.RunBot(t.Context(), newTask("http://ok.example.com"), nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		status, err := e.GetBotStatus(t.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusRunning, status.Status)
+	}
+
+	status, err := e.GetBotStatus(t.Context(), id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusCompleted, status.Status)
+}
+
+func TestFakeExecutor_FailWithExitCode(t *testing.T) {
+	e := newExecutor()
+
+	id, err := e.RunBot(t.Context(), newTask("http://fail.example.com"), nil)
+	require.NoError(t, err)
+
+	_, ok := e.ExitCode(id)
+	assert.False(t, ok)
+
+	e.GetBotStatus(t.Context(), id)
+	status, err := e.GetBotStatus(t.Context(), id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusFailed, status.Status)
+	require.NotNil(t, status.ExitCode)
+	assert.Equal(t, 3, *status.ExitCode)
+
+	code, ok := e.ExitCode(id)
+	assert.True(t, ok)
+	assert.Equal(t, 3, code)
+}
+
+func TestFakeExecutor_Hang(t *testing.T) {
+	e := newExecutor()
+
+	id, err := e.RunBot(t.Context(), newTask("http://hang.example.com"), nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		status, err := e.GetBotStatus(t.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusRunning, status.Status)
+	}
+}
+
+func TestFakeExecutor_RunError(t *testing.T) {
+	e := newExecutor()
+
+	_, err := e.RunBot(t.Context(), newTask("http://error.example.com"), nil)
+	assert.EqualError(t, err, "capacity")
+	assert.Equal(t, 0, e.RunCount("http://error.example.com"))
+}
+
+func TestFakeExecutor_UnknownID(t *testing.T) {
+	_, err := newExecutor().GetBotStatus(t.Context(), "fake-404")
+	assert.Error(t, err)
+}
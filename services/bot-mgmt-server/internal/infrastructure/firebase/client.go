@@ -7,9 +7,14 @@ import (
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 	"github.com/SKD-fastcampus/bot-management/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
 
+var tracer = otel.Tracer("github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/firebase")
+
 // TokenVerifier defines the interface for verifying tokens
 type TokenVerifier interface {
 	VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error)
@@ -51,8 +56,13 @@ func NewTokenVerifier(ctx context.Context, cfg config.Config) (TokenVerifier, er
 }
 
 func (v *firebaseTokenVerifier) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	// Verification may fetch Google's signing keys, so it gets a span of its own
+	ctx, span := tracer.Start(ctx, "firebase.VerifyIDToken", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
 	token, err := v.authClient.VerifyIDToken(ctx, idToken)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 	return token, nil
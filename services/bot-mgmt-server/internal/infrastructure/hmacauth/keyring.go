@@ -0,0 +1,85 @@
+package hmacauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+)
+
+// Environment variables through which a bot receives its webhook credentials
+const (
+	EnvKeyID  = "WEBHOOK_KEY_ID"
+	EnvSecret = "WEBHOOK_SECRET"
+)
+
+// maxKeyIDLength bounds key IDs, which are stored with every webhook nonce
+const maxKeyIDLength = 64
+
+// Keyring holds the active webhook signing keys. New bots are given a secret derived
+// from the current key; requests signed with any key in the ring are accepted, so a
+// key can be rotated by adding the new one, making it current, and removing the old
+// one once bots started under it have finished.
+type Keyring struct {
+	keys         map[string][]byte
+	currentKeyID string
+}
+
+// NewKeyring creates a Keyring from key ID -> secret pairs. currentKeyID may be
+// omitted when there is exactly one key.
+func NewKeyring(secrets map[string]string, currentKeyID string) (*Keyring, error) {
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("no webhook secrets configured")
+	}
+
+	keys := make(map[string][]byte, len(secrets))
+	for id, secret := range secrets {
+		if id == "" || secret == "" {
+			return nil, fmt.Errorf("webhook key %q has an empty id or secret", id)
+		}
+		if len(id) > maxKeyIDLength {
+			return nil, fmt.Errorf("webhook key id %q is longer than %d bytes", id, maxKeyIDLength)
+		}
+		keys[id] = []byte(secret)
+	}
+
+	if currentKeyID == "" && len(keys) == 1 {
+		for id := range keys {
+			currentKeyID = id
+		}
+	}
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("current webhook key %q is not in the keyring", currentKeyID)
+	}
+
+	return &Keyring{keys: keys, currentKeyID: currentKeyID}, nil
+}
+
+// TaskSecret derives the secret of a single task from the given key. Bots only ever see
+// their task's secret, so a compromised bot cannot sign updates for other tasks.
+func (k *Keyring) TaskSecret(keyID, taskID string) ([]byte, bool) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("task:" + taskID))
+	return []byte(hex.EncodeToString(mac.Sum(nil))), true
+}
+
+// BotEnv returns the webhook credentials to pass to the bot running task
+func (k *Keyring) BotEnv(task *domain.AnalysisTask) map[string]string {
+	secret, _ := k.TaskSecret(k.currentKeyID, task.ID.String())
+	return map[string]string{
+		EnvKeyID:  k.currentKeyID,
+		EnvSecret: string(secret),
+	}
+}
+
+// CurrentKey returns the ID and secret of the current key, for signing requests this
+// service sends itself
+func (k *Keyring) CurrentKey() (string, []byte) {
+	return k.currentKeyID, k.keys[k.currentKeyID]
+}
@@ -0,0 +1,105 @@
+package hmacauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+)
+
+// Request headers carrying the webhook signature
+const (
+	HeaderKeyID     = "X-Webhook-Key-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix seconds
+	HeaderNonce     = "X-Webhook-Nonce"
+	HeaderSignature = "X-Webhook-Signature" // "sha256=" + hex HMAC-SHA256
+)
+
+const signaturePrefix = "sha256="
+
+// maxNonceLength bounds the nonces stored to detect replays
+const maxNonceLength = 128
+
+// ErrInvalidSignature is returned for any request that fails verification
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the signature header value for a request body.
+// The signed message is "<timestamp>.<nonce>.<body>".
+func Sign(secret []byte, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verifier checks signed webhook requests and rejects stale or replayed ones.
+type Verifier struct {
+	keyring *Keyring
+	maxSkew time.Duration
+	nonces  domain.NonceRepository
+	now     func() time.Time
+}
+
+// NewVerifier creates a Verifier accepting timestamps within maxSkew of the server clock.
+// Nonces are remembered in nonces, so every replica sharing it rejects a replay.
+func NewVerifier(keyring *Keyring, nonces domain.NonceRepository, maxSkew time.Duration) *Verifier {
+	return &Verifier{
+		keyring: keyring,
+		maxSkew: maxSkew,
+		nonces:  nonces,
+		now:     time.Now,
+	}
+}
+
+// Verify checks the signature headers of a webhook request about taskID. Requests that
+// fail verification get an error wrapping ErrInvalidSignature; other errors mean the
+// nonce could not be checked.
+func (v *Verifier) Verify(ctx context.Context, taskID string, header http.Header, body []byte) error {
+	keyID := header.Get(HeaderKeyID)
+	timestamp := header.Get(HeaderTimestamp)
+	nonce := header.Get(HeaderNonce)
+	signature := header.Get(HeaderSignature)
+	if keyID == "" || timestamp == "" || nonce == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("%w: missing signature headers", ErrInvalidSignature)
+	}
+	if len(nonce) > maxNonceLength {
+		return fmt.Errorf("%w: nonce longer than %d bytes", ErrInvalidSignature, maxNonceLength)
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	now := v.now()
+	sentAt := time.Unix(sec, 0)
+	if sentAt.Before(now.Add(-v.maxSkew)) || sentAt.After(now.Add(v.maxSkew)) {
+		return fmt.Errorf("%w: stale timestamp", ErrInvalidSignature)
+	}
+
+	secret, ok := v.keyring.TaskSecret(keyID, taskID)
+	if !ok {
+		return fmt.Errorf("%w: unknown key %q", ErrInvalidSignature, keyID)
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, nonce, body)), []byte(signature)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+
+	// Checked last so unsigned requests cannot burn nonces. A nonce only needs to be
+	// remembered until its timestamp falls outside the skew window.
+	fresh, err := v.nonces.Remember(ctx, keyID, nonce, now, sentAt.Add(v.maxSkew))
+	if err != nil {
+		return fmt.Errorf("failed to record webhook nonce: %w", err)
+	}
+	if !fresh {
+		return fmt.Errorf("%w: nonce already used", ErrInvalidSignature)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package hmacauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedHeader(t *testing.T, k *Keyring, keyID, taskID string, sentAt time.Time, nonce string, body []byte) http.Header {
+	secret, ok := k.TaskSecret(keyID, taskID)
+	require.True(t, ok)
+
+	ts := strconv.FormatInt(sentAt.Unix(), 10)
+	h := http.Header{}
+	h.Set(HeaderKeyID, keyID)
+	h.Set(HeaderTimestamp, ts)
+	h.Set(HeaderNonce, nonce)
+	h.Set(HeaderSignature, Sign(secret, ts, nonce, body))
+	return h
+}
+
+// memoryNonces is a NonceRepository for a single verifier
+type memoryNonces struct {
+	seen map[string]time.Time
+	err  error
+}
+
+var _ domain.NonceRepository = (*memoryNonces)(nil)
+
+func (m *memoryNonces) Remember(_ context.Context, keyID, nonce string, now, expiresAt time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	if exp, ok := m.seen[keyID+":"+nonce]; ok && exp.After(now) {
+		return false, nil
+	}
+	m.seen[keyID+":"+nonce] = expiresAt
+	return true, nil
+}
+
+func (m *memoryNonces) DeleteExpired(context.Context, time.Time) error {
+	return nil
+}
+
+func TestVerifier(t *testing.T) {
+	k, err := NewKeyring(map[string]string{"old": "old-secret", "new": "new-secret"}, "new")
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_000, 0)
+	nonces := &memoryNonces{seen: map[string]time.Time{}}
+	v := NewVerifier(k, nonces, 5*time.Minute)
+	v.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	body := []byte(`{"task_id":"t1","status":"COMPLETED"}`)
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, v.Verify(ctx, "t1", signedHeader(t, k, "new", "t1", now, "n1", body), body))
+	})
+
+	t.Run("rotated out key still in ring", func(t *testing.T) {
+		assert.NoError(t, v.Verify(ctx, "t1", signedHeader(t, k, "old", "t1", now, "n2", body), body))
+	})
+
+	t.Run("replayed nonce", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t1", now, "n3", body)
+		require.NoError(t, v.Verify(ctx, "t1", h, body))
+		assert.True(t, errors.Is(v.Verify(ctx, "t1", h, body), ErrInvalidSignature))
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t1", now.Add(-10*time.Minute), "n4", body)
+		assert.ErrorIs(t, v.Verify(ctx, "t1", h, body), ErrInvalidSignature)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t1", now, "n5", body)
+		assert.ErrorIs(t, v.Verify(ctx, "t1", h, []byte(`{"task_id":"t1","status":"FAILED"}`)), ErrInvalidSignature)
+	})
+
+	t.Run("secret of another task", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t2", now, "n6", body)
+		assert.ErrorIs(t, v.Verify(ctx, "t1", h, body), ErrInvalidSignature)
+	})
+
+	t.Run("same nonce under another key", func(t *testing.T) {
+		require.NoError(t, v.Verify(ctx, "t1", signedHeader(t, k, "new", "t1", now, "n8", body), body))
+		assert.NoError(t, v.Verify(ctx, "t1", signedHeader(t, k, "old", "t1", now, "n8", body), body))
+	})
+
+	t.Run("oversized nonce", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t1", now, strings.Repeat("n", maxNonceLength+1), body)
+		assert.ErrorIs(t, v.Verify(ctx, "t1", h, body), ErrInvalidSignature)
+	})
+
+	t.Run("nonce store unavailable", func(t *testing.T) {
+		nonces.err = errors.New("connection refused")
+		defer func() { nonces.err = nil }()
+
+		err := v.Verify(ctx, "t1", signedHeader(t, k, "new", "t1", now, "n9", body), body)
+		assert.ErrorIs(t, err, nonces.err)
+		assert.NotErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		h := signedHeader(t, k, "new", "t1", now, "n7", body)
+		h.Set(HeaderKeyID, "retired")
+		assert.ErrorIs(t, v.Verify(ctx, "t1", h, body), ErrInvalidSignature)
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring(nil, "")
+	assert.Error(t, err)
+
+	_, err = NewKeyring(map[string]string{"a": "1", "b": "2"}, "")
+	assert.Error(t, err, "current key is ambiguous")
+
+	_, err = NewKeyring(map[string]string{strings.Repeat("k", maxKeyIDLength+1): "1"}, "")
+	assert.Error(t, err, "key id too long to store with nonces")
+
+	k, err := NewKeyring(map[string]string{"a": "1"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", k.currentKeyID)
+}
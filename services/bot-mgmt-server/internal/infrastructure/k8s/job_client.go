@@ -0,0 +1,314 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	labelTaskID = "bot-mgmt/task-id"
+	labelApp    = "app.kubernetes.io/name"
+	appName     = "bot"
+
+	// reasonJobNotFound is reported for a Job that no longer exists, e.g. one removed by
+	// its TTL before its outcome was read
+	reasonJobNotFound = "JobNotFound"
+)
+
+// JobConfig holds the settings applied to every bot Job.
+type JobConfig struct {
+	Namespace               string
+	Image                   string
+	ContainerName           string
+	ServiceAccount          string
+	Env                     []string // Extra KEY=VALUE pairs added to the bot container
+	TTLSecondsAfterFinished *int32   // nil keeps finished Jobs; 0 deletes them as soon as they finish
+	ActiveDeadlineSeconds   int64
+	BackoffLimit            int32
+	Requests                map[string]string // e.g. {"cpu": "250m", "memory": "512Mi"}
+	Limits                  map[string]string
+}
+
+// JobClient runs bots as batch/v1 Jobs on a Kubernetes cluster.
+type JobClient struct {
+	clientset kubernetes.Interface
+	cfg       JobConfig
+	resources corev1.ResourceRequirements
+	logger    *zap.Logger
+}
+
+// NewClientset creates a Kubernetes clientset from a kubeconfig path,
+// falling back to the in-cluster service account when the path is empty.
+func NewClientset(kubeconfig string) (kubernetes.Interface, error) {
+	var restCfg *rest.Config
+	var err error
+
+	if kubeconfig != "" {
+		restCfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		restCfg, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubernetes config: %w", err)
+	}
+
+	return kubernetes.NewForConfig(restCfg)
+}
+
+// NewJobClient creates a JobClient. Resource quantities are validated up front
+// so that a typo in config fails at startup rather than on the first RunBot.
+func NewJobClient(clientset kubernetes.Interface, cfg JobConfig, logger *zap.Logger) (*JobClient, error) {
+	if cfg.Image == "" {
+		return nil, fmt.Errorf("k8s image is not specified in config (k8s.image)")
+	}
+	if cfg.TTLSecondsAfterFinished != nil && *cfg.TTLSecondsAfterFinished < 0 {
+		return nil, fmt.Errorf("k8s.ttl_seconds_after_finished must not be negative")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = "default"
+	}
+	if cfg.ContainerName == "" {
+		cfg.ContainerName = appName
+	}
+
+	requests, err := parseResourceList(cfg.Requests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid k8s resource requests: %w", err)
+	}
+	limits, err := parseResourceList(cfg.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid k8s resource limits: %w", err)
+	}
+
+	return &JobClient{
+		clientset: clientset,
+		cfg:       cfg,
+		resources: corev1.ResourceRequirements{Requests: requests, Limits: limits},
+		logger:    logger,
+	}, nil
+}
+
+func parseResourceList(in map[string]string) (corev1.ResourceList, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := corev1.ResourceList{}
+	for name, value := range in {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s=%q: %w", name, value, err)
+		}
+		out[corev1.ResourceName(name)] = q
+	}
+	return out, nil
+}
+
+func (c *JobClient) RunBot(ctx context.Context, task *domain.AnalysisTask, extraEnv map[string]string) (string, error) {
+	var env []corev1.EnvVar
+	for _, v := range domain.BotEnv(task, extraEnv) {
+		env = append(env, corev1.EnvVar{Name: v.Name, Value: v.Value})
+	}
+	for _, kv := range c.cfg.Env {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	labels := map[string]string{
+		labelApp:    appName,
+		labelTaskID: task.ID.String(),
+	}
+
+	// Include the retry count so a retried task does not collide with its previous Job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("bot-%s-%d", task.ID.String(), task.RetryCount),
+			Namespace: c.cfg.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &c.cfg.BackoffLimit,
+			TTLSecondsAfterFinished: c.cfg.TTLSecondsAfterFinished,
+			ActiveDeadlineSeconds:   int64Ptr(c.cfg.ActiveDeadlineSeconds),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: c.cfg.ServiceAccount,
+					Containers: []corev1.Container{
+						{
+							Name:      c.cfg.ContainerName,
+							Image:     c.cfg.Image,
+							Env:       env,
+							Resources: c.resources,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	created, err := c.clientset.BatchV1().Jobs(c.cfg.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// Created by an earlier call whose response was lost (e.g. it timed out)
+		c.logger.Info("Job already exists",
+			zap.String("job_name", job.Name),
+			zap.String("task_id", task.ID.String()))
+		return job.Name, nil
+	}
+	if err != nil {
+		// The API server rejected the Job spec itself, so retrying cannot help
+		if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+			return "", domain.Permanent(err)
+		}
+		return "", err
+	}
+
+	c.logger.Info("Job created successfully",
+		zap.String("job_name", created.Name),
+		zap.String("namespace", created.Namespace),
+		zap.String("task_id", task.ID.String()),
+		zap.String("request_uuid", task.RequestUUID),
+		zap.String("url", task.URL))
+
+	return created.Name, nil
+}
+
+func (c *JobClient) GetBotStatus(ctx context.Context, externalID string) (domain.BotStatus, error) {
+	job, err := c.clientset.BatchV1().Jobs(c.cfg.Namespace).Get(ctx, externalID, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Deleted before its outcome was read, so it can no longer report success
+			return domain.BotStatus{Status: domain.TaskStatusFailed, Reason: reasonJobNotFound}, nil
+		}
+		return domain.BotStatus{}, err
+	}
+
+	// Pods carry the exit code that the Job conditions do not
+	pods, err := c.clientset.CoreV1().Pods(c.cfg.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		return domain.BotStatus{}, err
+	}
+
+	// Job conditions are authoritative once the Job controller has decided
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete, batchv1.JobSuccessCriteriaMet:
+			return domain.BotStatus{Status: domain.TaskStatusCompleted}, nil
+		case batchv1.JobFailed, batchv1.JobFailureTarget:
+			// Covers DeadlineExceeded, BackoffLimitExceeded and PodFailurePolicy reasons
+			c.logger.Info("Job failed",
+				zap.String("job_name", job.Name),
+				zap.String("reason", cond.Reason),
+				zap.String("message", cond.Message))
+			return domain.BotStatus{Status: domain.TaskStatusFailed, ExitCode: podExitCode(pods.Items), Reason: cond.Reason}, nil
+		}
+	}
+
+	// Otherwise look at the pods for failures the Job controller will not report
+	// until the deadline (e.g. an image that can never be pulled)
+	for _, pod := range pods.Items {
+		reason, replaced := podFailureReason(&pod)
+		if reason == "" || (replaced && c.cfg.BackoffLimit > 0) {
+			// A replaced pod is retried by the Job controller, which reports the final failure itself
+			continue
+		}
+
+		// Deleted first so that the Job cannot still run the bot once the task is retried,
+		// e.g. after a pull of its image finally succeeds
+		if err := c.StopBot(ctx, job.Name, reason); err != nil {
+			return domain.BotStatus{}, err
+		}
+		c.logger.Info("Job pod failed",
+			zap.String("job_name", job.Name),
+			zap.String("pod_name", pod.Name),
+			zap.String("reason", reason))
+		return domain.BotStatus{Status: domain.TaskStatusFailed, ExitCode: podExitCode([]corev1.Pod{pod}), Reason: reason}, nil
+	}
+
+	// Pending pods are treated as provisioning so we keep polling them
+	return domain.BotStatus{Status: domain.TaskStatusRunning}, nil
+}
+
+// podExitCode returns the first non-zero exit code of a terminated bot container, if any.
+func podExitCode(pods []corev1.Pod) *int {
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+				exitCode := int(t.ExitCode)
+				return &exitCode
+			}
+		}
+	}
+	return nil
+}
+
+func (c *JobClient) StopBot(ctx context.Context, externalID, reason string) error {
+	// Background propagation also deletes the Job's pods, which stops the bot
+	propagation := metav1.DeletePropagationBackground
+	err := c.clientset.BatchV1().Jobs(c.cfg.Namespace).Delete(ctx, externalID, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	c.logger.Info("Job deleted",
+		zap.String("job_name", externalID),
+		zap.String("reason", reason))
+	return nil
+}
+
+// ReleaseBot is a no-op: finished Jobs are removed by ttl_seconds_after_finished
+func (c *JobClient) ReleaseBot(ctx context.Context, externalID string) error {
+	return nil
+}
+
+// podFailureReason returns a non-empty reason if the pod can no longer succeed. replaced
+// reports whether the pod has stopped, so that the Job controller starts a new one while
+// the backoff limit allows; a pod stuck waiting is never replaced.
+func podFailureReason(pod *corev1.Pod) (reason string, replaced bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if w := cs.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+				return w.Reason, false
+			}
+		}
+		if t := cs.State.Terminated; t != nil {
+			if t.Reason == "OOMKilled" {
+				return t.Reason, true
+			}
+		}
+	}
+	if pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == "DeadlineExceeded" {
+		return pod.Status.Reason, true
+	}
+	return "", false
+}
+
+func int64Ptr(v int64) *int64 {
+	if v <= 0 {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,242 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/k8s"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const namespace = "bot"
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func newJobClient(t *testing.T, clientset *fake.Clientset) *k8s.JobClient {
+	c, err := k8s.NewJobClient(clientset, k8s.JobConfig{
+		Namespace:               namespace,
+		Image:                   "bot:latest",
+		Env:                     []string{"LOG_LEVEL=debug"},
+		TTLSecondsAfterFinished: int32Ptr(600),
+		ActiveDeadlineSeconds:   900,
+		Requests:                map[string]string{"cpu": "250m"},
+		Limits:                  map[string]string{"memory": "1Gi"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	return c
+}
+
+func TestRunBot_CreatesJob(t *testing.T) {
+	clientset := fake.NewClientset()
+	c := newJobClient(t, clientset)
+
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", RequestUUID: "req-1", AnalysisID: "analysis-1"}
+	name, err := c.RunBot(t.Context(), task, nil)
+	require.NoError(t, err)
+
+	job, err := clientset.BatchV1().Jobs(namespace).Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(600), *job.Spec.TTLSecondsAfterFinished)
+	assert.Equal(t, int64(900), *job.Spec.ActiveDeadlineSeconds)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "bot:latest", container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "TARGET_URL", Value: "http://example.com"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "PRIMARY_KEY", Value: "analysis-1"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"})
+	assert.Equal(t, "250m", container.Resources.Requests.Cpu().String())
+	assert.Equal(t, "1Gi", container.Resources.Limits.Memory().String())
+}
+
+func TestRunBot_JobAlreadyExists(t *testing.T) {
+	clientset := fake.NewClientset()
+	c := newJobClient(t, clientset)
+
+	// e.g. the first RunBot created the Job but timed out before its response arrived
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", RetryCount: 1}
+	first, err := c.RunBot(t.Context(), task, nil)
+	require.NoError(t, err)
+
+	second, err := c.RunBot(t.Context(), task, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, jobs.Items, 1)
+}
+
+func TestNewJobClient_InvalidResource(t *testing.T) {
+	_, err := k8s.NewJobClient(fake.NewClientset(), k8s.JobConfig{
+		Image:    "bot:latest",
+		Requests: map[string]string{"cpu": "lots"},
+	}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestGetBotStatus(t *testing.T) {
+	jobWith := func(conds ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "bot-1", Namespace: namespace},
+			Status:     batchv1.JobStatus{Conditions: conds},
+		}
+	}
+	podWith := func(state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bot-1-abcde",
+				Namespace: namespace,
+				Labels:    map[string]string{batchv1.JobNameLabel: "bot-1"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "bot", State: state}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name string
+		job  *batchv1.Job
+		pod  *corev1.Pod
+		want domain.TaskStatus
+	}{
+		{
+			name: "complete",
+			job:  jobWith(batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+			want: domain.TaskStatusCompleted,
+		},
+		{
+			name: "deadline exceeded",
+			job:  jobWith(batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: batchv1.JobReasonDeadlineExceeded}),
+			want: domain.TaskStatusFailed,
+		},
+		{
+			name: "image pull backoff",
+			job:  jobWith(),
+			pod:  podWith(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}),
+			want: domain.TaskStatusFailed,
+		},
+		{
+			name: "oom killed",
+			job:  jobWith(),
+			pod:  podWith(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}),
+			want: domain.TaskStatusFailed,
+		},
+		{
+			name: "container creating",
+			job:  jobWith(),
+			pod:  podWith(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}),
+			want: domain.TaskStatusRunning,
+		},
+		{
+			name: "running",
+			job:  jobWith(),
+			pod:  podWith(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}),
+			want: domain.TaskStatusRunning,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientset := fake.NewClientset(tc.job)
+			if tc.pod != nil {
+				_, err := clientset.CoreV1().Pods(namespace).Create(t.Context(), tc.pod, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			c := newJobClient(t, clientset)
+
+			status, err := c.GetBotStatus(t.Context(), "bot-1")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, status.Status)
+		})
+	}
+}
+
+func TestGetBotStatus_PodFailure(t *testing.T) {
+	oomKilled := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}
+	imagePull := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}}
+
+	cases := []struct {
+		name         string
+		backoffLimit int32
+		state        corev1.ContainerState
+		want         domain.TaskStatus
+		deleted      bool
+	}{
+		{name: "no retries left", backoffLimit: 0, state: oomKilled, want: domain.TaskStatusFailed, deleted: true},
+		{name: "replaced by the job controller", backoffLimit: 2, state: oomKilled, want: domain.TaskStatusRunning},
+		{name: "never replaced", backoffLimit: 2, state: imagePull, want: domain.TaskStatusFailed, deleted: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientset := fake.NewClientset(
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "bot-1", Namespace: namespace}},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bot-1-abcde",
+						Namespace: namespace,
+						Labels:    map[string]string{batchv1.JobNameLabel: "bot-1"},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{Name: "bot", State: tc.state}},
+					},
+				},
+			)
+			c, err := k8s.NewJobClient(clientset, k8s.JobConfig{Namespace: namespace, Image: "bot:latest", BackoffLimit: tc.backoffLimit}, zap.NewNop())
+			require.NoError(t, err)
+
+			status, err := c.GetBotStatus(t.Context(), "bot-1")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, status.Status)
+
+			// A task reported FAILED is retried, so its Job must not run the bot any more
+			_, err = clientset.BatchV1().Jobs(namespace).Get(t.Context(), "bot-1", metav1.GetOptions{})
+			assert.Equal(t, tc.deleted, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestGetBotStatus_NotFound(t *testing.T) {
+	c := newJobClient(t, fake.NewClientset())
+
+	// e.g. removed by ttl_seconds_after_finished before the poller got to it
+	status, err := c.GetBotStatus(t.Context(), "missing")
+	require.NoError(t, err)
+	assert.Equal(t, domain.BotStatus{Status: domain.TaskStatusFailed, Reason: "JobNotFound"}, status)
+}
+
+func TestRunBot_TTLSecondsAfterFinished(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  *int32
+	}{
+		{"unset keeps finished jobs", nil},
+		{"zero deletes them at once", int32Ptr(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewClientset()
+			c, err := k8s.NewJobClient(clientset, k8s.JobConfig{Namespace: namespace, Image: "bot:latest", TTLSecondsAfterFinished: tt.ttl}, zap.NewNop())
+			require.NoError(t, err)
+
+			name, err := c.RunBot(t.Context(), &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com"}, nil)
+			require.NoError(t, err)
+
+			job, err := clientset.BatchV1().Jobs(namespace).Get(t.Context(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.ttl, job.Spec.TTLSecondsAfterFinished)
+		})
+	}
+}
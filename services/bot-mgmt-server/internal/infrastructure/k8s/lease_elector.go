@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/leader"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaseElector elects a leader through a coordination.k8s.io Lease, for deployments that
+// run on Kubernetes. The server's service account needs get, create and update on leases.
+type LeaseElector struct {
+	config   leaderelection.LeaderElectionConfig
+	identity string
+
+	mu      sync.RWMutex
+	elector *leaderelection.LeaderElector // Of the current election round
+}
+
+var _ leader.Elector = (*LeaseElector)(nil)
+
+// NewLeaseElector creates a LeaseElector for the Lease cfg.Name in namespace; onChange may be nil
+func NewLeaseElector(clientset kubernetes.Interface, namespace string, cfg leader.Config, logger *zap.Logger, onChange leader.Observer) (*LeaseElector, error) {
+	if namespace == "" {
+		namespace = "default"
+	}
+	if onChange == nil {
+		onChange = func(bool) {}
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: cfg.Name, Namespace: namespace},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: cfg.Identity},
+	}
+
+	e := &LeaseElector{identity: cfg.Identity}
+	var leading atomic.Bool
+	e.config = leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: cfg.LeaseDuration,
+		// The leader gives up if it cannot renew for this long, before anyone else may take over
+		RenewDeadline:   cfg.LeaseDuration * 2 / 3,
+		RetryPeriod:     cfg.RenewInterval,
+		ReleaseOnCancel: true,
+		Name:            cfg.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				leading.Store(true)
+				logger.Info("Became leader", zap.String("lease", cfg.Name), zap.String("identity", cfg.Identity))
+				onChange(true)
+			},
+			OnStoppedLeading: func() {
+				// Called at the end of every round, including rounds never won
+				if !leading.Swap(false) {
+					return
+				}
+				logger.Info("Lost leadership", zap.String("lease", cfg.Name), zap.String("identity", cfg.Identity))
+				onChange(false)
+			},
+		},
+	}
+
+	// Validates the config up front so that a bad one fails at startup
+	elector, err := leaderelection.NewLeaderElector(e.config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	e.elector = elector
+	return e, nil
+}
+
+func (e *LeaseElector) Run(ctx context.Context) {
+	// An election round ends when leadership is lost; campaign again in a new round until shutdown
+	for {
+		e.current().Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		elector, err := leaderelection.NewLeaderElector(e.config)
+		if err != nil {
+			return // Unreachable: the config was validated by NewLeaseElector
+		}
+		e.mu.Lock()
+		e.elector = elector
+		e.mu.Unlock()
+	}
+}
+
+func (e *LeaseElector) current() *leaderelection.LeaderElector {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.elector
+}
+
+func (e *LeaseElector) IsLeader() bool {
+	return e.current().IsLeader()
+}
+
+func (e *LeaseElector) Status() leader.Status {
+	elector := e.current()
+	return leader.Status{Identity: e.identity, Leader: elector.GetLeader(), IsLeader: elector.IsLeader()}
+}
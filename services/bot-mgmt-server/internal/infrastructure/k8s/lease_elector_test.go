@@ -0,0 +1,61 @@
+package k8s_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/k8s"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/leader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeaseElector_ElectsOneLeaderAndHandsOver(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	cfg := leader.Config{Name: "bot-mgmt-workers", LeaseDuration: 600 * time.Millisecond, RenewInterval: 50 * time.Millisecond}
+
+	var aLeading atomic.Bool
+	aCfg := cfg
+	aCfg.Identity = "a"
+	a, err := k8s.NewLeaseElector(clientset, namespace, aCfg, zap.NewNop(), aLeading.Store)
+	require.NoError(t, err)
+
+	bCfg := cfg
+	bCfg.Identity = "b"
+	b, err := k8s.NewLeaseElector(clientset, namespace, bCfg, zap.NewNop(), nil)
+	require.NoError(t, err)
+
+	ctxA, stopA := context.WithCancel(t.Context())
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		a.Run(ctxA)
+	}()
+	require.Eventually(t, a.IsLeader, 2*time.Second, 10*time.Millisecond)
+	assert.True(t, aLeading.Load())
+
+	go b.Run(t.Context())
+	require.Eventually(t, func() bool { return b.Status().Leader == "a" }, 2*time.Second, 10*time.Millisecond)
+	assert.False(t, b.IsLeader())
+
+	lease, err := clientset.CoordinationV1().Leases(namespace).Get(t.Context(), "bot-mgmt-workers", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "a", *lease.Spec.HolderIdentity)
+
+	// a releases the Lease on shutdown and b takes over
+	stopA()
+	<-doneA
+	assert.False(t, aLeading.Load())
+	require.Eventually(t, b.IsLeader, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestNewLeaseElector_RejectsInvalidConfig(t *testing.T) {
+	cfg := leader.Config{Name: "workers", Identity: "a", LeaseDuration: time.Second, RenewInterval: time.Second}
+	_, err := k8s.NewLeaseElector(fake.NewSimpleClientset(), namespace, cfg, zap.NewNop(), nil)
+	assert.Error(t, err)
+}
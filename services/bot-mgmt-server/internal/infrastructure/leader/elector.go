@@ -0,0 +1,61 @@
+package leader
+
+import (
+	"context"
+	"time"
+)
+
+// Elector decides which replica runs the background workers that must not run concurrently
+// (e.g. retrying failed tasks or polling the executor). Every replica runs an Elector;
+// at most one of them is the leader at a time.
+type Elector interface {
+	// Run campaigns for leadership until ctx ends, then gives up any leadership held
+	Run(ctx context.Context)
+	// IsLeader reports whether this replica currently holds leadership
+	IsLeader() bool
+	// Status returns this replica's identity and the leader it last observed
+	Status() Status
+}
+
+// Status is a replica's view of the election
+type Status struct {
+	Identity string `json:"identity"`
+	Leader   string `json:"leader"` // Empty if no replica holds leadership
+	IsLeader bool   `json:"is_leader"`
+}
+
+// Observer is notified whenever this replica gains or loses leadership
+type Observer func(isLeader bool)
+
+// Config holds the settings shared by all election backends
+type Config struct {
+	Name     string // Name of the lease the replicas compete for
+	Identity string // Unique name of this replica, e.g. the pod name
+	// LeaseDuration is how long leadership lasts without renewal; a crashed leader is
+	// replaced within this time. A leader that shuts down hands over immediately.
+	LeaseDuration time.Duration
+	// RenewInterval is how often the leader renews its lease and followers try to take it
+	RenewInterval time.Duration
+}
+
+// DefaultConfig fails over within 15 seconds
+var DefaultConfig = Config{
+	Name:          "bot-mgmt-workers",
+	LeaseDuration: 15 * time.Second,
+	RenewInterval: 5 * time.Second,
+}
+
+// Always is an Elector for single-replica deployments: it is always the leader
+type Always struct {
+	Identity string
+}
+
+func (a Always) Run(ctx context.Context) {}
+
+func (a Always) IsLeader() bool {
+	return true
+}
+
+func (a Always) Status() Status {
+	return Status{Identity: a.Identity, Leader: a.Identity, IsLeader: true}
+}
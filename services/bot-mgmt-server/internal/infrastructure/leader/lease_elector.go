@@ -0,0 +1,124 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"go.uber.org/zap"
+)
+
+// LeaseElector elects a leader through a lease row in the database, so that it works with
+// every database the server supports and needs no extra infrastructure.
+type LeaseElector struct {
+	repo     domain.LeaseRepository
+	cfg      Config
+	logger   *zap.Logger
+	onChange Observer
+
+	mu          sync.RWMutex
+	leader      string
+	isLeader    bool
+	leaderUntil time.Time // Leadership is only trusted until the last renewed lease would expire
+}
+
+var _ Elector = (*LeaseElector)(nil)
+
+// NewLeaseElector creates a LeaseElector; onChange may be nil
+func NewLeaseElector(repo domain.LeaseRepository, cfg Config, logger *zap.Logger, onChange Observer) *LeaseElector {
+	if onChange == nil {
+		onChange = func(bool) {}
+	}
+	return &LeaseElector{repo: repo, cfg: cfg, logger: logger, onChange: onChange}
+}
+
+func (e *LeaseElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign acquires or renews the lease once
+func (e *LeaseElector) campaign(ctx context.Context) {
+	now := time.Now()
+	acquired, err := e.repo.TryAcquire(ctx, e.cfg.Name, e.cfg.Identity, now, now.Add(e.cfg.LeaseDuration))
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// Our lease may still be valid; leadership lapses on its own once it would have expired
+		e.logger.Error("Failed to renew leader lease", zap.String("lease", e.cfg.Name), zap.Error(err))
+		e.mu.Lock()
+		lapsed := e.isLeader && !now.Before(e.leaderUntil)
+		e.mu.Unlock()
+		if lapsed {
+			e.setLeader(false, "", time.Time{})
+		}
+		return
+	}
+
+	if acquired {
+		e.setLeader(true, e.cfg.Identity, now.Add(e.cfg.LeaseDuration))
+		return
+	}
+
+	holder, err := e.repo.GetHolder(ctx, e.cfg.Name, now)
+	if err != nil {
+		e.logger.Error("Failed to read leader lease", zap.String("lease", e.cfg.Name), zap.Error(err))
+	}
+	e.setLeader(false, holder, time.Time{})
+}
+
+// release hands leadership over on shutdown instead of making the others wait for the lease to expire
+func (e *LeaseElector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.RenewInterval)
+	defer cancel()
+	if err := e.repo.Release(ctx, e.cfg.Name, e.cfg.Identity); err != nil {
+		e.logger.Error("Failed to release leader lease", zap.String("lease", e.cfg.Name), zap.Error(err))
+	}
+	e.setLeader(false, "", time.Time{})
+}
+
+func (e *LeaseElector) setLeader(isLeader bool, leader string, until time.Time) {
+	e.mu.Lock()
+	changed := isLeader != e.isLeader
+	e.isLeader, e.leader, e.leaderUntil = isLeader, leader, until
+	e.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if isLeader {
+		e.logger.Info("Became leader", zap.String("lease", e.cfg.Name), zap.String("identity", e.cfg.Identity))
+	} else {
+		e.logger.Info("Lost leadership", zap.String("lease", e.cfg.Name), zap.String("identity", e.cfg.Identity))
+	}
+	e.onChange(isLeader)
+}
+
+func (e *LeaseElector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	// A stalled campaign must not leave us acting as leader after the lease has expired
+	return e.isLeader && time.Now().Before(e.leaderUntil)
+}
+
+func (e *LeaseElector) Status() Status {
+	e.mu.RLock()
+	leader := e.leader
+	e.mu.RUnlock()
+	return Status{Identity: e.cfg.Identity, Leader: leader, IsLeader: e.IsLeader()}
+}
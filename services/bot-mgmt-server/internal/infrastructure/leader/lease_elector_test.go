@@ -0,0 +1,139 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memLeases is an in-memory domain.LeaseRepository
+type memLeases struct {
+	mu        sync.Mutex
+	holder    string
+	expiresAt time.Time
+	err       error
+}
+
+func (m *memLeases) TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.holder == "" || m.holder == holder || !now.Before(m.expiresAt) {
+		m.holder, m.expiresAt = holder, expiresAt
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *memLeases) Release(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == holder {
+		m.expiresAt = time.Time{}
+	}
+	return nil
+}
+
+func (m *memLeases) GetHolder(ctx context.Context, name string, now time.Time) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.Before(m.expiresAt) {
+		return m.holder, nil
+	}
+	return "", nil
+}
+
+func newTestElector(repo *memLeases, identity string, leaseDuration time.Duration, changes *[]bool) *LeaseElector {
+	cfg := Config{Name: "workers", Identity: identity, LeaseDuration: leaseDuration, RenewInterval: leaseDuration / 3}
+	return NewLeaseElector(repo, cfg, zap.NewNop(), func(isLeader bool) { *changes = append(*changes, isLeader) })
+}
+
+func TestLeaseElector_SingleLeader(t *testing.T) {
+	repo := &memLeases{}
+	var aChanges, bChanges []bool
+	a := newTestElector(repo, "a", time.Minute, &aChanges)
+	b := newTestElector(repo, "b", time.Minute, &bChanges)
+
+	a.campaign(t.Context())
+	b.campaign(t.Context())
+
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+	assert.Equal(t, Status{Identity: "b", Leader: "a"}, b.Status())
+	assert.Equal(t, []bool{true}, aChanges)
+	assert.Empty(t, bChanges)
+
+	// Renewing keeps the leadership without reporting a change
+	a.campaign(t.Context())
+	assert.True(t, a.IsLeader())
+	assert.Equal(t, []bool{true}, aChanges)
+}
+
+func TestLeaseElector_HandsOverOnShutdown(t *testing.T) {
+	repo := &memLeases{}
+	var aChanges, bChanges []bool
+	a := newTestElector(repo, "a", time.Minute, &aChanges)
+	b := newTestElector(repo, "b", time.Minute, &bChanges)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.Run(ctx)
+	}()
+	require.Eventually(t, a.IsLeader, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+	assert.False(t, a.IsLeader())
+	assert.Equal(t, []bool{true, false}, aChanges)
+
+	// The lease is free at once, long before it would have expired
+	b.campaign(t.Context())
+	assert.True(t, b.IsLeader())
+}
+
+func TestLeaseElector_FailsOverWhenLeaderStops(t *testing.T) {
+	repo := &memLeases{}
+	var aChanges, bChanges []bool
+	a := newTestElector(repo, "a", 60*time.Millisecond, &aChanges)
+	b := newTestElector(repo, "b", 60*time.Millisecond, &bChanges)
+
+	a.campaign(t.Context())
+	b.campaign(t.Context())
+	require.True(t, a.IsLeader())
+	require.False(t, b.IsLeader())
+
+	// a crashed: its lease runs out and b takes over
+	time.Sleep(70 * time.Millisecond)
+	assert.False(t, a.IsLeader(), "a must stop acting as leader once its lease has expired")
+	b.campaign(t.Context())
+	assert.True(t, b.IsLeader())
+}
+
+func TestLeaseElector_StepsDownWhenRenewalFails(t *testing.T) {
+	repo := &memLeases{}
+	var changes []bool
+	a := newTestElector(repo, "a", 60*time.Millisecond, &changes)
+
+	a.campaign(t.Context())
+	require.True(t, a.IsLeader())
+
+	// A failed renewal is tolerated while the lease is still valid
+	repo.err = errors.New("connection refused")
+	a.campaign(t.Context())
+	assert.True(t, a.IsLeader())
+
+	time.Sleep(70 * time.Millisecond)
+	a.campaign(t.Context())
+	assert.False(t, a.IsLeader())
+	assert.Equal(t, []bool{true, false}, changes)
+}
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+)
+
+const namespace = "bot_mgmt"
+
+// Prometheus records the task lifecycle and executor API calls in a Prometheus registry.
+// It implements usecase.Metrics.
+type Prometheus struct {
+	cacheLookups  *prometheus.CounterVec
+	created       prometheus.Counter
+	deduped       *prometheus.CounterVec
+	finished      *prometheus.CounterVec
+	retries       prometheus.Counter
+	pendingTime   prometheus.Histogram
+	runningTime   *prometheus.HistogramVec
+	queueDepth    *prometheus.GaugeVec
+	awsCalls      *prometheus.HistogramVec
+	awsCallErrors *prometheus.CounterVec
+	leader        prometheus.Gauge
+}
+
+var _ usecase.Metrics = (*Prometheus)(nil)
+
+// NewPrometheus registers the metrics with reg
+func NewPrometheus(reg prometheus.Registerer) *Prometheus {
+	p := &Prometheus{
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "result_cache_lookups_total",
+			Help:      "Result cache lookups by outcome (hit or miss).",
+		}, []string{"outcome"}),
+		created: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_created_total",
+			Help:      "Tasks stored, including those sharing another task or reusing a cached result.",
+		}),
+		deduped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_deduped_total",
+			Help:      "Submissions answered without launching a new bot, by reason.",
+		}, []string{"reason"}),
+		finished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_finished_total",
+			Help:      "Tasks leaving flight by status (FAILED and TIMED_OUT may still be retried) and reason.",
+		}, []string{"status", "reason"}),
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "task_retries_total",
+			Help:      "Failed tasks sent back to PENDING.",
+		}),
+		pendingTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "task_pending_seconds",
+			Help:      "Time from task creation to the first launch of its bot (PENDING to RUNNING).",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12), // 0.5s to ~17m
+		}),
+		runningTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "task_running_seconds",
+			Help:      "Time a bot ran before its task left RUNNING, by the status it moved to.",
+			Buckets:   prometheus.ExponentialBuckets(5, 2, 10), // 5s to ~43m
+		}, []string{"status"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "task_queue_depth",
+			Help:      "Tasks running their own bot in each active status.",
+		}, []string{"status"}),
+		awsCalls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "aws_api_call_duration_seconds",
+			Help:      "Latency of AWS API calls (e.g. ECS RunTask, DescribeTasks), including SDK retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "operation"}),
+		awsCallErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "aws_api_call_errors_total",
+			Help:      "Failed AWS API calls by AWS error code.",
+		}, []string{"service", "operation", "code"}),
+		leader: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "leader",
+			Help:      "1 while this replica is the leader running the retry, polling and reaper workers.",
+		}),
+	}
+	reg.MustRegister(p.cacheLookups, p.created, p.deduped, p.finished, p.retries,
+		p.pendingTime, p.runningTime, p.queueDepth, p.awsCalls, p.awsCallErrors, p.leader)
+	return p
+}
+
+func (p *Prometheus) ResultCacheHit() {
+	p.cacheLookups.WithLabelValues("hit").Inc()
+}
+
+func (p *Prometheus) ResultCacheMiss() {
+	p.cacheLookups.WithLabelValues("miss").Inc()
+}
+
+func (p *Prometheus) TaskCreated() {
+	p.created.Inc()
+}
+
+func (p *Prometheus) TaskDeduped(reason usecase.DedupeReason) {
+	p.deduped.WithLabelValues(string(reason)).Inc()
+}
+
+func (p *Prometheus) TaskFinished(status domain.TaskStatus, reason usecase.FinishReason) {
+	p.finished.WithLabelValues(string(status), string(reason)).Inc()
+}
+
+func (p *Prometheus) TaskRetried() {
+	p.retries.Inc()
+}
+
+func (p *Prometheus) TaskStarted(wait time.Duration) {
+	p.pendingTime.Observe(wait.Seconds())
+}
+
+func (p *Prometheus) TaskRan(status domain.TaskStatus, runtime time.Duration) {
+	p.runningTime.WithLabelValues(string(status)).Observe(runtime.Seconds())
+}
+
+func (p *Prometheus) QueueDepth(status domain.TaskStatus, n int) {
+	p.queueDepth.WithLabelValues(string(status)).Set(float64(n))
+}
+
+// SetLeader records whether this replica is the leader; it is a leader.Observer
+func (p *Prometheus) SetLeader(isLeader bool) {
+	if isLeader {
+		p.leader.Set(1)
+	} else {
+		p.leader.Set(0)
+	}
+}
+
+// InstrumentAWS is an AWS SDK API option that records the latency and error code of
+// every call made by the clients it is applied to.
+func (p *Prometheus) InstrumentAWS(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("PrometheusMetrics",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := time.Now()
+			out, md, err := next.HandleInitialize(ctx, in)
+
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			p.awsCalls.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+			if err != nil {
+				p.awsCallErrors.WithLabelValues(service, operation, awsErrorCode(err)).Inc()
+			}
+			return out, md, err
+		}), middleware.After)
+}
+
+// awsErrorCode returns the error code sent by AWS, or a placeholder for errors that
+// never got a response (e.g. network failures or a cancelled context)
+func awsErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "Canceled"
+	}
+	return "Unknown"
+}
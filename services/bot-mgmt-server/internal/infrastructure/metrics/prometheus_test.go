@@ -0,0 +1,71 @@
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/metrics"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+)
+
+func TestPrometheus_TaskLifecycle(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	p := metrics.NewPrometheus(reg)
+
+	p.TaskCreated()
+	p.TaskDeduped(usecase.DedupedCachedResult)
+	p.ResultCacheHit()
+	p.TaskFinished(domain.TaskStatusFailed, usecase.FinishedLaunchError)
+	p.TaskRan(domain.TaskStatusCompleted, 30*time.Second)
+	p.QueueDepth(domain.TaskStatusPending, 7)
+
+	expected := `
+# HELP bot_mgmt_tasks_finished_total Tasks leaving flight by status (FAILED and TIMED_OUT may still be retried) and reason.
+# TYPE bot_mgmt_tasks_finished_total counter
+bot_mgmt_tasks_finished_total{reason="launch_error",status="FAILED"} 1
+# HELP bot_mgmt_task_queue_depth Tasks running their own bot in each active status.
+# TYPE bot_mgmt_task_queue_depth gauge
+bot_mgmt_task_queue_depth{status="PENDING"} 7
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"bot_mgmt_tasks_finished_total", "bot_mgmt_task_queue_depth"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "bot_mgmt_task_running_seconds"))
+}
+
+func TestPrometheus_InstrumentAWS(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	p := metrics.NewPrometheus(reg)
+
+	call := func(err error) {
+		stack := middleware.NewStack("RunTask", func() interface{} { return struct{}{} })
+		require.NoError(t, stack.Initialize.Add(&awsmiddleware.RegisterServiceMetadata{ServiceID: "ECS", OperationName: "RunTask"}, middleware.Before))
+		require.NoError(t, p.InstrumentAWS(stack))
+
+		handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			return nil, middleware.Metadata{}, err
+		}), stack)
+		_, _, _ = handler.Handle(context.Background(), struct{}{})
+	}
+
+	call(nil)
+	call(&smithy.GenericAPIError{Code: "ThrottlingException"})
+
+	expected := `
+# HELP bot_mgmt_aws_api_call_errors_total Failed AWS API calls by AWS error code.
+# TYPE bot_mgmt_aws_api_call_errors_total counter
+bot_mgmt_aws_api_call_errors_total{code="ThrottlingException",operation="RunTask",service="ECS"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "bot_mgmt_aws_api_call_errors_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "bot_mgmt_aws_api_call_duration_seconds"))
+}
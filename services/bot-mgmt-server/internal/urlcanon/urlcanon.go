@@ -0,0 +1,200 @@
+// Package urlcanon normalizes URLs so that trivially different spellings of the same
+// address are analysed once.
+package urlcanon
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+)
+
+// ErrInvalidURL is returned for URLs that are not absolute http(s) URLs
+var ErrInvalidURL = errors.New("invalid URL")
+
+// Options selects the normalization steps
+type Options struct {
+	LowercaseHost        bool
+	IDNA                 bool // Convert internationalized host names to punycode
+	StripDefaultPort     bool // Drop :80 for http and :443 for https
+	StripTrailingSlash   bool
+	RemoveTrackingParams bool
+	SortQuery            bool // Order query parameters by name
+	DropFragment         bool
+
+	// TrackingParams are the query parameters removed by RemoveTrackingParams.
+	// Entries ending in "*" match by prefix.
+	TrackingParams []string
+}
+
+// DefaultTrackingParams are common analytics and click-ID parameters
+var DefaultTrackingParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid",
+	"mc_cid", "mc_eid", "igshid", "_ga", "_gl",
+}
+
+// DefaultOptions enables every normalization step
+var DefaultOptions = Options{
+	LowercaseHost:        true,
+	IDNA:                 true,
+	StripDefaultPort:     true,
+	StripTrailingSlash:   true,
+	RemoveTrackingParams: true,
+	SortQuery:            true,
+	DropFragment:         true,
+	TrackingParams:       DefaultTrackingParams,
+}
+
+// Result is a canonicalized URL
+type Result struct {
+	URL              string
+	Host             string // Host name without port
+	RegisteredDomain string // eTLD+1 of Host (e.g. "example.co.uk"), or Host itself for IPs and single-label hosts
+}
+
+// Canonicalizer normalizes URLs with a fixed set of Options
+type Canonicalizer struct {
+	opts     Options
+	exact    map[string]bool
+	prefixes []string
+}
+
+// New creates a Canonicalizer
+func New(opts Options) *Canonicalizer {
+	c := &Canonicalizer{opts: opts, exact: make(map[string]bool)}
+	for _, p := range opts.TrackingParams {
+		p = strings.ToLower(p)
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			c.prefixes = append(c.prefixes, prefix)
+		} else {
+			c.exact[p] = true
+		}
+	}
+	return c
+}
+
+// Canonicalize normalizes raw. The scheme is always lowercased; userinfo and path
+// escaping are preserved.
+func (c *Canonicalizer) Canonicalize(raw string) (Result, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return Result{}, fmt.Errorf("%w: %q is not an absolute http(s) URL", ErrInvalidURL, raw)
+	}
+
+	host, port := u.Hostname(), u.Port()
+	host = strings.TrimSuffix(host, ".")
+	if c.opts.LowercaseHost {
+		host = strings.ToLower(host)
+	}
+	if c.opts.IDNA && net.ParseIP(host) == nil {
+		host = toASCII(host)
+	}
+	if c.opts.StripDefaultPort && (u.Scheme == "http" && port == "80" || u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]" // IPv6 literal
+	}
+	if port != "" {
+		u.Host += ":" + port
+	}
+
+	if c.opts.StripTrailingSlash {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+	}
+
+	if u.RawQuery != "" && (c.opts.RemoveTrackingParams || c.opts.SortQuery) {
+		u.RawQuery = c.normalizeQuery(u.RawQuery)
+	}
+	u.ForceQuery = false
+
+	if c.opts.DropFragment {
+		u.Fragment, u.RawFragment = "", ""
+	}
+
+	return Result{
+		URL:              u.String(),
+		Host:             host,
+		RegisteredDomain: registeredDomain(host),
+	}, nil
+}
+
+// normalizeQuery removes tracking parameters and, with SortQuery, orders the rest.
+// Without SortQuery the original order and encoding of the kept parameters is preserved.
+func (c *Canonicalizer) normalizeQuery(rawQuery string) string {
+	if !c.opts.SortQuery {
+		var kept []string
+		for _, pair := range strings.Split(rawQuery, "&") {
+			name, _, _ := strings.Cut(pair, "=")
+			if name, err := url.QueryUnescape(name); err == nil && c.isTracking(name) {
+				continue
+			}
+			kept = append(kept, pair)
+		}
+		return strings.Join(kept, "&")
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Leave malformed queries alone rather than silently dropping parameters
+		return rawQuery
+	}
+	if c.opts.RemoveTrackingParams {
+		for name := range values {
+			if c.isTracking(name) {
+				values.Del(name)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func (c *Canonicalizer) isTracking(name string) bool {
+	if !c.opts.RemoveTrackingParams {
+		return false
+	}
+	name = strings.ToLower(name)
+	if c.exact[name] {
+		return true
+	}
+	for _, p := range c.prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// toASCII converts host to its punycode form. Phishing hosts are often not valid under
+// the strict lookup profile, so those fall back to plain punycode encoding.
+func toASCII(host string) string {
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		return ascii
+	}
+	if ascii, err := idna.Punycode.ToASCII(host); err == nil {
+		return ascii
+	}
+	return host
+}
+
+func registeredDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(host))
+	if err != nil {
+		// Single-label hosts and bare public suffixes have no registered domain
+		return strings.ToLower(host)
+	}
+	return domain
+}
@@ -0,0 +1,55 @@
+package urlcanon_test
+
+import (
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	c := urlcanon.New(urlcanon.DefaultOptions)
+
+	cases := []struct {
+		raw    string
+		url    string
+		domain string
+	}{
+		{"http://Example.com/", "http://example.com", "example.com"},
+		{"http://example.com", "http://example.com", "example.com"},
+		{"http://example.com/?utm_source=sms", "http://example.com", "example.com"},
+		{"HTTP://example.com:80/a/?b=2&a=1&fbclid=x#top", "http://example.com/a?a=1&b=2", "example.com"},
+		{"https://login.example.co.uk:443/", "https://login.example.co.uk", "example.co.uk"},
+		{"https://example.com:8443/", "https://example.com:8443", "example.com"},
+		{"http://Bücher.example.", "http://xn--bcher-kva.example", "xn--bcher-kva.example"},
+		{"http://192.168.0.1:80/x", "http://192.168.0.1/x", "192.168.0.1"},
+		{"http://[::1]:8080/", "http://[::1]:8080", "::1"},
+		{"http://user@evil.com/", "http://user@evil.com", "evil.com"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.raw, func(t *testing.T) {
+			res, err := c.Canonicalize(tc.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.url, res.URL)
+			assert.Equal(t, tc.domain, res.RegisteredDomain)
+		})
+	}
+}
+
+func TestCanonicalize_Options(t *testing.T) {
+	c := urlcanon.New(urlcanon.Options{RemoveTrackingParams: true, TrackingParams: []string{"ref"}})
+
+	res, err := c.Canonicalize("http://Example.com/a/?z=1&ref=sms&utm_source=x#f")
+	require.NoError(t, err)
+	assert.Equal(t, "http://Example.com/a/?z=1&utm_source=x#f", res.URL, "only the selected steps are applied")
+}
+
+func TestCanonicalize_Invalid(t *testing.T) {
+	c := urlcanon.New(urlcanon.DefaultOptions)
+
+	for _, raw := range []string{"", "example.com", "ftp://example.com", "http://", "http://%zz"} {
+		_, err := c.Canonicalize(raw)
+		assert.ErrorIs(t, err, urlcanon.ErrInvalidURL, raw)
+	}
+}
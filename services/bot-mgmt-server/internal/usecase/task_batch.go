@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// BatchItem is one URL of a batch submission
+type BatchItem struct {
+	URL         string
+	AnalysisID  string
+	RequestUUID string
+}
+
+// BatchItemResult is the outcome of one BatchItem: either Task or Err is set
+type BatchItemResult struct {
+	Task      *domain.AnalysisTask
+	Duplicate bool // The URL canonicalizes to the same URL as an earlier item; Task is that item's task
+	Err       error
+}
+
+// BatchResult is the outcome of a batch submission, with Items in submission order
+type BatchResult struct {
+	Batch *domain.TaskBatch
+	Items []BatchItemResult
+}
+
+func (u *taskUsecase) CreateBatch(ctx context.Context, ownerUID string, items []BatchItem, opts CreateOptions) (*BatchResult, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: no items", domain.ErrInvalidBatch)
+	}
+	if len(items) > u.batch.MaxItems {
+		return nil, fmt.Errorf("%w: %d items exceed the limit of %d", domain.ErrInvalidBatch, len(items), u.batch.MaxItems)
+	}
+	if err := u.checkCallbackURL(opts.CallbackURL); err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, len(items))}
+	byCanonicalURL := make(map[string]*domain.AnalysisTask)
+	var taskIDs []uuid.UUID
+
+	for i, item := range items {
+		canonical, err := u.canon.Canonicalize(item.URL)
+		if err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+
+		// Several links in one message often differ only in tracking parameters
+		if task, ok := byCanonicalURL[canonical.URL]; ok {
+			result.Items[i] = BatchItemResult{Task: task, Duplicate: true}
+			continue
+		}
+
+		task, err := u.createTask(ctx, item.URL, canonical, item.RequestUUID, item.AnalysisID, ownerUID, opts)
+		if err != nil {
+			u.log(ctx).Error("Failed to create batch task", zap.String("url", item.URL), zap.Error(err))
+			result.Items[i].Err = err
+			continue
+		}
+		byCanonicalURL[canonical.URL] = task
+		taskIDs = append(taskIDs, task.ID)
+		result.Items[i].Task = task
+	}
+
+	if len(taskIDs) == 0 {
+		return result, fmt.Errorf("%w: no item could be submitted", domain.ErrInvalidBatch)
+	}
+
+	result.Batch = &domain.TaskBatch{ID: uuid.New(), OwnerUID: ownerUID, CreatedAt: time.Now()}
+	if err := u.repo.CreateBatch(ctx, result.Batch, taskIDs); err != nil {
+		// The tasks themselves were created and will run; only the grouping is lost
+		return result, fmt.Errorf("failed to store batch: %w", err)
+	}
+	return result, nil
+}
+
+func (u *taskUsecase) GetBatchProgress(ctx context.Context, id uuid.UUID, ownerUID string) (*domain.BatchProgress, error) {
+	progress, err := u.repo.GetBatchProgress(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Batches of other owners are reported as not found, like their tasks
+	if progress.Batch.OwnerUID != ownerUID {
+		return nil, domain.ErrBatchNotFound
+	}
+	return progress, nil
+}
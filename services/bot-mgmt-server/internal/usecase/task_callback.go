@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// checkCallbackURL validates the callback URL of a create request; an empty URL is valid
+func (u *taskUsecase) checkCallbackURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if u.callbackRepo == nil {
+		return fmt.Errorf("%w: callbacks are not enabled", domain.ErrInvalidCallbackURL)
+	}
+	return u.callbacks.AllowedHosts.Check(raw)
+}
+
+func (u *taskUsecase) DeliverCallbacks(ctx context.Context) error {
+	if u.callbackRepo == nil {
+		return nil
+	}
+
+	deliveries, err := u.callbackRepo.ClaimDueDeliveries(ctx, time.Now(), u.callbacks.BatchSize, u.callbacks.Lease)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		wg.Add(1)
+		go func(d *domain.CallbackDelivery) {
+			defer wg.Done()
+			u.deliverCallback(ctx, d)
+		}(d)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliverCallback makes one attempt at a claimed delivery and records the outcome
+func (u *taskUsecase) deliverCallback(ctx context.Context, d *domain.CallbackDelivery) {
+	status, err := u.sendCallback(ctx, d)
+	if err != nil && ctx.Err() != nil {
+		// Shutting down: the delivery is attempted again once its lease expires
+		return
+	}
+
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = status
+	d.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		d.Status = domain.CallbackDelivered
+		d.NextAttemptAt = nil
+		d.LastError = ""
+	case d.Attempts >= u.callbacks.MaxAttempts || u.callbacks.Retry.ClassifyError(err) == domain.FailurePermanent:
+		d.Status = domain.CallbackFailed
+		d.NextAttemptAt = nil
+		d.LastError = err.Error()
+		u.log(ctx).Error("Giving up on callback delivery",
+			zap.String("delivery_id", d.ID.String()),
+			zap.String("task_id", d.TaskID.String()),
+			zap.Int("attempts", d.Attempts),
+			zap.Error(err))
+	default:
+		next := now.Add(u.callbacks.Retry.Backoff(d.Attempts - 1))
+		d.NextAttemptAt = &next
+		d.LastError = err.Error()
+		u.log(ctx).Warn("Callback delivery failed",
+			zap.String("delivery_id", d.ID.String()),
+			zap.String("task_id", d.TaskID.String()),
+			zap.Int("attempts", d.Attempts),
+			zap.Time("next_attempt_at", next),
+			zap.Error(err))
+	}
+
+	// The outcome must be persisted even if ctx was cancelled after the callback was sent
+	if err := u.callbackRepo.Update(context.WithoutCancel(ctx), d); err != nil {
+		u.log(ctx).Error("Failed to record callback delivery", zap.String("delivery_id", d.ID.String()), zap.Error(err))
+	}
+}
+
+// sendCallback posts the current outcome of d's task. Errors that retrying cannot fix
+// are wrapped with domain.Permanent.
+func (u *taskUsecase) sendCallback(ctx context.Context, d *domain.CallbackDelivery) (int, error) {
+	// The allowlist may have been narrowed since the delivery was queued
+	if err := u.callbacks.AllowedHosts.Check(d.URL); err != nil {
+		return 0, domain.Permanent(err)
+	}
+
+	task, err := u.repo.GetByID(ctx, d.TaskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return 0, domain.Permanent(err)
+		}
+		return 0, err
+	}
+
+	body, err := json.Marshal(domain.NewCallbackPayload(d, task))
+	if err != nil {
+		return 0, domain.Permanent(err)
+	}
+	return u.callbackSender.Send(ctx, d.URL, d.ID, body)
+}
+
+func (u *taskUsecase) ListCallbackDeliveries(ctx context.Context, taskID uuid.UUID, ownerUID string) ([]*domain.CallbackDelivery, error) {
+	if _, err := u.getOwnedTask(ctx, taskID, ownerUID); err != nil {
+		return nil, err
+	}
+	if u.callbackRepo == nil {
+		return nil, nil
+	}
+	return u.callbackRepo.ListByTask(ctx, taskID)
+}
+
+func (u *taskUsecase) RedeliverCallback(ctx context.Context, taskID uuid.UUID, ownerUID string) (*domain.CallbackDelivery, error) {
+	task, err := u.getOwnedTask(ctx, taskID, ownerUID)
+	if err != nil {
+		return nil, err
+	}
+	if u.callbackRepo == nil || task.CallbackURL == "" {
+		return nil, fmt.Errorf("%w: task has no callback URL", domain.ErrCallbackNotDeliverable)
+	}
+	if !task.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: task has not finished", domain.ErrCallbackNotDeliverable)
+	}
+	if err := u.callbacks.AllowedHosts.Check(task.CallbackURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrCallbackNotDeliverable, err)
+	}
+
+	d := domain.NewCallbackDelivery(task)
+	if err := u.callbackRepo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	u.log(ctx).Info("Queued callback redelivery", zap.String("task_id", task.ID.String()), zap.String("delivery_id", d.ID.String()))
+	return d, nil
+}
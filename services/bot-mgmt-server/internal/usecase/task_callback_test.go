@@ -0,0 +1,143 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateTask_CallbackURL(t *testing.T) {
+	ctx := context.Background()
+	opts := usecase.CreateOptions{CallbackURL: "https://search.example.com/hooks/analysis"}
+
+	disabled := usecase.NewTaskUsecase(new(mocks.MockTaskRepository), new(mocks.MockBotExecutor), zap.NewNop())
+	_, err := disabled.CreateTask(ctx, "http://example.com", "", "a1", "bob", opts)
+	assert.ErrorIs(t, err, domain.ErrInvalidCallbackURL, "callbacks not enabled")
+
+	mockRepo := new(mocks.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithCallbacks(new(mocks.MockCallbackRepository), new(mocks.MockCallbackSender), usecase.CallbackConfig{
+			AllowedHosts: domain.CallbackAllowlist{"*.example.com"},
+		}),
+	)
+
+	_, err = u.CreateTask(ctx, "http://example.com", "", "a1", "bob", usecase.CreateOptions{CallbackURL: "https://evil.test/"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCallbackURL)
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", "http://example.com").Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", ctx, "http://example.com").Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	task, err := u.CreateTask(ctx, "http://example.com", "", "a1", "bob", opts)
+	require.NoError(t, err)
+	assert.Equal(t, opts.CallbackURL, task.CallbackURL)
+}
+
+func TestDeliverCallbacks(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	callbackRepo := new(mocks.MockCallbackRepository)
+	sender := new(mocks.MockCallbackSender)
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithCallbacks(callbackRepo, sender, usecase.CallbackConfig{
+			AllowedHosts: domain.CallbackAllowlist{"hooks.example.com"},
+			MaxAttempts:  3,
+			Retry:        domain.RetryPolicy{BaseDelay: time.Minute, Multiplier: 2},
+		}),
+	)
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{
+		ID:          uuid.New(),
+		AnalysisID:  "a1",
+		Status:      domain.TaskStatusCompleted,
+		CallbackURL: "https://hooks.example.com/ok",
+		AnalysisResult: &domain.AnalysisResult{
+			SchemaVersion: domain.AnalysisResultSchemaVersion,
+			Verdict:       domain.VerdictPhishing,
+		},
+	}
+	delivered := &domain.CallbackDelivery{ID: uuid.New(), TaskID: task.ID, URL: "https://hooks.example.com/ok", Status: domain.CallbackPending}
+	retried := &domain.CallbackDelivery{ID: uuid.New(), TaskID: task.ID, URL: "https://hooks.example.com/down", Status: domain.CallbackPending}
+	exhausted := &domain.CallbackDelivery{ID: uuid.New(), TaskID: task.ID, URL: "https://hooks.example.com/down", Status: domain.CallbackPending, Attempts: 2}
+	disallowed := &domain.CallbackDelivery{ID: uuid.New(), TaskID: task.ID, URL: "https://removed.example.com/", Status: domain.CallbackPending}
+
+	callbackRepo.On("ClaimDueDeliveries", ctx, mock.Anything, usecase.DefaultCallbackConfig.BatchSize, usecase.DefaultCallbackConfig.Lease).
+		Return([]*domain.CallbackDelivery{delivered, retried, exhausted, disallowed}, nil)
+	callbackRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	sender.On("Send", ctx, "https://hooks.example.com/ok", delivered.ID, mock.MatchedBy(func(body []byte) bool {
+		var payload domain.CallbackPayload
+		return json.Unmarshal(body, &payload) == nil &&
+			payload.TaskID == task.ID && payload.AnalysisID == "a1" &&
+			payload.Status == domain.TaskStatusCompleted && payload.AnalysisResult.Verdict == domain.VerdictPhishing
+	})).Return(http.StatusOK, nil)
+	sender.On("Send", ctx, "https://hooks.example.com/down", mock.Anything, mock.Anything).
+		Return(http.StatusBadGateway, errors.New("callback endpoint returned 502 Bad Gateway"))
+
+	before := time.Now()
+	assert.NoError(t, u.DeliverCallbacks(ctx))
+
+	assert.Equal(t, domain.CallbackDelivered, delivered.Status)
+	assert.Equal(t, 1, delivered.Attempts)
+	assert.Nil(t, delivered.NextAttemptAt)
+
+	assert.Equal(t, domain.CallbackPending, retried.Status)
+	assert.Equal(t, 1, retried.Attempts)
+	assert.Equal(t, http.StatusBadGateway, retried.ResponseStatus)
+	require.NotNil(t, retried.NextAttemptAt)
+	assert.WithinDuration(t, before.Add(time.Minute), *retried.NextAttemptAt, time.Second)
+
+	assert.Equal(t, domain.CallbackFailed, exhausted.Status, "last attempt")
+	assert.Equal(t, 3, exhausted.Attempts)
+
+	assert.Equal(t, domain.CallbackFailed, disallowed.Status, "host removed from the allowlist")
+	assert.Contains(t, disallowed.LastError, "not allowed")
+
+	callbackRepo.AssertNumberOfCalls(t, "Update", 4)
+	sender.AssertNotCalled(t, "Send", mock.Anything, "https://removed.example.com/", mock.Anything, mock.Anything)
+}
+
+func TestRedeliverCallback(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	callbackRepo := new(mocks.MockCallbackRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithCallbacks(callbackRepo, new(mocks.MockCallbackSender), usecase.CallbackConfig{
+			AllowedHosts: domain.CallbackAllowlist{"hooks.example.com"},
+		}),
+	)
+
+	ctx := context.Background()
+	running := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning, CallbackURL: "https://hooks.example.com/"}
+	noCallback := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusCompleted}
+	finished := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusDeadLetter, CallbackURL: "https://hooks.example.com/"}
+	for _, task := range []*domain.AnalysisTask{running, noCallback, finished} {
+		mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	}
+	callbackRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	_, err := u.RedeliverCallback(ctx, running.ID, "alice")
+	assert.ErrorIs(t, err, domain.ErrCallbackNotDeliverable)
+	_, err = u.RedeliverCallback(ctx, noCallback.ID, "alice")
+	assert.ErrorIs(t, err, domain.ErrCallbackNotDeliverable)
+	_, err = u.RedeliverCallback(ctx, finished.ID, "mallory")
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	d, err := u.RedeliverCallback(ctx, finished.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, finished.ID, d.TaskID)
+	assert.Equal(t, domain.CallbackPending, d.Status)
+	callbackRepo.AssertNumberOfCalls(t, "Create", 1)
+}
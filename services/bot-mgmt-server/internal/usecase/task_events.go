@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TaskNotification announces that a task's stored state changed. It only identifies the
+// change; subscribers re-read the task, so notifications can be dropped or coalesced.
+type TaskNotification struct {
+	TaskID uuid.UUID
+	Event  *domain.TaskEvent
+}
+
+// EventBroker fans task notifications out to subscribers. The in-memory broker only
+// reaches subscribers of the same process; a multi-instance deployment plugs in a
+// broker backed by a shared channel (e.g. Postgres LISTEN/NOTIFY or Redis pub/sub).
+type EventBroker interface {
+	Publish(ctx context.Context, n TaskNotification)
+	// Subscribe returns a channel of notifications for taskID and a function that
+	// cancels the subscription and closes the channel.
+	Subscribe(taskID uuid.UUID) (<-chan TaskNotification, func())
+}
+
+// WithEventBroker sets the broker task notifications are published to
+func WithEventBroker(b EventBroker) Option {
+	return func(u *taskUsecase) {
+		u.broker = b
+	}
+}
+
+// DefaultWatchRecheckInterval is how often a watched task is re-read without a notification.
+// A broker may not carry transitions made by other instances, e.g. the in-memory one
+// behind a load balancer; the re-read picks those up.
+const DefaultWatchRecheckInterval = 5 * time.Second
+
+// WithWatchRecheckInterval sets how often WatchTask re-reads a task without a notification
+func WithWatchRecheckInterval(d time.Duration) Option {
+	return func(u *taskUsecase) {
+		if d > 0 {
+			u.watchRecheck = d
+		}
+	}
+}
+
+// subscriberBuffer is the number of notifications a slow subscriber may fall behind by
+// before further ones are dropped. Dropping is safe: the next one triggers a re-read.
+const subscriberBuffer = 16
+
+// MemoryBroker is an EventBroker for a single instance
+type MemoryBroker struct {
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[chan TaskNotification]struct{}
+}
+
+// NewMemoryBroker creates an empty MemoryBroker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subs: make(map[uuid.UUID]map[chan TaskNotification]struct{})}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, n TaskNotification) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[n.TaskID] {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
+
+func (b *MemoryBroker) Subscribe(taskID uuid.UUID) (<-chan TaskNotification, func()) {
+	ch := make(chan TaskNotification, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subs[taskID] == nil {
+		b.subs[taskID] = make(map[chan TaskNotification]struct{})
+	}
+	b.subs[taskID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs[taskID], ch)
+			if len(b.subs[taskID]) == 0 {
+				delete(b.subs, taskID)
+			}
+			close(ch)
+		})
+	}
+}
+
+// TaskChange is one message of WatchTask: the task as stored after Event.
+// Event is nil for the initial snapshot and for changes found by a periodic re-read. For a task sharing another task's analysis,
+// Event is the shared task's transition that was mirrored onto it.
+type TaskChange struct {
+	Task  *domain.AnalysisTask
+	Event *domain.TaskEvent
+}
+
+// saveTransition persists a transition and notifies watchers of the task
+func (u *taskUsecase) saveTransition(ctx context.Context, task *domain.AnalysisTask, event *domain.TaskEvent) error {
+	if err := u.repo.SaveTransition(ctx, task, event); err != nil {
+		return err
+	}
+	u.observeTransition(task, event)
+	u.broker.Publish(ctx, TaskNotification{TaskID: task.ID, Event: event})
+	return nil
+}
+
+func (u *taskUsecase) WatchTask(ctx context.Context, id uuid.UUID, ownerUID string) (<-chan TaskChange, error) {
+	// Subscribe before the first read so no change between the two is missed
+	w := &taskWatch{broker: u.broker}
+	w.own, w.unsubscribeOwn = u.broker.Subscribe(id)
+
+	task, err := u.getOwnedTask(ctx, id, ownerUID)
+	if err == nil && w.follow(task.SharedTaskID) {
+		// Read again so that a change of the shared task before it was subscribed is not missed
+		task, err = u.getOwnedTask(ctx, id, ownerUID)
+	}
+	if err != nil {
+		w.close()
+		return nil, err
+	}
+
+	changes := make(chan TaskChange, 1)
+	changes <- TaskChange{Task: task}
+	if task.Status.IsTerminal() {
+		w.close()
+		close(changes)
+		return changes, nil
+	}
+
+	go u.watch(ctx, task, w, false, changes)
+	return changes, nil
+}
+
+// taskWatch holds the subscriptions of one WatchTask call: the task itself and, for a
+// task sharing another task's analysis, the shared task whose transitions change it.
+type taskWatch struct {
+	broker            EventBroker
+	own, shared       <-chan TaskNotification
+	sharedID          *uuid.UUID
+	unsubscribeOwn    func()
+	unsubscribeShared func()
+}
+
+// follow subscribes to sharedID if it differs from the current one and reports whether it did
+func (w *taskWatch) follow(sharedID *uuid.UUID) bool {
+	if equalIDs(w.sharedID, sharedID) {
+		return false
+	}
+	if w.unsubscribeShared != nil {
+		w.unsubscribeShared()
+	}
+	w.shared, w.unsubscribeShared, w.sharedID = nil, nil, sharedID
+	if sharedID != nil {
+		w.shared, w.unsubscribeShared = w.broker.Subscribe(*sharedID)
+	}
+	return true
+}
+
+func (w *taskWatch) close() {
+	w.unsubscribeOwn()
+	if w.unsubscribeShared != nil {
+		w.unsubscribeShared()
+	}
+}
+
+// watch forwards changes of task until it is terminal or ctx ends. The task is re-read on
+// every notification and every watchRecheck; if recheck is set it is also re-read once
+// right away.
+func (u *taskUsecase) watch(ctx context.Context, task *domain.AnalysisTask, w *taskWatch, recheck bool, changes chan<- TaskChange) {
+	defer close(changes)
+	defer w.close()
+
+	ticker := time.NewTicker(u.watchRecheck)
+	defer ticker.Stop()
+
+	for {
+		var n TaskNotification
+		if !recheck {
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case n, ok = <-w.own:
+			case n, ok = <-w.shared:
+			case <-ticker.C:
+				ok = true
+			}
+			if !ok {
+				return
+			}
+		}
+
+		current, err := u.repo.GetByID(ctx, task.ID)
+		if err != nil {
+			if ctx.Err() == nil {
+				u.log(ctx).Error("Failed to reload watched task", zap.String("task_id", task.ID.String()), zap.Error(err))
+			}
+			return
+		}
+		// The shared task changes on hand-over. Re-read once after following the new one
+		// in case it changed before it was subscribed.
+		recheck = w.follow(current.SharedTaskID)
+
+		if n.Event == nil && current.Status == task.Status && current.UpdatedAt.Equal(task.UpdatedAt) {
+			continue // Nothing visible changed
+		}
+		task = current
+
+		select {
+		case changes <- TaskChange{Task: task, Event: n.Event}:
+		case <-ctx.Done():
+			return
+		}
+		if task.Status.IsTerminal() {
+			return
+		}
+	}
+}
+
+func equalIDs(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// taskStore backs GetByID and SaveTransition of a mock repository with copies of
+// stored tasks, so the watcher goroutine never shares a task with the test.
+type taskStore struct {
+	mu      sync.Mutex
+	tasks   map[uuid.UUID]domain.AnalysisTask
+	mirrors map[uuid.UUID][]uuid.UUID // Leader ID -> followers whose status SaveTransition mirrors
+}
+
+func newTaskStore(repo *mocks.MockTaskRepository, tasks ...domain.AnalysisTask) *taskStore {
+	s := &taskStore{tasks: make(map[uuid.UUID]domain.AnalysisTask), mirrors: make(map[uuid.UUID][]uuid.UUID)}
+	for _, t := range tasks {
+		s.tasks[t.ID] = t
+	}
+	repo.On("GetByID", mock.Anything, mock.Anything).Return(func(_ context.Context, id uuid.UUID) (*domain.AnalysisTask, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		t, ok := s.tasks[id]
+		if !ok {
+			return nil, domain.ErrTaskNotFound
+		}
+		return &t, nil
+	})
+	repo.On("SaveTransition", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		s.set(*args.Get(1).(*domain.AnalysisTask))
+	}).Return(nil)
+	return s
+}
+
+func (s *taskStore) set(t domain.AnalysisTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[t.ID] = t
+	for _, id := range s.mirrors[t.ID] {
+		follower := s.tasks[id]
+		follower.Status = t.Status
+		follower.UpdatedAt = t.UpdatedAt
+		s.tasks[id] = follower
+	}
+}
+
+func receive(t *testing.T, changes <-chan usecase.TaskChange) usecase.TaskChange {
+	t.Helper()
+	select {
+	case change, ok := <-changes:
+		require.True(t, ok, "stream ended early")
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("no change received")
+		return usecase.TaskChange{}
+	}
+}
+
+func TestWatchTask_StreamsUntilTerminal(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	task := domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning, ExternalID: "ext-1"}
+	newTaskStore(mockRepo, task)
+	mockExecutor := new(mocks.MockBotExecutor)
+	mockExecutor.On("ReleaseBot", mock.Anything, "ext-1").Return(nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+	ctx := context.Background()
+
+	_, err := u.WatchTask(ctx, task.ID, "mallory")
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	changes, err := u.WatchTask(ctx, task.ID, "alice")
+	require.NoError(t, err)
+
+	initial := receive(t, changes)
+	assert.Equal(t, domain.TaskStatusRunning, initial.Task.Status)
+	assert.Nil(t, initial.Event)
+
+	require.NoError(t, u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{Status: domain.TaskStatusCompleted, Result: "ok"}, domain.ActorWebhook))
+
+	final := receive(t, changes)
+	assert.Equal(t, domain.TaskStatusCompleted, final.Task.Status)
+	assert.Equal(t, "ok", final.Task.Result)
+	assert.Equal(t, domain.ActorWebhook, final.Event.Actor)
+
+	_, open := <-changes
+	assert.False(t, open, "the stream ends at a terminal status")
+}
+
+func TestWatchTask_FollowsSharedTask(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	leader := domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning, ExternalID: "ext-1"}
+	follower := domain.AnalysisTask{ID: uuid.New(), OwnerUID: "bob", Status: domain.TaskStatusRunning, SharedTaskID: &leader.ID}
+	store := newTaskStore(mockRepo, leader, follower)
+	store.mirrors[leader.ID] = []uuid.UUID{follower.ID}
+	mockExecutor := new(mocks.MockBotExecutor)
+	mockExecutor.On("ReleaseBot", mock.Anything, "ext-1").Return(nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := u.WatchTask(ctx, follower.ID, "bob")
+	require.NoError(t, err)
+	receive(t, changes)
+
+	require.NoError(t, u.UpdateTaskStatus(ctx, leader.ID, usecase.StatusUpdate{Status: domain.TaskStatusCompleted}, domain.ActorPoller))
+
+	change := receive(t, changes)
+	assert.Equal(t, follower.ID, change.Task.ID)
+	assert.Equal(t, domain.TaskStatusCompleted, change.Task.Status)
+	assert.Equal(t, leader.ID, change.Event.TaskID)
+}
+
+func TestWatchTask_RechecksWithoutNotification(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	task := domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning, ExternalID: "ext-1"}
+	store := newTaskStore(mockRepo, task)
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithWatchRecheckInterval(10*time.Millisecond))
+	ctx := context.Background()
+
+	changes, err := u.WatchTask(ctx, task.ID, "alice")
+	require.NoError(t, err)
+	receive(t, changes)
+
+	// Completed by another replica, whose notification never reaches this broker
+	task.Status = domain.TaskStatusCompleted
+	task.UpdatedAt = time.Now()
+	store.set(task)
+
+	change := receive(t, changes)
+	assert.Equal(t, domain.TaskStatusCompleted, change.Task.Status)
+	assert.Nil(t, change.Event)
+
+	_, open := <-changes
+	assert.False(t, open, "the stream ends at a terminal status")
+}
+
+func TestMemoryBroker(t *testing.T) {
+	b := usecase.NewMemoryBroker()
+	id := uuid.New()
+
+	ch, unsubscribe := b.Subscribe(id)
+	b.Publish(context.Background(), usecase.TaskNotification{TaskID: uuid.New()})
+	b.Publish(context.Background(), usecase.TaskNotification{TaskID: id})
+
+	n := <-ch
+	assert.Equal(t, id, n.TaskID, "only notifications for the subscribed task are delivered")
+
+	// A subscriber that stops reading does not block publishers
+	for i := 0; i < 100; i++ {
+		b.Publish(context.Background(), usecase.TaskNotification{TaskID: id})
+	}
+
+	unsubscribe()
+	unsubscribe()
+	for range ch {
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+)
+
+// DedupeReason says why a submission did not launch a new bot
+type DedupeReason string
+
+const (
+	DedupedOwnTask      DedupeReason = "own_task"      // The owner's active task for the URL was returned
+	DedupedSharedTask   DedupeReason = "shared_task"   // The new task mirrors another owner's active task
+	DedupedCachedResult DedupeReason = "cached_result" // The new task reuses a recent completed analysis
+)
+
+// FinishReason is a bounded label for why a task left flight; event reasons are free-form text
+type FinishReason string
+
+const (
+	FinishedBotReport        FinishReason = "bot_report"        // Reported by the bot's webhook
+	FinishedExecutorStatus   FinishReason = "executor_status"   // Seen by the poller
+	FinishedLaunchError      FinishReason = "launch_error"      // RunBot failed
+	FinishedDispatchDeadline FinishReason = "dispatch_deadline" // The dispatcher never recorded the launch
+	FinishedNoExternalID     FinishReason = "no_external_id"    // RUNNING without a bot to poll
+	FinishedMaxRuntime       FinishReason = "max_runtime"       // The bot ran too long
+	FinishedUserCancel       FinishReason = "user_cancel"       // Cancelled through the API
+	FinishedOther            FinishReason = "other"
+)
+
+// finishReason classifies a transition out of flight by who made it and from where
+func finishReason(event *domain.TaskEvent) FinishReason {
+	switch event.Actor {
+	case domain.ActorWebhook:
+		return FinishedBotReport
+	case domain.ActorPoller:
+		return FinishedExecutorStatus
+	case domain.ActorDispatcher:
+		return FinishedLaunchError
+	case domain.ActorAPI:
+		return FinishedUserCancel
+	case domain.ActorReaper:
+		switch {
+		case event.ToStatus == domain.TaskStatusTimedOut:
+			return FinishedMaxRuntime
+		case event.FromStatus == domain.TaskStatusDispatching:
+			return FinishedDispatchDeadline
+		default:
+			return FinishedNoExternalID
+		}
+	default:
+		return FinishedOther
+	}
+}
+
+// observeTransition reports a saved status change of task to the metrics
+func (u *taskUsecase) observeTransition(task *domain.AnalysisTask, event *domain.TaskEvent) {
+	if event.FromStatus == event.ToStatus {
+		return
+	}
+
+	switch event.ToStatus {
+	case domain.TaskStatusPending, domain.TaskStatusDispatching:
+		return
+	case domain.TaskStatusRunning:
+		// Retried tasks waited out a backoff, which would skew the queueing time
+		if task.RetryCount == 0 {
+			u.metrics.TaskStarted(event.CreatedAt.Sub(task.CreatedAt))
+		}
+		return
+	}
+
+	if event.FromStatus == domain.TaskStatusRunning && task.StartedAt != nil {
+		u.metrics.TaskRan(event.ToStatus, event.CreatedAt.Sub(*task.StartedAt))
+	}
+	u.metrics.TaskFinished(event.ToStatus, finishReason(event))
+}
+
+// RecordQueueDepth reports how many tasks are in each active status
+func (u *taskUsecase) RecordQueueDepth(ctx context.Context) error {
+	counts, err := u.repo.CountByStatus(ctx, domain.ActiveStatuses())
+	if err != nil {
+		return err
+	}
+	for status, n := range counts {
+		u.metrics.QueueDepth(status, n)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type finish struct {
+	status domain.TaskStatus
+	reason usecase.FinishReason
+}
+
+type recordingMetrics struct {
+	usecase.NopMetrics
+	created  int
+	deduped  []usecase.DedupeReason
+	finished []finish
+	retries  int
+	started  []time.Duration
+	ran      map[domain.TaskStatus]time.Duration
+	depth    map[domain.TaskStatus]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{ran: map[domain.TaskStatus]time.Duration{}, depth: map[domain.TaskStatus]int{}}
+}
+
+func (m *recordingMetrics) TaskCreated()                          { m.created++ }
+func (m *recordingMetrics) TaskDeduped(r usecase.DedupeReason)    { m.deduped = append(m.deduped, r) }
+func (m *recordingMetrics) TaskRetried()                          { m.retries++ }
+func (m *recordingMetrics) TaskStarted(wait time.Duration)        { m.started = append(m.started, wait) }
+func (m *recordingMetrics) QueueDepth(s domain.TaskStatus, n int) { m.depth[s] = n }
+func (m *recordingMetrics) TaskRan(s domain.TaskStatus, d time.Duration) {
+	m.ran[s] = d
+}
+func (m *recordingMetrics) TaskFinished(s domain.TaskStatus, r usecase.FinishReason) {
+	m.finished = append(m.finished, finish{s, r})
+}
+
+func TestMetrics_CreateAndDedupe(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	metrics := newRecordingMetrics()
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(), usecase.WithMetrics(metrics))
+
+	ctx := context.Background()
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning}
+	own := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "bob", Status: domain.TaskStatusPending}
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", "http://example.com/a").Return(own, nil)
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", "http://example.com/b").Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", ctx, "http://example.com/b").Return(leader, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	_, err := u.CreateTask(ctx, "http://example.com/a", "", "", "bob", usecase.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = u.CreateTask(ctx, "http://example.com/b", "", "", "bob", usecase.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, metrics.created, "returning the own task stores nothing")
+	assert.Equal(t, []usecase.DedupeReason{usecase.DedupedOwnTask, usecase.DedupedSharedTask}, metrics.deduped)
+}
+
+func TestMetrics_TimeInState(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	metrics := newRecordingMetrics()
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(), usecase.WithMetrics(metrics))
+
+	ctx := context.Background()
+	started := time.Now().Add(-time.Minute)
+	task := &domain.AnalysisTask{
+		ID:        uuid.New(),
+		Status:    domain.TaskStatusDispatching,
+		CreatedAt: time.Now().Add(-90 * time.Second),
+	}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{Status: domain.TaskStatusRunning}, domain.ActorWebhook))
+	if assert.Len(t, metrics.started, 1) {
+		assert.InDelta(t, 90*time.Second, metrics.started[0], float64(time.Second))
+	}
+
+	task.StartedAt = &started
+	assert.NoError(t, u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{Status: domain.TaskStatusCompleted}, domain.ActorWebhook))
+	assert.InDelta(t, time.Minute, metrics.ran[domain.TaskStatusCompleted], float64(time.Second))
+	assert.Equal(t, []finish{{domain.TaskStatusCompleted, usecase.FinishedBotReport}}, metrics.finished)
+}
+
+func TestMetrics_FinishReasons(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+	metrics := newRecordingMetrics()
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop(),
+		usecase.WithReaperConfig(usecase.ReaperConfig{DispatchDeadline: time.Minute, MaxRuntime: 10 * time.Minute}),
+		usecase.WithMetrics(metrics),
+	)
+
+	ctx := context.Background()
+	stuck := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusDispatching}
+	overdue := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusRunning, ExternalID: "arn:overdue"}
+
+	mockRepo.On("GetOverdueTasks", ctx, mock.Anything, mock.Anything).Return([]*domain.AnalysisTask{stuck, overdue}, nil)
+	mockRepo.On("SaveTransition", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockExecutor.On("StopBot", ctx, "arn:overdue", mock.Anything).Return(nil)
+
+	assert.NoError(t, u.ReapOverdueTasks(ctx))
+	assert.Equal(t, []finish{
+		{domain.TaskStatusFailed, usecase.FinishedDispatchDeadline},
+		{domain.TaskStatusTimedOut, usecase.FinishedMaxRuntime},
+	}, metrics.finished)
+
+	failed := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusFailed}
+	mockRepo.On("GetFailedTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{failed}, nil)
+
+	assert.NoError(t, u.RetryFailedTasks(ctx))
+	assert.Equal(t, 1, metrics.retries)
+	assert.Len(t, metrics.finished, 2, "going back to PENDING does not finish the task")
+}
+
+func TestRecordQueueDepth(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	metrics := newRecordingMetrics()
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(), usecase.WithMetrics(metrics))
+
+	ctx := context.Background()
+	mockRepo.On("CountByStatus", ctx, mock.MatchedBy(func(statuses []domain.TaskStatus) bool {
+		return assert.ElementsMatch(t, []domain.TaskStatus{
+			domain.TaskStatusPending, domain.TaskStatusDispatching, domain.TaskStatusRunning,
+			domain.TaskStatusFailed, domain.TaskStatusTimedOut,
+		}, statuses)
+	})).Return(map[domain.TaskStatus]int{domain.TaskStatusPending: 4, domain.TaskStatusRunning: 2}, nil)
+
+	assert.NoError(t, u.RecordQueueDepth(ctx))
+	assert.Equal(t, map[domain.TaskStatus]int{domain.TaskStatusPending: 4, domain.TaskStatusRunning: 2}, metrics.depth)
+}
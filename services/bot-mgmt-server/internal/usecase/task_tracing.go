@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase")
+
+// traceContext is the W3C Trace Context format used both for the trace stored on a task
+// and for the one handed to its bot, independent of the globally configured propagator
+var traceContext = propagation.TraceContext{}
+
+// startSpan starts a usecase span for the task with the given ID, if known. Usecase spans
+// only ever join a trace started by a request; without one, ctx is returned unchanged
+// with a span that records nothing.
+func startSpan(ctx context.Context, name string, taskID string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	var attrs []attribute.KeyValue
+	if taskID != "" {
+		attrs = append(attrs, attribute.String("task.id", taskID))
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// log returns the logger annotated with the trace of ctx
+func (u *taskUsecase) log(ctx context.Context) *zap.Logger {
+	return logger.WithTraceContext(ctx, u.logger)
+}
+
+// injectTraceParent returns the traceparent of the span in ctx, or "" if there is none
+func injectTraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// taskTraceContext returns ctx with the trace that created task as the parent of new spans,
+// so that work done later by the workers joins the submitting request's trace
+func taskTraceContext(ctx context.Context, task *domain.AnalysisTask) context.Context {
+	if task.TraceParent == "" {
+		return ctx
+	}
+	return traceContext.Extract(ctx, propagation.MapCarrier{"traceparent": task.TraceParent})
+}
+
+// traceEnv returns the trace context of ctx as bot environment variables (TRACEPARENT,
+// TRACESTATE), the convention OpenTelemetry SDKs use for processes, or nil
+func traceEnv(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(carrier))
+	for k, v := range carrier {
+		env[strings.ToUpper(k)] = v
+	}
+	return env
+}
@@ -0,0 +1,77 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/fake"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const testTraceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+// tracedContext returns a context inside the span of testTraceParent
+func tracedContext(t *testing.T) context.Context {
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("b7ad6b7169203331")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestCreateTask_StoresTraceParent(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop())
+
+	url := "http://example.com/traced"
+	mockRepo.On("GetOwnerActiveTaskByURL", mock.Anything, "user-1", url).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", mock.Anything, url).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	task, err := u.CreateTask(tracedContext(t), url, "req", "analysis", "user-1", usecase.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testTraceParent, task.TraceParent)
+
+	// Requests outside a trace leave nothing to join
+	untraced, err := u.CreateTask(context.Background(), url, "req", "analysis", "user-1", usecase.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, untraced.TraceParent)
+}
+
+func TestDispatchPendingTasks_PropagatesTraceToBot(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeSucceed}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop(),
+		usecase.WithBotEnv(func(task *domain.AnalysisTask) map[string]string {
+			return map[string]string{"WEBHOOK_SECRET": "secret"}
+		}),
+	)
+
+	ctx := context.Background()
+	traced := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com/a", Status: domain.TaskStatusDispatching, TraceParent: testTraceParent}
+	untraced := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com/b", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{traced, untraced}, nil)
+	mockRepo.On("SaveTransition", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
+
+	// The bot continues the trace of the request that submitted its task
+	env := executor.Env(traced.ExternalID)
+	assert.Contains(t, env["TRACEPARENT"], "0af7651916cd43dd8448eb211c80319c")
+	assert.Equal(t, "secret", env["WEBHOOK_SECRET"])
+
+	env = executor.Env(untraced.ExternalID)
+	assert.NotContains(t, env, "TRACEPARENT")
+	assert.Equal(t, "secret", env["WEBHOOK_SECRET"])
+}
@@ -2,154 +2,842 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
-	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/firebase"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
 type TaskUsecase interface {
-	CreateTask(ctx context.Context, url, requestUUID, firebaseToken, analysisID string) (*domain.AnalysisTask, error)
-	GetTaskStatus(ctx context.Context, id uuid.UUID) (*domain.AnalysisTask, error)
-	UpdateTaskStatus(ctx context.Context, id uuid.UUID, status domain.TaskStatus, result string) error
+	CreateTask(ctx context.Context, url, requestUUID, analysisID, ownerUID string, opts CreateOptions) (*domain.AnalysisTask, error)
+	// CreateBatch creates a task per item. Items fail individually; the batch fails only
+	// if it is empty, too large, or no item succeeded.
+	CreateBatch(ctx context.Context, ownerUID string, items []BatchItem, opts CreateOptions) (*BatchResult, error)
+	GetBatchProgress(ctx context.Context, id uuid.UUID, ownerUID string) (*domain.BatchProgress, error)
+	// WatchTask streams ownerUID's task: its current state, then its state after every
+	// change, until it reaches a terminal status or ctx ends. The channel is then closed.
+	WatchTask(ctx context.Context, id uuid.UUID, ownerUID string) (<-chan TaskChange, error)
+	GetTaskStatus(ctx context.Context, id uuid.UUID, ownerUID string) (*domain.AnalysisTask, error)
+	// ListTasks returns one page of ownerUID's tasks matching filter; filter.OwnerUID is ignored
+	ListTasks(ctx context.Context, ownerUID string, filter domain.TaskFilter, page domain.Page) (*domain.TaskPage, error)
+	UpdateTaskStatus(ctx context.Context, id uuid.UUID, update StatusUpdate, actor domain.TaskActor) error
+	CancelTask(ctx context.Context, id uuid.UUID, ownerUID, reason string) (*domain.AnalysisTask, error)
 	RetryFailedTasks(ctx context.Context) error
 	CheckRunningTasks(ctx context.Context) error
+	DispatchPendingTasks(ctx context.Context) error
+	ReapOverdueTasks(ctx context.Context) error
+	// RecordQueueDepth reports the number of tasks in each active status to the metrics
+	RecordQueueDepth(ctx context.Context) error
+	// DeliverCallbacks sends the due callback deliveries
+	DeliverCallbacks(ctx context.Context) error
+	ListCallbackDeliveries(ctx context.Context, taskID uuid.UUID, ownerUID string) ([]*domain.CallbackDelivery, error)
+	// RedeliverCallback queues a new delivery of ownerUID's finished task to its callback URL
+	RedeliverCallback(ctx context.Context, taskID uuid.UUID, ownerUID string) (*domain.CallbackDelivery, error)
+}
+
+// CreateOptions are per-request CreateTask settings
+type CreateOptions struct {
+	MaxAge       *time.Duration // Overrides the result cache window for this request; 0 disables it
+	ForceRefresh bool           // Always launch a new analysis, ignoring cached results
+	// CallbackURL receives the outcome once the task finishes. It must be on the callback
+	// allowlist. An already active task returned for the same URL keeps its own callback.
+	CallbackURL string
+}
+
+// StatusUpdate is a status report for a task, e.g. from the bot's webhook
+type StatusUpdate struct {
+	Status         domain.TaskStatus
+	Result         string                 // Free-form result, or the failure reason for FAILED
+	ExitCode       *int                   // Bot exit code for FAILED, used to classify the failure
+	AnalysisResult *domain.AnalysisResult // Structured result, only for COMPLETED
+}
+
+// DispatchConfig bounds how many bots the dispatcher launches
+type DispatchConfig struct {
+	BatchSize     int // Maximum number of tasks claimed per dispatch cycle
+	MaxConcurrent int // Global cap on DISPATCHING + RUNNING tasks (0 = unlimited)
+	MaxPerUser    int // Per-owner cap on DISPATCHING + RUNNING tasks (0 = unlimited)
+	// LaunchTimeout bounds a single RunBot call. It must be shorter than the reaper's
+	// DispatchDeadline so that a hung launch fails before the reaper fails the task under it.
+	LaunchTimeout time.Duration
+}
+
+// DefaultDispatchConfig is used when no DispatchConfig option is given
+var DefaultDispatchConfig = DispatchConfig{
+	BatchSize:     10,
+	LaunchTimeout: 90 * time.Second,
+}
+
+// ReaperConfig sets how long a task may stay in flight before it is reaped
+type ReaperConfig struct {
+	DispatchDeadline time.Duration // Max time between claim and the bot being launched
+	MaxRuntime       time.Duration // Max time a bot may run
+}
+
+// DefaultReaperConfig is used when no ReaperConfig option is given
+var DefaultReaperConfig = ReaperConfig{
+	DispatchDeadline: 2 * time.Minute,
+	MaxRuntime:       15 * time.Minute,
+}
+
+// Option configures optional taskUsecase settings
+type Option func(*taskUsecase)
+
+// WithDispatchConfig sets the dispatcher limits
+func WithDispatchConfig(cfg DispatchConfig) Option {
+	return func(u *taskUsecase) {
+		u.dispatch = cfg.withDefaults()
+	}
+}
+
+func (c DispatchConfig) withDefaults() DispatchConfig {
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultDispatchConfig.BatchSize
+	}
+	if c.LaunchTimeout <= 0 {
+		c.LaunchTimeout = DefaultDispatchConfig.LaunchTimeout
+	}
+	return c
+}
+
+// WithReaperConfig sets the reaper deadlines
+func WithReaperConfig(cfg ReaperConfig) Option {
+	return func(u *taskUsecase) {
+		u.reaper = cfg.withDefaults()
+	}
+}
+
+func (c ReaperConfig) withDefaults() ReaperConfig {
+	if c.DispatchDeadline <= 0 {
+		c.DispatchDeadline = DefaultReaperConfig.DispatchDeadline
+	}
+	if c.MaxRuntime <= 0 {
+		c.MaxRuntime = DefaultReaperConfig.MaxRuntime
+	}
+	return c
+}
+
+// ValidateDeadlines reports a launch timeout that would let the reaper fail tasks whose
+// bot is still being launched. Unset values are checked with their defaults.
+func ValidateDeadlines(dispatch DispatchConfig, reaper ReaperConfig) error {
+	dispatch, reaper = dispatch.withDefaults(), reaper.withDefaults()
+	if dispatch.LaunchTimeout >= reaper.DispatchDeadline {
+		return fmt.Errorf("launch timeout (%s) must be shorter than the dispatch deadline (%s)", dispatch.LaunchTimeout, reaper.DispatchDeadline)
+	}
+	return nil
+}
+
+// WithRetryPolicy sets the backoff and failure classification for failed tasks
+func WithRetryPolicy(policy domain.RetryPolicy) Option {
+	return func(u *taskUsecase) {
+		u.retry = policy
+	}
+}
+
+// CacheConfig sets how long a completed analysis is reused for new tasks of the same URL
+type CacheConfig struct {
+	MaxAge time.Duration // 0 disables the result cache
+}
+
+// WithResultCache enables reusing recent completed analyses
+func WithResultCache(cfg CacheConfig) Option {
+	return func(u *taskUsecase) {
+		u.cache = cfg
+	}
+}
+
+// Metrics receives usecase counters and timings
+type Metrics interface {
+	ResultCacheHit()
+	ResultCacheMiss()
+	// TaskCreated counts a stored task
+	TaskCreated()
+	// TaskDeduped counts a submission answered without launching a new bot
+	TaskDeduped(reason DedupeReason)
+	// TaskFinished counts a task leaving flight: completed, failed (possibly to be retried) or cancelled
+	TaskFinished(status domain.TaskStatus, reason FinishReason)
+	// TaskRetried counts a failed task sent back to PENDING
+	TaskRetried()
+	// TaskStarted observes the time from creation to the first launch of the task's bot
+	TaskStarted(wait time.Duration)
+	// TaskRan observes how long a bot ran before its task left RUNNING for status
+	TaskRan(status domain.TaskStatus, runtime time.Duration)
+	// QueueDepth reports the number of tasks running their own bot in an active status
+	QueueDepth(status domain.TaskStatus, n int)
+}
+
+// WithMetrics sets where usecase counters are reported
+func WithMetrics(m Metrics) Option {
+	return func(u *taskUsecase) {
+		u.metrics = m
+	}
+}
+
+// NopMetrics discards everything; it is used when no Metrics is configured
+type NopMetrics struct{}
+
+func (NopMetrics) ResultCacheHit()                              {}
+func (NopMetrics) ResultCacheMiss()                             {}
+func (NopMetrics) TaskCreated()                                 {}
+func (NopMetrics) TaskDeduped(DedupeReason)                     {}
+func (NopMetrics) TaskFinished(domain.TaskStatus, FinishReason) {}
+func (NopMetrics) TaskRetried()                                 {}
+func (NopMetrics) TaskStarted(time.Duration)                    {}
+func (NopMetrics) TaskRan(domain.TaskStatus, time.Duration)     {}
+func (NopMetrics) QueueDepth(domain.TaskStatus, int)            {}
+
+// BatchConfig bounds batch submissions
+type BatchConfig struct {
+	MaxItems int
+}
+
+// DefaultBatchConfig is used when no BatchConfig option is given
+var DefaultBatchConfig = BatchConfig{
+	MaxItems: 20,
+}
+
+// WithBatchConfig sets the batch submission limits
+func WithBatchConfig(cfg BatchConfig) Option {
+	return func(u *taskUsecase) {
+		if cfg.MaxItems <= 0 {
+			cfg.MaxItems = DefaultBatchConfig.MaxItems
+		}
+		u.batch = cfg
+	}
+}
+
+// CallbackConfig sets how callbacks to caller-supplied URLs are delivered
+type CallbackConfig struct {
+	AllowedHosts domain.CallbackAllowlist // Hosts a callback URL may point to
+	MaxAttempts  int                      // Attempts per delivery before it is FAILED
+	Retry        domain.RetryPolicy       // Backoff between attempts
+	BatchSize    int                      // Maximum number of deliveries sent per cycle
+	Lease        time.Duration            // How long a claimed delivery is hidden from other replicas; must exceed the send timeout
+}
+
+// DefaultCallbackConfig is used for the CallbackConfig fields left unset
+var DefaultCallbackConfig = CallbackConfig{
+	MaxAttempts: 8,
+	Retry: domain.RetryPolicy{
+		BaseDelay:  10 * time.Second,
+		MaxDelay:   time.Hour,
+		Multiplier: 2,
+		Jitter:     0.2,
+	},
+	BatchSize: 10,
+	Lease:     2 * time.Minute,
+}
+
+// WithCallbacks enables callbacks to the callback URLs of finished tasks.
+// Without it, requests with a callback URL are rejected.
+func WithCallbacks(repo domain.CallbackRepository, sender domain.CallbackSender, cfg CallbackConfig) Option {
+	return func(u *taskUsecase) {
+		if cfg.MaxAttempts <= 0 {
+			cfg.MaxAttempts = DefaultCallbackConfig.MaxAttempts
+		}
+		if cfg.Retry.BaseDelay <= 0 {
+			cfg.Retry = DefaultCallbackConfig.Retry
+		}
+		if cfg.BatchSize <= 0 {
+			cfg.BatchSize = DefaultCallbackConfig.BatchSize
+		}
+		if cfg.Lease <= 0 {
+			cfg.Lease = DefaultCallbackConfig.Lease
+		}
+		u.callbackRepo = repo
+		u.callbackSender = sender
+		u.callbacks = cfg
+	}
+}
+
+// DedupeScope selects which active task of another owner a new task shares
+type DedupeScope string
+
+const (
+	DedupeByURL    DedupeScope = "url"    // A task for the same canonical URL
+	DedupeByDomain DedupeScope = "domain" // A task for any URL under the same registered domain
+)
+
+// WithURLCanonicalizer sets how submitted URLs are normalized before deduplication
+func WithURLCanonicalizer(c *urlcanon.Canonicalizer) Option {
+	return func(u *taskUsecase) {
+		u.canon = c
+	}
+}
+
+// WithDedupeScope sets how widely tasks of other owners are shared
+func WithDedupeScope(scope DedupeScope) Option {
+	return func(u *taskUsecase) {
+		u.dedupe = scope
+	}
+}
+
+// BotEnvFunc returns extra environment variables for the bot running task
+type BotEnvFunc func(task *domain.AnalysisTask) map[string]string
+
+// WithBotEnv adds per-task environment variables (e.g. webhook credentials) to launched bots
+func WithBotEnv(fn BotEnvFunc) Option {
+	return func(u *taskUsecase) {
+		u.botEnv = fn
+	}
 }
 
 type taskUsecase struct {
 	repo     domain.TaskRepository
 	executor domain.BotExecutor
-	verifier firebase.TokenVerifier
 	logger   *zap.Logger
+	dispatch DispatchConfig
+	reaper   ReaperConfig
+	retry    domain.RetryPolicy
+	botEnv   BotEnvFunc
+	canon    *urlcanon.Canonicalizer
+	dedupe   DedupeScope
+	cache    CacheConfig
+	metrics  Metrics
+	batch    BatchConfig
+	broker   EventBroker
+
+	watchRecheck time.Duration
+
+	callbackRepo   domain.CallbackRepository // nil when callbacks are disabled
+	callbackSender domain.CallbackSender
+	callbacks      CallbackConfig
 }
 
-func NewTaskUsecase(repo domain.TaskRepository, executor domain.BotExecutor, verifier firebase.TokenVerifier, logger *zap.Logger) TaskUsecase {
-	return &taskUsecase{
+func NewTaskUsecase(repo domain.TaskRepository, executor domain.BotExecutor, logger *zap.Logger, opts ...Option) TaskUsecase {
+	u := &taskUsecase{
 		repo:     repo,
 		executor: executor,
-		verifier: verifier,
 		logger:   logger,
+		dispatch: DefaultDispatchConfig,
+		reaper:   DefaultReaperConfig,
+		retry:    domain.DefaultRetryPolicy,
+		canon:    urlcanon.New(urlcanon.DefaultOptions),
+		dedupe:   DedupeByURL,
+		metrics:  NopMetrics{},
+		batch:    DefaultBatchConfig,
+		broker:   NewMemoryBroker(),
+
+		watchRecheck: DefaultWatchRecheckInterval,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
-func (u *taskUsecase) CreateTask(ctx context.Context, url, requestUUID, firebaseToken, analysisID string) (*domain.AnalysisTask, error) {
-	// Verify Firebase Token if provided
-	if firebaseToken != "" {
-		if _, err := u.verifier.VerifyIDToken(ctx, firebaseToken); err != nil {
-			u.logger.Warn("Invalid firebase token", zap.Error(err))
-			return nil, fmt.Errorf("invalid firebase token: %w", err)
+func (u *taskUsecase) CreateTask(ctx context.Context, url, requestUUID, analysisID, ownerUID string, opts CreateOptions) (task *domain.AnalysisTask, err error) {
+	ctx, span := startSpan(ctx, "TaskUsecase.CreateTask", "")
+	defer func() {
+		if task != nil {
+			span.SetAttributes(attribute.String("task.id", task.ID.String()))
 		}
-	} else {
-		// Enforce token presence?
-		// For now, let's make it optional or strictly required as per "verify FirebaseToken" request.
-		// User said "receive ... and verify", implies it is required.
-		// However, I'll log warning for now or return error if strict.
-		// Let's assume strict for security if token is meant to be verified.
-		// BUT the user didn't say it's MANDATORY. Let's return error if empty for better security.
-		return nil, fmt.Errorf("firebase token is required")
+		endSpan(span, err)
+	}()
+
+	if err := u.checkCallbackURL(opts.CallbackURL); err != nil {
+		return nil, err
 	}
+	canonical, err := u.canon.Canonicalize(url)
+	if err != nil {
+		return nil, err
+	}
+	return u.createTask(ctx, url, canonical, requestUUID, analysisID, ownerUID, opts)
+}
 
+// createTask creates a task for url, whose canonical form is canonical
+func (u *taskUsecase) createTask(ctx context.Context, url string, canonical urlcanon.Result, requestUUID, analysisID, ownerUID string, opts CreateOptions) (*domain.AnalysisTask, error) {
+	// The same requester submitting the URL again gets their existing task back
+	if ownTask, err := u.repo.GetOwnerActiveTaskByURL(ctx, ownerUID, canonical.URL); err == nil && ownTask != nil {
+		u.log(ctx).Info("Returning existing active task for URL",
+			zap.String("url", url),
+			zap.String("task_id", ownTask.ID.String()),
+			zap.String("status", string(ownTask.Status)))
+		u.metrics.TaskDeduped(DedupedOwnTask)
+		return ownTask, nil
+	}
+
+	now := time.Now()
 	task := &domain.AnalysisTask{
-		ID:            uuid.New(),
-		RequestUUID:   requestUUID,
-		URL:           url,
-		FirebaseToken: firebaseToken,
-		AnalysisID:    analysisID,
-		Status:        domain.TaskStatusPending,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
-	}
-
-	// Check if there is already an active task for this URL
-	if existingTask, err := u.repo.GetActiveTaskByURL(ctx, url); err == nil && existingTask != nil {
-		u.logger.Info("Returning existing active task for URL",
+		ID:               uuid.New(),
+		RequestUUID:      requestUUID,
+		URL:              url,
+		CanonicalURL:     canonical.URL,
+		RegisteredDomain: canonical.RegisteredDomain,
+		CallbackURL:      opts.CallbackURL,
+		TraceParent:      injectTraceParent(ctx),
+		OwnerUID:         ownerUID,
+		AnalysisID:       analysisID,
+		Status:           domain.TaskStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// A recent analysis of the URL is reused instead of launching a bot at all
+	if cached := u.findCachedTask(ctx, canonical, opts); cached != nil {
+		copyCachedResult(task, cached)
+		if err := u.repo.Create(ctx, task); err != nil {
+			return nil, err
+		}
+		u.metrics.TaskCreated()
+		u.metrics.TaskDeduped(DedupedCachedResult)
+		u.log(ctx).Info("Reusing cached result for URL",
+			zap.String("url", url),
+			zap.String("task_id", task.ID.String()),
+			zap.String("cached_task_id", cached.ID.String()))
+		return task, nil
+	}
+
+	// Another requester's active task for the URL is shared rather than launching a second bot.
+	// The new task starts from the shared task's state and mirrors it from then on.
+	if shared, err := u.findSharableTask(ctx, canonical); err == nil && shared != nil {
+		task.SharedTaskID = &shared.ID
+		task.Status = shared.Status
+		task.RetryCount = shared.RetryCount
+		task.StartedAt = shared.StartedAt
+		task.NextAttemptAt = shared.NextAttemptAt
+		u.log(ctx).Info("Sharing active task for URL",
 			zap.String("url", url),
-			zap.String("task_id", existingTask.ID.String()),
-			zap.String("status", string(existingTask.Status)))
-		return existingTask, nil
+			zap.String("task_id", task.ID.String()),
+			zap.String("shared_task_id", shared.ID.String()),
+			zap.String("status", string(shared.Status)))
 	}
 
+	// The task is persisted as PENDING and launched by DispatchPendingTasks,
+	// so a crash before the bot starts never loses it.
 	if err := u.repo.Create(ctx, task); err != nil {
 		return nil, err
 	}
+	u.metrics.TaskCreated()
+	if task.SharedTaskID != nil {
+		u.metrics.TaskDeduped(DedupedSharedTask)
+	}
 
-	// Trigger Bot
-	// Note: In a real system, we might want to do this asynchronously or via a queue.
-	// For now, we launch it immediately.
-	go func() {
-		bgCtx := context.Background()
-		extID, err := u.executor.RunBot(bgCtx, task)
-		if err != nil {
-			u.logger.Error("Failed to run bot", zap.String("task_id", task.ID.String()), zap.Error(err))
-			u.UpdateTaskStatus(bgCtx, task.ID, domain.TaskStatusFailed, err.Error())
-		} else {
+	return task, nil
+}
 
-			// Update with External ID
-			task.ExternalID = extID
-			task.Status = domain.TaskStatusRunning
-			task.UpdatedAt = time.Now()
-			u.repo.Update(bgCtx, task)
-		}
-	}()
+// findCachedTask returns a completed task for canonical within the cache window, or nil.
+// Lookup errors are logged and treated as a miss so that caching never blocks a request.
+func (u *taskUsecase) findCachedTask(ctx context.Context, canonical urlcanon.Result, opts CreateOptions) *domain.AnalysisTask {
+	maxAge := u.cache.MaxAge
+	if opts.MaxAge != nil {
+		maxAge = *opts.MaxAge
+	}
+	if opts.ForceRefresh || maxAge <= 0 {
+		return nil
+	}
+
+	cached, err := u.repo.GetRecentCompletedTask(ctx, canonical.URL, canonical.RegisteredDomain, time.Now().Add(-maxAge))
+	if err != nil {
+		u.log(ctx).Error("Failed to look up cached result", zap.String("url", canonical.URL), zap.Error(err))
+	}
+	if cached == nil {
+		u.metrics.ResultCacheMiss()
+		return nil
+	}
+	u.metrics.ResultCacheHit()
+	return cached
+}
+
+// copyCachedResult turns task into a COMPLETED task carrying cached's result
+func copyCachedResult(task, cached *domain.AnalysisTask) {
+	task.SharedTaskID = &cached.ID
+	task.FromCache = true
+	task.Status = domain.TaskStatusCompleted
+	task.StartedAt = cached.StartedAt
+	task.Result = cached.Result
+	if cached.AnalysisResult != nil {
+		result := *cached.AnalysisResult
+		result.TaskID = task.ID
+		result.CreatedAt, result.UpdatedAt = time.Time{}, time.Time{}
+		task.AnalysisResult = &result
+	}
+}
+
+// findSharableTask returns the active task a new task for canonical may share, or nil
+func (u *taskUsecase) findSharableTask(ctx context.Context, canonical urlcanon.Result) (*domain.AnalysisTask, error) {
+	if u.dedupe == DedupeByDomain && canonical.RegisteredDomain != "" {
+		return u.repo.GetActiveTaskByDomain(ctx, canonical.RegisteredDomain)
+	}
+	return u.repo.GetActiveTaskByURL(ctx, canonical.URL)
+}
 
+// getOwnedTask loads a task on behalf of ownerUID. Tasks of other owners are reported
+// as not found so that task IDs cannot be probed.
+func (u *taskUsecase) getOwnedTask(ctx context.Context, id uuid.UUID, ownerUID string) (*domain.AnalysisTask, error) {
+	task, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.OwnerUID != ownerUID {
+		return nil, domain.ErrTaskNotFound
+	}
 	return task, nil
 }
 
-func (u *taskUsecase) GetTaskStatus(ctx context.Context, id uuid.UUID) (*domain.AnalysisTask, error) {
-	return u.repo.GetByID(ctx, id)
+func (u *taskUsecase) GetTaskStatus(ctx context.Context, id uuid.UUID, ownerUID string) (*domain.AnalysisTask, error) {
+	return u.getOwnedTask(ctx, id, ownerUID)
+}
+
+func (u *taskUsecase) ListTasks(ctx context.Context, ownerUID string, filter domain.TaskFilter, page domain.Page) (*domain.TaskPage, error) {
+	// Users only ever see their own tasks
+	filter.OwnerUID = ownerUID
+	return u.repo.List(ctx, filter, page)
 }
 
-func (u *taskUsecase) UpdateTaskStatus(ctx context.Context, id uuid.UUID, status domain.TaskStatus, result string) error {
+func (u *taskUsecase) UpdateTaskStatus(ctx context.Context, id uuid.UUID, update StatusUpdate, actor domain.TaskActor) (err error) {
+	ctx, span := startSpan(ctx, "TaskUsecase.UpdateTaskStatus", id.String())
+	defer func() { endSpan(span, err) }()
+
+	status := update.Status
+
+	// Bots report on their own run only; cancelling, retrying and reaping are up to the server
+	if actor == domain.ActorWebhook && !slices.Contains(domain.BotReportableStatuses(), status) {
+		return fmt.Errorf("%w: %q", domain.ErrUnreportableStatus, status)
+	}
+
+	if update.AnalysisResult != nil {
+		if status != domain.TaskStatusCompleted {
+			return fmt.Errorf("%w: only COMPLETED reports may carry an analysis result", domain.ErrInvalidResult)
+		}
+		if err := update.AnalysisResult.Validate(); err != nil {
+			return err
+		}
+	}
+
 	task, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	task.Status = status
-	if result != "" {
-		task.Result = result
+	if status == domain.TaskStatusFailed {
+		if task.Status == domain.TaskStatusDeadLetter {
+			// The poller already classified this failure as permanent
+			status = task.Status
+		} else if u.retry.ClassifyExitCode(update.ExitCode) == domain.FailurePermanent {
+			status = domain.TaskStatusDeadLetter
+		}
 	}
-	task.UpdatedAt = time.Now()
 
-	return u.repo.Update(ctx, task)
+	if task.Status == status {
+		// Duplicate report (e.g. webhook after the poller saw the task finish): only attach missing results
+		changed := false
+		if update.Result != "" && task.Result == "" {
+			task.Result = update.Result
+			changed = true
+		}
+		if update.AnalysisResult != nil && task.AnalysisResult == nil {
+			task.AnalysisResult = update.AnalysisResult
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		task.UpdatedAt = time.Now()
+		return u.saveTransition(ctx, task, &domain.TaskEvent{TaskID: task.ID, FromStatus: status, ToStatus: status, Actor: actor})
+	}
+
+	reason := ""
+	switch status {
+	case domain.TaskStatusFailed:
+		reason = update.Result
+		u.scheduleRetry(task)
+	case domain.TaskStatusDeadLetter:
+		reason = update.Result
+	}
+
+	event, err := task.Transition(status, actor, reason)
+	if err != nil {
+		u.log(ctx).Warn("Rejected task status update",
+			zap.String("task_id", task.ID.String()),
+			zap.String("actor", string(actor)),
+			zap.Error(err))
+		return err
+	}
+	if update.Result != "" {
+		task.Result = update.Result
+	}
+	if update.AnalysisResult != nil {
+		task.AnalysisResult = update.AnalysisResult
+	}
+
+	if err := u.saveTransition(ctx, task, event); err != nil {
+		return err
+	}
+	if status != domain.TaskStatusRunning {
+		// The bot reported its outcome, so nothing needs it any more
+		u.releaseBot(ctx, task)
+	}
+	return nil
+}
+
+func (u *taskUsecase) CancelTask(ctx context.Context, id uuid.UUID, ownerUID, reason string) (_ *domain.AnalysisTask, err error) {
+	ctx, span := startSpan(ctx, "TaskUsecase.CancelTask", id.String())
+	defer func() { endSpan(span, err) }()
+
+	task, err := u.getOwnedTask(ctx, id, ownerUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "cancelled by user"
+	}
+
+	// Record the cancellation first so the poller and dispatcher stop acting on the task
+	if err := u.transition(ctx, task, domain.TaskStatusCancelled, domain.ActorAPI, reason); err != nil {
+		return nil, err
+	}
+
+	// Other requesters still waiting on the analysis keep the bot running
+	if task.SharedTaskID == nil {
+		successor, err := u.repo.HandOverSharedTask(ctx, task)
+		if err != nil {
+			// Stopping the bot now would strand the tasks sharing it, so leave it running
+			u.log(ctx).Error("Failed to hand over shared task", zap.String("task_id", task.ID.String()), zap.Error(err))
+			return task, nil
+		}
+		if successor != nil {
+			u.log(ctx).Info("Handed over shared task",
+				zap.String("task_id", task.ID.String()),
+				zap.String("successor_id", successor.ID.String()))
+			// Watchers of the tasks sharing this one re-read them and follow the successor
+			u.broker.Publish(ctx, TaskNotification{TaskID: task.ID})
+			task.ExternalID = ""
+			return task, nil
+		}
+	}
+
+	// A DISPATCHING task has no external ID yet; the dispatcher stops it once RunBot returns
+	if task.ExternalID != "" {
+		if err := u.executor.StopBot(ctx, task.ExternalID, reason); err != nil {
+			// The task stays CANCELLED; the bot will finish or hit its deadline on its own
+			u.log(ctx).Error("Failed to stop bot",
+				zap.String("task_id", task.ID.String()),
+				zap.String("external_id", task.ExternalID),
+				zap.Error(err))
+		}
+	}
+
+	return task, nil
+}
+
+// transition applies and persists a status change, logging rejected or conflicting ones.
+func (u *taskUsecase) transition(ctx context.Context, task *domain.AnalysisTask, to domain.TaskStatus, actor domain.TaskActor, reason string) error {
+	from := task.Status
+	event, err := task.Transition(to, actor, reason)
+	if err != nil {
+		u.log(ctx).Warn("Rejected task status transition",
+			zap.String("task_id", task.ID.String()),
+			zap.String("actor", string(actor)),
+			zap.Error(err))
+		return err
+	}
+
+	if err := u.saveTransition(ctx, task, event); err != nil {
+		if errors.Is(err, domain.ErrTransitionConflict) {
+			u.log(ctx).Info("Task status changed concurrently, transition skipped",
+				zap.String("task_id", task.ID.String()),
+				zap.String("from_status", string(from)),
+				zap.String("to_status", string(to)),
+				zap.String("actor", string(actor)))
+		} else {
+			u.log(ctx).Error("Failed to save task transition", zap.String("task_id", task.ID.String()), zap.Error(err))
+		}
+		return err
+	}
+
+	u.log(ctx).Info("Task status changed",
+		zap.String("task_id", task.ID.String()),
+		zap.String("from_status", string(from)),
+		zap.String("to_status", string(to)),
+		zap.String("actor", string(actor)))
+	return nil
+}
+
+// fail records a failure of the given class. Permanent failures go to DEAD_LETTER without
+// consuming a retry; transient ones become `to` (FAILED or TIMED_OUT) and are retried after a backoff.
+func (u *taskUsecase) fail(ctx context.Context, task *domain.AnalysisTask, to domain.TaskStatus, actor domain.TaskActor, reason string, class domain.FailureClass) error {
+	if class == domain.FailurePermanent {
+		to = domain.TaskStatusDeadLetter
+	} else {
+		u.scheduleRetry(task)
+	}
+	return u.transition(ctx, task, to, actor, reason)
+}
+
+// scheduleRetry sets when the retry worker may pick the task up again
+func (u *taskUsecase) scheduleRetry(task *domain.AnalysisTask) {
+	next := time.Now().Add(u.retry.Backoff(task.RetryCount))
+	task.NextAttemptAt = &next
 }
 
 func (u *taskUsecase) RetryFailedTasks(ctx context.Context) error {
-	tasks, err := u.repo.GetFailedTasks(ctx)
+	tasks, err := u.repo.GetFailedTasks(ctx, time.Now())
 	if err != nil {
 		return err
 	}
 
 	for _, task := range tasks {
 		task.RetryCount++
-		task.Status = domain.TaskStatusPending // Reset to Pending to be picked up or run immediately
-		task.UpdatedAt = time.Now()
-
-		if err := u.repo.Update(ctx, task); err != nil {
-			u.logger.Error("Failed to update task retry count", zap.String("task_id", task.ID.String()), zap.Error(err))
-			continue
+		task.NextAttemptAt = nil
+		// Reset to Pending to be picked up by the dispatcher
+		if err := u.transition(ctx, task, domain.TaskStatusPending, domain.ActorRetryWorker, fmt.Sprintf("retry attempt %d", task.RetryCount)); err == nil {
+			u.metrics.TaskRetried()
 		}
+	}
+	return nil
+}
+
+func (u *taskUsecase) DispatchPendingTasks(ctx context.Context) error {
+	tasks, err := u.repo.ClaimPendingTasks(ctx, domain.ClaimOptions{
+		Limit:       u.dispatch.BatchSize,
+		MaxInFlight: u.dispatch.MaxConcurrent,
+		MaxPerUser:  u.dispatch.MaxPerUser,
+	})
+	if err != nil {
+		return err
+	}
 
-		// Launch immediately
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
 		go func(t *domain.AnalysisTask) {
-			extID, err := u.executor.RunBot(context.Background(), t)
-			if err != nil {
-				// If fails again, it will be marked FAILED again by the err check or subsequent check
-				u.UpdateTaskStatus(context.Background(), t.ID, domain.TaskStatusFailed, err.Error())
-			} else {
-				t.ExternalID = extID
-				t.Status = domain.TaskStatusRunning
-				t.UpdatedAt = time.Now()
-				u.repo.Update(context.Background(), t)
-			}
+			defer wg.Done()
+			u.dispatchTask(ctx, t)
 		}(task)
 	}
+	wg.Wait()
+
 	return nil
 }
 
+// dispatchTask launches a claimed task and records the outcome. The launch is traced as part
+// of the request that created the task, and the bot is told to continue that trace.
+func (u *taskUsecase) dispatchTask(ctx context.Context, task *domain.AnalysisTask) {
+	ctx, span := startSpan(taskTraceContext(ctx, task), "TaskUsecase.dispatchTask", task.ID.String())
+	span.SetAttributes(attribute.Int("task.retry_count", task.RetryCount))
+	defer span.End()
+
+	var env map[string]string
+	if u.botEnv != nil {
+		env = u.botEnv(task)
+	}
+	if traceVars := traceEnv(ctx); traceVars != nil {
+		merged := make(map[string]string, len(env)+len(traceVars))
+		for k, v := range env {
+			merged[k] = v
+		}
+		for k, v := range traceVars {
+			merged[k] = v
+		}
+		env = merged
+	}
+	launchCtx, cancel := context.WithTimeout(ctx, u.dispatch.LaunchTimeout)
+	extID, err := u.executor.RunBot(launchCtx, task, env)
+	cancel()
+
+	// The outcome must be persisted even if ctx was cancelled while RunBot was in flight
+	updateCtx := context.WithoutCancel(ctx)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: hand the task back so the next dispatcher picks it up
+			_ = u.transition(updateCtx, task, domain.TaskStatusPending, domain.ActorDispatcher, "released on shutdown")
+			return
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		class := u.retry.ClassifyError(err)
+		u.log(ctx).Error("Failed to run bot",
+			zap.String("task_id", task.ID.String()),
+			zap.Int("dispatch_attempts", task.DispatchAttempts),
+			zap.String("failure_class", string(class)),
+			zap.Error(err))
+		task.Result = err.Error()
+		_ = u.fail(updateCtx, task, domain.TaskStatusFailed, domain.ActorDispatcher, err.Error(), class)
+		return
+	}
+
+	now := time.Now()
+	task.ExternalID = extID
+	task.StartedAt = &now
+	err = u.transition(updateCtx, task, domain.TaskStatusRunning, domain.ActorDispatcher, "bot started")
+	if errors.Is(err, domain.ErrTransitionConflict) {
+		u.settleLostLaunch(updateCtx, task, extID)
+	}
+}
+
+// settleLostLaunch handles a bot whose launch could not be recorded because the task moved
+// on while RunBot was in flight. The bot is kept only if a webhook already reported this
+// launch RUNNING. Otherwise the task was cancelled, reaped or retried meanwhile and the bot
+// is stopped so that it cannot run alongside a later attempt. The external ID is recorded
+// only if the bot ran for the current state of the task: RUNNING, or finished by its webhook.
+func (u *taskUsecase) settleLostLaunch(ctx context.Context, launched *domain.AnalysisTask, extID string) {
+	logger := u.log(ctx).With(zap.String("task_id", launched.ID.String()), zap.String("external_id", extID))
+
+	current, err := u.repo.GetByID(ctx, launched.ID)
+	if err != nil {
+		logger.Error("Failed to reload task after lost launch; stopping bot", zap.Error(err))
+		u.stopLostBot(ctx, logger, extID, "launch could not be recorded")
+		return
+	}
+
+	sameLaunch := current.RetryCount == launched.RetryCount && current.DispatchAttempts == launched.DispatchAttempts
+	keep := false
+	record := false
+	if sameLaunch {
+		switch {
+		case current.Status == domain.TaskStatusRunning:
+			keep, record = true, true
+		case current.Status != domain.TaskStatusDispatching && current.Status != domain.TaskStatusPending:
+			// Finished: by the bot's own webhook, or by the reaper or a cancellation instead
+			event, err := u.repo.GetLastEvent(ctx, current.ID)
+			if err != nil {
+				logger.Error("Failed to read last task event", zap.Error(err))
+			}
+			record = event != nil && event.Actor == domain.ActorWebhook
+		}
+	}
+
+	if !keep {
+		reason := fmt.Sprintf("task became %s during dispatch", current.Status)
+		if !sameLaunch {
+			reason = "task was retried during dispatch"
+		}
+		u.stopLostBot(ctx, logger, extID, reason)
+	}
+	if !record || current.ExternalID != "" {
+		return
+	}
+
+	// Saved as a same-status transition so that it cannot overwrite a concurrent retry
+	current.ExternalID = extID
+	if current.StartedAt == nil {
+		current.StartedAt = launched.StartedAt
+	}
+	event := &domain.TaskEvent{TaskID: current.ID, FromStatus: current.Status, ToStatus: current.Status, Actor: domain.ActorDispatcher}
+	if err := u.repo.SaveTransition(ctx, current, event); err != nil {
+		logger.Error("Failed to record external ID", zap.Error(err))
+	}
+}
+
+func (u *taskUsecase) stopLostBot(ctx context.Context, logger *zap.Logger, extID, reason string) {
+	logger.Warn("Stopping bot whose launch was overtaken", zap.String("reason", reason))
+	if err := u.executor.StopBot(ctx, extID, reason); err != nil {
+		logger.Error("Failed to stop bot", zap.Error(err))
+	}
+}
+
 func (u *taskUsecase) CheckRunningTasks(ctx context.Context) error {
 	tasks, err := u.repo.GetRunningTasks(ctx)
 	if err != nil {
@@ -161,25 +849,96 @@ func (u *taskUsecase) CheckRunningTasks(ctx context.Context) error {
 			continue
 		}
 
-		u.logger.Debug("Checking task status", zap.String("task_id", task.ID.String()), zap.String("external_id", task.ExternalID))
+		u.log(ctx).Debug("Checking task status", zap.String("task_id", task.ID.String()), zap.String("external_id", task.ExternalID))
 
 		status, err := u.executor.GetBotStatus(ctx, task.ExternalID)
 
 		if err != nil {
-			u.logger.Error("Failed to check status", zap.String("task_id", task.ID.String()), zap.Error(err))
+			u.log(ctx).Error("Failed to check status", zap.String("task_id", task.ID.String()), zap.Error(err))
 			continue
 		}
 
-		if status != task.Status {
-			u.logger.Info("Updating task status",
-				zap.String("task_id", task.ID.String()),
-				zap.String("old_status", string(task.Status)),
-				zap.String("new_status", string(status)))
-			task.Status = status
+		switch {
+		case !domain.CanTransition(task.Status, status.Status):
+			// Still running, or an earlier status such as PENDING for a container that
+			// has not started yet. Only moves forward are applied.
+			continue
+		case status.Status == domain.TaskStatusFailed:
+			err = u.fail(ctx, task, status.Status, domain.ActorPoller, failureReason(status), u.retry.ClassifyExitCode(status.ExitCode))
+		default:
+			err = u.transition(ctx, task, status.Status, domain.ActorPoller, "reported by executor")
+		}
+		// Only once the outcome is stored: until then the next poll must still find the bot
+		if err == nil {
+			u.releaseBot(ctx, task)
+		}
+	}
+	return nil
+}
 
-			task.UpdatedAt = time.Now()
-			u.repo.Update(ctx, task)
+func (u *taskUsecase) ReapOverdueTasks(ctx context.Context) error {
+	now := time.Now()
+	tasks, err := u.repo.GetOverdueTasks(ctx, now.Add(-u.reaper.DispatchDeadline), now.Add(-u.reaper.MaxRuntime))
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		var to domain.TaskStatus
+		var reason string
+
+		// Deadlines are transient failures: the next attempt may well finish in time
+		switch {
+		case task.Status == domain.TaskStatusDispatching:
+			// The dispatcher died or hung inside RunBot
+			to = domain.TaskStatusFailed
+			reason = fmt.Sprintf("dispatch deadline of %s exceeded", u.reaper.DispatchDeadline)
+		case task.ExternalID == "":
+			// RUNNING without a bot to poll, so CheckRunningTasks can never finish it
+			to = domain.TaskStatusFailed
+			reason = "running without an external ID"
+		default:
+			to = domain.TaskStatusTimedOut
+			reason = fmt.Sprintf("max runtime of %s exceeded", u.reaper.MaxRuntime)
 		}
+
+		if task.ExternalID != "" {
+			if err := u.executor.StopBot(ctx, task.ExternalID, reason); err != nil {
+				u.log(ctx).Error("Failed to stop overdue bot",
+					zap.String("task_id", task.ID.String()),
+					zap.String("external_id", task.ExternalID),
+					zap.Error(err))
+			}
+		}
+
+		// The retry worker picks FAILED and TIMED_OUT tasks up again while retries remain
+		task.Result = reason
+		_ = u.fail(ctx, task, to, domain.ActorReaper, reason, domain.FailureTransient)
 	}
 	return nil
 }
+
+// releaseBot frees what the finished bot of task left behind
+func (u *taskUsecase) releaseBot(ctx context.Context, task *domain.AnalysisTask) {
+	if task.ExternalID == "" {
+		return
+	}
+	if err := u.executor.ReleaseBot(ctx, task.ExternalID); err != nil {
+		u.log(ctx).Warn("Failed to release finished bot",
+			zap.String("task_id", task.ID.String()),
+			zap.String("external_id", task.ExternalID),
+			zap.Error(err))
+	}
+}
+
+// failureReason describes a failed bot run for the task event log
+func failureReason(status domain.BotStatus) string {
+	reason := "reported by executor"
+	if status.ExitCode != nil {
+		reason = fmt.Sprintf("%s: exit code %d", reason, *status.ExitCode)
+	}
+	if status.Reason != "" {
+		reason = fmt.Sprintf("%s (%s)", reason, status.Reason)
+	}
+	return reason
+}
@@ -2,45 +2,49 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/infrastructure/fake"
+	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/urlcanon"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/usecase"
 	"github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestCreateTask_DuplicateURL(t *testing.T) {
 	mockRepo := new(mocks.MockTaskRepository)
 	mockExecutor := new(mocks.MockBotExecutor)
-	mockVerifier := new(mocks.MockTokenVerifier)
 	logger := zap.NewNop()
 
-	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, mockVerifier, logger)
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, logger)
 
 	ctx := context.Background()
 	url := "http://example.com"
 	reqUUID := "req-123"
 
-	// Mock GetActiveTaskByURL to return an existing task
+	// Mock GetOwnerActiveTaskByURL to return the requester's existing task
 	existingTask := &domain.AnalysisTask{
 		ID:          uuid.New(),
 		RequestUUID: "req-existing",
+		OwnerUID:    "user-1",
 		URL:         url,
 		Status:      domain.TaskStatusRunning,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	mockRepo.On("GetActiveTaskByURL", ctx, url).Return(existingTask, nil)
-	mockVerifier.On("VerifyIDToken", ctx, "dummy-token").Return(nil, nil)
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "user-1", url).Return(existingTask, nil)
 
 	// Call CreateTask
-	result, err := u.CreateTask(ctx, url, reqUUID, "dummy-token", "dummy-analysis-id")
+	result, err := u.CreateTask(ctx, url, reqUUID, "dummy-analysis-id", "user-1", usecase.CreateOptions{})
 
 	// Verify
 	assert.NoError(t, err)
@@ -54,40 +58,856 @@ func TestCreateTask_DuplicateURL(t *testing.T) {
 func TestCreateTask_NewURL(t *testing.T) {
 	mockRepo := new(mocks.MockTaskRepository)
 	mockExecutor := new(mocks.MockBotExecutor)
-	mockVerifier := new(mocks.MockTokenVerifier)
 	logger := zap.NewNop()
 
-	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, mockVerifier, logger)
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, logger)
 
 	ctx := context.Background()
 	url := "http://example.com/new"
 	reqUUID := "req-new"
 
-	// Mock GetActiveTaskByURL to return nil (no active task)
+	// Mock the dedupe lookups to return nil (no active task)
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "user-1", url).Return((*domain.AnalysisTask)(nil), nil)
 	mockRepo.On("GetActiveTaskByURL", ctx, url).Return((*domain.AnalysisTask)(nil), nil)
-	mockVerifier.On("VerifyIDToken", ctx, "dummy-token").Return(nil, nil)
 
 	// Mock Create to succeed
 	mockRepo.On("Create", ctx, mock.MatchedBy(func(task *domain.AnalysisTask) bool {
-		return task.URL == url && task.RequestUUID == reqUUID
+		return task.URL == url && task.RequestUUID == reqUUID && task.OwnerUID == "user-1"
 	})).Return(nil)
 
-	// Mock Bot execution
-	mockExecutor.On("RunBot", mock.Anything, mock.Anything).Return("ext-new", nil)
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
-
 	// Call CreateTask
-	result, err := u.CreateTask(ctx, url, reqUUID, "dummy-token", "dummy-analysis-id")
+	result, err := u.CreateTask(ctx, url, reqUUID, "dummy-analysis-id", "user-1", usecase.CreateOptions{})
 
 	// Verify
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, url, result.URL)
 	assert.Equal(t, domain.TaskStatusPending, result.Status)
+	assert.Nil(t, result.SharedTaskID)
 
-	// Verify that Create WAS called
+	// Verify that Create WAS called and the bot is left to the dispatcher
 	mockRepo.AssertCalled(t, "Create", ctx, mock.Anything)
+	mockExecutor.AssertNotCalled(t, "RunBot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_SharesOtherOwnersTask(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	url := "http://example.com/shared"
+	startedAt := time.Now().Add(-time.Minute)
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", URL: url, Status: domain.TaskStatusRunning, StartedAt: &startedAt}
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", url).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", ctx, url).Return(leader, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := u.CreateTask(ctx, url, "", "analysis-bob", "bob", usecase.CreateOptions{})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, leader.ID, result.ID, "each requester gets their own task")
+	assert.Equal(t, "bob", result.OwnerUID)
+	assert.Equal(t, &leader.ID, result.SharedTaskID)
+	assert.Equal(t, domain.TaskStatusRunning, result.Status)
+	assert.Equal(t, leader.StartedAt, result.StartedAt)
+	mockExecutor.AssertNotCalled(t, "RunBot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_DedupesCanonicalURL(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop())
+
+	ctx := context.Background()
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", URL: "http://example.com", Status: domain.TaskStatusRunning}
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", "http://example.com").Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", ctx, "http://example.com").Return(leader, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := u.CreateTask(ctx, "http://Example.com/?utm_source=sms", "", "", "bob", usecase.CreateOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &leader.ID, result.SharedTaskID)
+	assert.Equal(t, "http://Example.com/?utm_source=sms", result.URL, "the submitted URL is kept")
+	assert.Equal(t, "http://example.com", result.CanonicalURL)
+	assert.Equal(t, "example.com", result.RegisteredDomain)
+}
+
+func TestCreateTask_DedupesByDomain(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithDedupeScope(usecase.DedupeByDomain),
+	)
+
+	ctx := context.Background()
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", URL: "https://login.example.co.uk/a1", Status: domain.TaskStatusPending}
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", "https://pay.example.co.uk/b2").Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByDomain", ctx, "example.co.uk").Return(leader, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := u.CreateTask(ctx, "https://pay.example.co.uk/b2", "", "", "bob", usecase.CreateOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &leader.ID, result.SharedTaskID)
+	mockRepo.AssertNotCalled(t, "GetActiveTaskByURL", mock.Anything, mock.Anything)
+}
+
+type countingMetrics struct {
+	usecase.NopMetrics
+	hits, misses int
+}
+
+func (m *countingMetrics) ResultCacheHit()  { m.hits++ }
+func (m *countingMetrics) ResultCacheMiss() { m.misses++ }
+
+func TestCreateTask_ReusesCachedResult(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	metrics := &countingMetrics{}
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithResultCache(usecase.CacheConfig{MaxAge: time.Hour}),
+		usecase.WithMetrics(metrics),
+	)
+
+	ctx := context.Background()
+	url := "http://example.com/cached"
+	cached := &domain.AnalysisTask{
+		ID:             uuid.New(),
+		OwnerUID:       "alice",
+		URL:            url,
+		Status:         domain.TaskStatusCompleted,
+		Result:         "done",
+		AnalysisResult: &domain.AnalysisResult{Verdict: domain.VerdictPhishing, Confidence: 0.9},
+	}
+
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", url).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetRecentCompletedTask", ctx, url, "example.com", mock.Anything).Return(cached, nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := u.CreateTask(ctx, url, "", "", "bob", usecase.CreateOptions{})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, cached.ID, result.ID)
+	assert.Equal(t, domain.TaskStatusCompleted, result.Status)
+	assert.True(t, result.FromCache)
+	assert.Equal(t, &cached.ID, result.SharedTaskID)
+	assert.Equal(t, "done", result.Result)
+	assert.Equal(t, result.ID, result.AnalysisResult.TaskID, "the result is copied, not shared")
+	assert.Equal(t, domain.VerdictPhishing, result.AnalysisResult.Verdict)
+	assert.Equal(t, 1, metrics.hits)
+	mockRepo.AssertNotCalled(t, "GetActiveTaskByURL", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_CacheOverrides(t *testing.T) {
+	url := "http://example.com/fresh"
+	zero := time.Duration(0)
+	thirtySeconds := 30 * time.Second
+
+	cases := []struct {
+		name   string
+		opts   usecase.CreateOptions
+		lookup bool
+	}{
+		{"force refresh", usecase.CreateOptions{ForceRefresh: true}, false},
+		{"max age zero", usecase.CreateOptions{MaxAge: &zero}, false},
+		{"narrower max age", usecase.CreateOptions{MaxAge: &thirtySeconds}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTaskRepository)
+			metrics := &countingMetrics{}
+			u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+				usecase.WithResultCache(usecase.CacheConfig{MaxAge: time.Hour}),
+				usecase.WithMetrics(metrics),
+			)
+
+			ctx := context.Background()
+			mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", url).Return((*domain.AnalysisTask)(nil), nil)
+			mockRepo.On("GetRecentCompletedTask", ctx, url, "example.com", mock.MatchedBy(func(after time.Time) bool {
+				return time.Since(after) < time.Minute
+			})).Return((*domain.AnalysisTask)(nil), nil)
+			mockRepo.On("GetActiveTaskByURL", ctx, url).Return((*domain.AnalysisTask)(nil), nil)
+			mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+			result, err := u.CreateTask(ctx, url, "", "", "bob", tc.opts)
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.TaskStatusPending, result.Status)
+			assert.False(t, result.FromCache)
+			if tc.lookup {
+				assert.Equal(t, 1, metrics.misses)
+			} else {
+				mockRepo.AssertNotCalled(t, "GetRecentCompletedTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				assert.Equal(t, 0, metrics.misses)
+			}
+		})
+	}
+}
+
+func TestCreateBatch_PartialSuccess(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithBatchConfig(usecase.BatchConfig{MaxItems: 5}),
+	)
+
+	ctx := context.Background()
+	mockRepo.On("GetOwnerActiveTaskByURL", ctx, "bob", mock.Anything).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("GetActiveTaskByURL", ctx, mock.Anything).Return((*domain.AnalysisTask)(nil), nil)
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil)
+	mockRepo.On("CreateBatch", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	result, err := u.CreateBatch(ctx, "bob", []usecase.BatchItem{
+		{URL: "http://example.com/a", AnalysisID: "a1"},
+		{URL: "ftp://example.com/b", AnalysisID: "a2"},
+		{URL: "http://EXAMPLE.com/a?utm_source=sms", AnalysisID: "a3"},
+		{URL: "http://example.org", AnalysisID: "a4"},
+	}, usecase.CreateOptions{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 4)
+	assert.Equal(t, "a1", result.Items[0].Task.AnalysisID)
+	assert.ErrorIs(t, result.Items[1].Err, urlcanon.ErrInvalidURL)
+	assert.True(t, result.Items[2].Duplicate)
+	assert.Equal(t, result.Items[0].Task.ID, result.Items[2].Task.ID)
+	assert.Equal(t, "bob", result.Batch.OwnerUID)
+
+	mockRepo.AssertNumberOfCalls(t, "Create", 2)
+	mockRepo.AssertCalled(t, "CreateBatch", ctx, result.Batch, []uuid.UUID{result.Items[0].Task.ID, result.Items[3].Task.ID})
+}
+
+func TestCreateBatch_Invalid(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop(),
+		usecase.WithBatchConfig(usecase.BatchConfig{MaxItems: 1}),
+	)
+	ctx := context.Background()
+
+	_, err := u.CreateBatch(ctx, "bob", nil, usecase.CreateOptions{})
+	assert.ErrorIs(t, err, domain.ErrInvalidBatch)
+
+	_, err = u.CreateBatch(ctx, "bob", []usecase.BatchItem{{URL: "http://a.example"}, {URL: "http://b.example"}}, usecase.CreateOptions{})
+	assert.ErrorIs(t, err, domain.ErrInvalidBatch, "too many items")
+
+	result, err := u.CreateBatch(ctx, "bob", []usecase.BatchItem{{URL: "not a url"}}, usecase.CreateOptions{})
+	assert.ErrorIs(t, err, domain.ErrInvalidBatch, "no item succeeded")
+	assert.Error(t, result.Items[0].Err)
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetBatchProgress_HidesOtherOwnersBatch(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop())
+
+	ctx := context.Background()
+	progress := &domain.BatchProgress{Batch: &domain.TaskBatch{ID: uuid.New(), OwnerUID: "alice"}, Total: 2, Active: 1}
+	mockRepo.On("GetBatchProgress", ctx, progress.Batch.ID).Return(progress, nil)
+
+	got, err := u.GetBatchProgress(ctx, progress.Batch.ID, "alice")
+	assert.NoError(t, err)
+	assert.False(t, got.Done())
+
+	_, err = u.GetBatchProgress(ctx, progress.Batch.ID, "mallory")
+	assert.ErrorIs(t, err, domain.ErrBatchNotFound)
+}
+
+func TestGetTaskStatus_HidesOtherOwnersTask(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", Status: domain.TaskStatusRunning}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+
+	got, err := u.GetTaskStatus(ctx, task.ID, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, got.ID)
+
+	_, err = u.GetTaskStatus(ctx, task.ID, "mallory")
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+}
+
+func TestListTasks_OnlyOwnTasks(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	u := usecase.NewTaskUsecase(mockRepo, new(mocks.MockBotExecutor), zap.NewNop())
+
+	ctx := context.Background()
+	page := domain.Page{Limit: 10}
+	expected := &domain.TaskPage{Tasks: []*domain.AnalysisTask{{ID: uuid.New(), OwnerUID: "alice"}}}
+	mockRepo.On("List", ctx, domain.TaskFilter{OwnerUID: "alice", URLContains: "example"}, page).Return(expected, nil)
+
+	// A filter naming another owner is overridden by the caller's UID
+	result, err := u.ListTasks(ctx, "alice", domain.TaskFilter{OwnerUID: "mallory", URLContains: "example"}, page)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestCheckRunningTasks_FakeExecutor(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeSucceed, Polls: 2}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusRunning}
+	extID, err := executor.RunBot(ctx, task, nil)
+	assert.NoError(t, err)
+	task.ExternalID = extID
+
+	mockRepo.On("GetRunningTasks", ctx).Return([]*domain.AnalysisTask{task}, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(nil)
+
+	// The first two polls still report RUNNING
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, u.CheckRunningTasks(ctx))
+		assert.Equal(t, domain.TaskStatusRunning, task.Status)
+	}
+	mockRepo.AssertNotCalled(t, "SaveTransition", ctx, task, mock.Anything)
+
+	assert.NoError(t, u.CheckRunningTasks(ctx))
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	mockRepo.AssertCalled(t, "SaveTransition", ctx, task, mock.Anything)
+}
+
+func TestCheckRunningTasks_ReleasesBotOnceOutcomeIsStored(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusRunning, ExternalID: "ext-1"}
+
+	mockRepo.On("GetRunningTasks", ctx).Return([]*domain.AnalysisTask{task}, nil)
+	mockExecutor.On("GetBotStatus", ctx, "ext-1").Return(domain.BotStatus{Status: domain.TaskStatusCompleted}, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(errors.New("connection reset")).Once()
+
+	// The bot is kept while its outcome is not stored, so the next poll can read it again
+	assert.NoError(t, u.CheckRunningTasks(ctx))
+	mockExecutor.AssertNotCalled(t, "ReleaseBot", mock.Anything, mock.Anything)
+
+	task.Status = domain.TaskStatusRunning
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(nil).Once()
+	mockExecutor.On("ReleaseBot", ctx, "ext-1").Return(nil).Once()
+
+	assert.NoError(t, u.CheckRunningTasks(ctx))
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestCheckRunningTasks_IgnoresEarlierStatuses(t *testing.T) {
+	for _, reported := range []domain.TaskStatus{domain.TaskStatusRunning, domain.TaskStatusPending, domain.TaskStatusDispatching} {
+		t.Run(string(reported), func(t *testing.T) {
+			mockRepo := new(mocks.MockTaskRepository)
+			mockExecutor := new(mocks.MockBotExecutor)
+
+			core, logs := observer.New(zap.WarnLevel)
+			u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.New(core))
+
+			ctx := context.Background()
+			task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusRunning, ExternalID: "ext-1"}
+
+			mockRepo.On("GetRunningTasks", ctx).Return([]*domain.AnalysisTask{task}, nil)
+			mockExecutor.On("GetBotStatus", ctx, "ext-1").Return(domain.BotStatus{Status: reported}, nil)
+
+			assert.NoError(t, u.CheckRunningTasks(ctx))
+			assert.Equal(t, domain.TaskStatusRunning, task.Status)
+			mockRepo.AssertNotCalled(t, "SaveTransition", mock.Anything, mock.Anything, mock.Anything)
+			assert.Zero(t, logs.Len(), "polling a running bot is not worth a warning")
+		})
+	}
+}
+
+func TestRetryFailedTasks_ResetsToPending(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com/retry", Status: domain.TaskStatusFailed}
+
+	mockRepo.On("GetFailedTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{task}, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.RetryFailedTasks(ctx))
+	assert.Equal(t, 1, task.RetryCount)
+	assert.Equal(t, domain.TaskStatusPending, task.Status)
+	assert.Nil(t, task.NextAttemptAt)
+
+	// Launching is left to the dispatcher
+	mockExecutor.AssertNotCalled(t, "RunBot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDispatchPendingTasks_FakeExecutor(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(
+		fake.Outcome{Kind: fake.OutcomeSucceed},
+		[]fake.Rule{{Pattern: regexp.MustCompile(`broken`), Outcome: fake.Outcome{Kind: fake.OutcomeRunError, Message: "no capacity"}}},
+		nil,
+	)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop(),
+		usecase.WithDispatchConfig(usecase.DispatchConfig{BatchSize: 5, MaxConcurrent: 20, MaxPerUser: 2}),
+	)
+
+	ctx := context.Background()
+	ok := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com/ok", Status: domain.TaskStatusDispatching}
+	broken := &domain.AnalysisTask{ID: uuid.New(), URL: "http://broken.example.com", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, domain.ClaimOptions{Limit: 5, MaxInFlight: 20, MaxPerUser: 2}).
+		Return([]*domain.AnalysisTask{ok, broken}, nil)
+	mockRepo.On("SaveTransition", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
+
+	assert.Equal(t, domain.TaskStatusRunning, ok.Status)
+	assert.Equal(t, "fake-1", ok.ExternalID)
+	assert.Equal(t, domain.TaskStatusFailed, broken.Status)
+	assert.Equal(t, "no capacity", broken.Result)
+	assert.NotNil(t, broken.NextAttemptAt, "transient failures are scheduled for retry")
+}
+
+func TestDispatchPendingTasks_PassesBotEnv(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeSucceed}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop(),
+		usecase.WithBotEnv(func(task *domain.AnalysisTask) map[string]string {
+			return map[string]string{"WEBHOOK_SECRET": "secret-for-" + task.ID.String()}
+		}),
+	)
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{task}, nil)
+	mockRepo.On("SaveTransition", mock.Anything, task, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
 
-	// Wait a bit for goroutine
-	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "secret-for-"+task.ID.String(), executor.Env(task.ExternalID)["WEBHOOK_SECRET"])
+}
+
+func TestDispatchPendingTasks_PermanentErrorDeadLetters(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeRunError, Message: "invalid task definition", Permanent: true}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{task}, nil)
+	mockRepo.On("SaveTransition", mock.Anything, task, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
+
+	assert.Equal(t, domain.TaskStatusDeadLetter, task.Status)
+	assert.Equal(t, 0, task.RetryCount)
+	assert.Nil(t, task.NextAttemptAt)
+}
+
+func TestCheckRunningTasks_ClassifiesExitCode(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(
+		fake.Outcome{Kind: fake.OutcomeFail, ExitCode: 137},
+		[]fake.Rule{{Pattern: regexp.MustCompile(`nxdomain`), Outcome: fake.Outcome{Kind: fake.OutcomeFail, ExitCode: 3}}},
+		nil,
+	)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop(),
+		usecase.WithRetryPolicy(domain.RetryPolicy{BaseDelay: time.Minute, Multiplier: 2, PermanentExitCodes: []int{2, 3}}),
+	)
+
+	ctx := context.Background()
+	oom := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusRunning, RetryCount: 1}
+	nxdomain := &domain.AnalysisTask{ID: uuid.New(), URL: "http://nxdomain.example", Status: domain.TaskStatusRunning}
+	oom.ExternalID, _ = executor.RunBot(ctx, oom, nil)
+	nxdomain.ExternalID, _ = executor.RunBot(ctx, nxdomain, nil)
+
+	mockRepo.On("GetRunningTasks", ctx).Return([]*domain.AnalysisTask{oom, nxdomain}, nil)
+	mockRepo.On("SaveTransition", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	before := time.Now()
+	assert.NoError(t, u.CheckRunningTasks(ctx))
+
+	assert.Equal(t, domain.TaskStatusFailed, oom.Status)
+	if assert.NotNil(t, oom.NextAttemptAt) {
+		assert.WithinDuration(t, before.Add(2*time.Minute), *oom.NextAttemptAt, time.Second)
+	}
+	assert.Equal(t, domain.TaskStatusDeadLetter, nxdomain.Status)
+	assert.Nil(t, nxdomain.NextAttemptAt)
+}
+
+func TestDispatchPendingTasks_ReleasesOnShutdown(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{task}, nil)
+	mockExecutor.On("RunBot", mock.Anything, task, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return("", context.Canceled)
+	mockRepo.On("SaveTransition", mock.Anything, task, mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
+	assert.Equal(t, domain.TaskStatusPending, task.Status)
+}
+
+func TestDispatchPendingTasks_LaunchTimeout(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop(),
+		usecase.WithDispatchConfig(usecase.DispatchConfig{LaunchTimeout: 20 * time.Millisecond}),
+	)
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching}
+
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{task}, nil)
+	// A launch that hangs until its context gives up
+	mockExecutor.On("RunBot", mock.Anything, task, mock.Anything).Return(func(ctx context.Context, _ *domain.AnalysisTask, _ map[string]string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	mockRepo.On("SaveTransition", mock.Anything, task, mock.Anything).Return(nil)
+
+	done := make(chan error, 1)
+	go func() { done <- u.DispatchPendingTasks(ctx) }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dispatch cycle blocked on a hung launch")
+	}
+	assert.Equal(t, domain.TaskStatusFailed, task.Status)
+	assert.NotNil(t, task.NextAttemptAt, "a timed out launch is retried")
+}
+
+func TestValidateDeadlines(t *testing.T) {
+	assert.NoError(t, usecase.ValidateDeadlines(usecase.DispatchConfig{}, usecase.ReaperConfig{}))
+	assert.NoError(t, usecase.ValidateDeadlines(usecase.DispatchConfig{LaunchTimeout: 30 * time.Second}, usecase.ReaperConfig{DispatchDeadline: time.Minute}))
+	assert.Error(t, usecase.ValidateDeadlines(usecase.DispatchConfig{LaunchTimeout: 2 * time.Minute}, usecase.ReaperConfig{DispatchDeadline: time.Minute}))
+	assert.Error(t, usecase.ValidateDeadlines(usecase.DispatchConfig{}, usecase.ReaperConfig{DispatchDeadline: time.Minute}),
+		"the default launch timeout is checked against a shortened deadline")
+}
+
+func TestUpdateTaskStatus_RejectsLateWebhook(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusCompleted, Result: "safe"}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+
+	err := u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{Status: domain.TaskStatusRunning}, domain.ActorWebhook)
+
+	var invalid *domain.InvalidTransitionError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	mockRepo.AssertNotCalled(t, "SaveTransition", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskStatus_RejectsStatusesBotsCannotReport(t *testing.T) {
+	for _, status := range []domain.TaskStatus{
+		domain.TaskStatusPending, domain.TaskStatusDispatching, domain.TaskStatusCancelled,
+		domain.TaskStatusTimedOut, domain.TaskStatusDeadLetter, "BOGUS",
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			mockRepo := new(mocks.MockTaskRepository)
+			mockExecutor := new(mocks.MockBotExecutor)
+
+			u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+			err := u.UpdateTaskStatus(context.Background(), uuid.New(), usecase.StatusUpdate{Status: status}, domain.ActorWebhook)
+
+			assert.ErrorIs(t, err, domain.ErrUnreportableStatus)
+			mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateTaskStatus_AttachesResultAfterPoller(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusCompleted}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.MatchedBy(func(e *domain.TaskEvent) bool {
+		return e.FromStatus == domain.TaskStatusCompleted && e.ToStatus == domain.TaskStatusCompleted
+	})).Return(nil)
+
+	assert.NoError(t, u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{Status: domain.TaskStatusCompleted, Result: "phishing"}, domain.ActorWebhook))
+	assert.Equal(t, "phishing", task.Result)
+}
+
+func TestUpdateTaskStatus_StoresAnalysisResult(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusRunning}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.Anything).Return(nil)
+
+	err := u.UpdateTaskStatus(ctx, task.ID, usecase.StatusUpdate{
+		Status: domain.TaskStatusCompleted,
+		AnalysisResult: &domain.AnalysisResult{
+			Verdict:    domain.VerdictPhishing,
+			Confidence: 0.93,
+			FinalURL:   "https://Login.Example-Bank.com/verify",
+		},
+	}, domain.ActorWebhook)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusCompleted, task.Status)
+	if assert.NotNil(t, task.AnalysisResult) {
+		assert.Equal(t, domain.AnalysisResultSchemaVersion, task.AnalysisResult.SchemaVersion)
+		assert.Equal(t, "login.example-bank.com", task.AnalysisResult.FinalDomain)
+	}
+}
+
+func TestUpdateTaskStatus_RejectsInvalidAnalysisResult(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	err := u.UpdateTaskStatus(context.Background(), uuid.New(), usecase.StatusUpdate{
+		Status:         domain.TaskStatusCompleted,
+		AnalysisResult: &domain.AnalysisResult{Verdict: "very bad", Confidence: 0.5},
+	}, domain.ActorWebhook)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidResult)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestCancelTask_StopsBot(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeHang}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "user-1", URL: "http://example.com", Status: domain.TaskStatusRunning}
+	extID, _ := executor.RunBot(ctx, task, nil)
+	task.ExternalID = extID
+
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+	mockRepo.On("SaveTransition", ctx, task, mock.MatchedBy(func(e *domain.TaskEvent) bool {
+		return e.ToStatus == domain.TaskStatusCancelled && e.Actor == domain.ActorAPI && e.Reason == "wrong url"
+	})).Return(nil)
+	mockRepo.On("HandOverSharedTask", ctx, task).Return((*domain.AnalysisTask)(nil), nil)
+
+	result, err := u.CancelTask(ctx, task.ID, "user-1", "wrong url")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusCancelled, result.Status)
+	assert.True(t, executor.Stopped(extID))
+}
+
+func TestCancelTask_HandsOverSharedTask(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeHang}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop())
+
+	ctx := context.Background()
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", URL: "http://example.com", Status: domain.TaskStatusRunning}
+	extID, _ := executor.RunBot(ctx, leader, nil)
+	leader.ExternalID = extID
+	follower := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "bob", URL: leader.URL, Status: domain.TaskStatusRunning, SharedTaskID: &leader.ID}
+
+	mockRepo.On("GetByID", ctx, leader.ID).Return(leader, nil)
+	mockRepo.On("SaveTransition", ctx, leader, mock.Anything).Return(nil)
+	mockRepo.On("HandOverSharedTask", ctx, leader).Return(follower, nil)
+
+	result, err := u.CancelTask(ctx, leader.ID, "alice", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusCancelled, result.Status)
+	assert.False(t, executor.Stopped(extID), "bob is still waiting on the analysis")
+}
+
+func TestCancelTask_HandsOverSharedTaskDuringDispatch(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	leader := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "alice", URL: "http://example.com", Status: domain.TaskStatusDispatching, DispatchAttempts: 1}
+	// The repository sends the successor back to PENDING because no bot was launched yet
+	successor := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "bob", URL: leader.URL, Status: domain.TaskStatusPending}
+
+	mockRepo.On("GetByID", ctx, leader.ID).Return(leader, nil)
+	mockRepo.On("SaveTransition", ctx, leader, mock.MatchedBy(func(e *domain.TaskEvent) bool {
+		return e.FromStatus == domain.TaskStatusDispatching && e.ToStatus == domain.TaskStatusCancelled
+	})).Return(nil)
+	mockRepo.On("HandOverSharedTask", ctx, mock.MatchedBy(func(t *domain.AnalysisTask) bool {
+		return t.ID == leader.ID && t.Status == domain.TaskStatusCancelled && t.ExternalID == ""
+	})).Return(successor, nil)
+
+	result, err := u.CancelTask(ctx, leader.ID, "alice", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusCancelled, result.Status)
+	mockRepo.AssertCalled(t, "HandOverSharedTask", ctx, leader)
+	// The dispatcher stops the leader's bot once RunBot returns
+	mockExecutor.AssertNotCalled(t, "StopBot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelTask_RefusesTerminal(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+	ctx := context.Background()
+	task := &domain.AnalysisTask{ID: uuid.New(), OwnerUID: "user-1", Status: domain.TaskStatusCompleted, ExternalID: "arn"}
+	mockRepo.On("GetByID", ctx, task.ID).Return(task, nil)
+
+	_, err := u.CancelTask(ctx, task.ID, "user-1", "")
+
+	var invalid *domain.InvalidTransitionError
+	assert.ErrorAs(t, err, &invalid)
+	mockExecutor.AssertNotCalled(t, "StopBot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReapOverdueTasks(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	executor := fake.NewFakeExecutor(fake.Outcome{Kind: fake.OutcomeHang}, nil, nil)
+
+	u := usecase.NewTaskUsecase(mockRepo, executor, zap.NewNop(),
+		usecase.WithReaperConfig(usecase.ReaperConfig{DispatchDeadline: time.Minute, MaxRuntime: 10 * time.Minute}),
+	)
+
+	ctx := context.Background()
+	longAgo := time.Now().Add(-time.Hour)
+
+	hung := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com/hang", Status: domain.TaskStatusRunning, StartedAt: &longAgo}
+	hung.ExternalID, _ = executor.RunBot(ctx, hung, nil)
+	stuckDispatch := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusDispatching, LastDispatchedAt: &longAgo}
+	orphan := &domain.AnalysisTask{ID: uuid.New(), Status: domain.TaskStatusRunning}
+
+	mockRepo.On("GetOverdueTasks", ctx, mock.Anything, mock.Anything).
+		Return([]*domain.AnalysisTask{hung, stuckDispatch, orphan}, nil)
+	mockRepo.On("SaveTransition", ctx, mock.Anything, mock.MatchedBy(func(e *domain.TaskEvent) bool {
+		return e.Actor == domain.ActorReaper
+	})).Return(nil)
+
+	assert.NoError(t, u.ReapOverdueTasks(ctx))
+
+	assert.Equal(t, domain.TaskStatusTimedOut, hung.Status)
+	assert.True(t, executor.Stopped(hung.ExternalID))
+	assert.Equal(t, domain.TaskStatusFailed, stuckDispatch.Status)
+	assert.Equal(t, domain.TaskStatusFailed, orphan.Status)
+}
+
+func TestDispatchPendingTasks_ReapedDuringRunBot(t *testing.T) {
+	mockRepo := new(mocks.MockTaskRepository)
+	mockExecutor := new(mocks.MockBotExecutor)
+
+	u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop(),
+		usecase.WithReaperConfig(usecase.ReaperConfig{DispatchDeadline: time.Minute, MaxRuntime: 10 * time.Minute}),
+	)
+
+	ctx := context.Background()
+	longAgo := time.Now().Add(-time.Hour)
+	claimed := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching, DispatchAttempts: 1, LastDispatchedAt: &longAgo}
+	stored := *claimed // The row the reaper reads while RunBot is still in flight
+
+	var reaperEvent *domain.TaskEvent
+	mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{claimed}, nil)
+	mockRepo.On("GetOverdueTasks", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.AnalysisTask{&stored}, nil)
+	mockRepo.On("SaveTransition", mock.Anything, &stored, mock.MatchedBy(func(e *domain.TaskEvent) bool {
+		return e.Actor == domain.ActorReaper
+	})).Run(func(args mock.Arguments) { reaperEvent = args.Get(2).(*domain.TaskEvent) }).Return(nil)
+	mockExecutor.On("RunBot", mock.Anything, claimed, mock.Anything).Run(func(mock.Arguments) {
+		assert.NoError(t, u.ReapOverdueTasks(ctx))
+	}).Return("bot-1", nil)
+	mockRepo.On("SaveTransition", mock.Anything, claimed, mock.Anything).Return(domain.ErrTransitionConflict)
+	mockRepo.On("GetByID", mock.Anything, claimed.ID).Return(&stored, nil)
+	mockRepo.On("GetLastEvent", mock.Anything, claimed.ID).Return(func(context.Context, uuid.UUID) (*domain.TaskEvent, error) {
+		return reaperEvent, nil
+	})
+	mockExecutor.On("StopBot", mock.Anything, "bot-1", mock.Anything).Return(nil)
+
+	assert.NoError(t, u.DispatchPendingTasks(ctx))
+
+	assert.Equal(t, domain.TaskStatusFailed, stored.Status)
+	mockExecutor.AssertCalled(t, "StopBot", mock.Anything, "bot-1", mock.Anything)
+	assert.Empty(t, stored.ExternalID, "the reaped task must not point at the stopped bot")
+	mockRepo.AssertNumberOfCalls(t, "SaveTransition", 2)
+}
+
+func TestDispatchPendingTasks_LostLaunch(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     domain.AnalysisTask
+		lastActor  domain.TaskActor
+		wantStop   bool
+		wantRecord bool
+	}{
+		{"webhook reported start", domain.AnalysisTask{Status: domain.TaskStatusRunning, DispatchAttempts: 1}, domain.ActorWebhook, false, true},
+		{"webhook finished", domain.AnalysisTask{Status: domain.TaskStatusCompleted, DispatchAttempts: 1}, domain.ActorWebhook, true, true},
+		{"cancelled", domain.AnalysisTask{Status: domain.TaskStatusCancelled, DispatchAttempts: 1}, domain.ActorAPI, true, false},
+		{"retried", domain.AnalysisTask{Status: domain.TaskStatusPending, DispatchAttempts: 1, RetryCount: 1}, domain.ActorRetryWorker, true, false},
+		{"redispatched", domain.AnalysisTask{Status: domain.TaskStatusRunning, DispatchAttempts: 2, RetryCount: 1, ExternalID: "bot-2"}, domain.ActorDispatcher, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTaskRepository)
+			mockExecutor := new(mocks.MockBotExecutor)
+			u := usecase.NewTaskUsecase(mockRepo, mockExecutor, zap.NewNop())
+
+			ctx := context.Background()
+			claimed := &domain.AnalysisTask{ID: uuid.New(), URL: "http://example.com", Status: domain.TaskStatusDispatching, DispatchAttempts: 1}
+			stored := tt.stored
+			stored.ID = claimed.ID
+
+			mockRepo.On("ClaimPendingTasks", ctx, mock.Anything).Return([]*domain.AnalysisTask{claimed}, nil)
+			mockExecutor.On("RunBot", mock.Anything, claimed, mock.Anything).Return("bot-1", nil)
+			mockRepo.On("SaveTransition", mock.Anything, claimed, mock.Anything).Return(domain.ErrTransitionConflict)
+			mockRepo.On("GetByID", mock.Anything, claimed.ID).Return(&stored, nil)
+			mockRepo.On("GetLastEvent", mock.Anything, claimed.ID).Return(&domain.TaskEvent{Actor: tt.lastActor}, nil)
+			mockRepo.On("SaveTransition", mock.Anything, &stored, mock.Anything).Return(nil)
+			mockExecutor.On("StopBot", mock.Anything, "bot-1", mock.Anything).Return(nil)
+
+			assert.NoError(t, u.DispatchPendingTasks(ctx))
+
+			if tt.wantStop {
+				mockExecutor.AssertCalled(t, "StopBot", mock.Anything, "bot-1", mock.Anything)
+			} else {
+				mockExecutor.AssertNotCalled(t, "StopBot", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantRecord {
+				assert.Equal(t, "bot-1", stored.ExternalID)
+				mockRepo.AssertCalled(t, "SaveTransition", mock.Anything, &stored, mock.Anything)
+			} else {
+				assert.NotEqual(t, "bot-1", stored.ExternalID)
+				mockRepo.AssertNotCalled(t, "SaveTransition", mock.Anything, &stored, mock.Anything)
+			}
+		})
+	}
 }
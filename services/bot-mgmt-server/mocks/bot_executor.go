@@ -23,22 +23,22 @@ func (_m *MockBotExecutor) EXPECT() *MockBotExecutor_Expecter {
 }
 
 // GetBotStatus provides a mock function with given fields: ctx, externalID
-func (_m *MockBotExecutor) GetBotStatus(ctx context.Context, externalID string) (domain.TaskStatus, error) {
+func (_m *MockBotExecutor) GetBotStatus(ctx context.Context, externalID string) (domain.BotStatus, error) {
 	ret := _m.Called(ctx, externalID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBotStatus")
 	}
 
-	var r0 domain.TaskStatus
+	var r0 domain.BotStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.TaskStatus, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.BotStatus, error)); ok {
 		return rf(ctx, externalID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TaskStatus); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.BotStatus); ok {
 		r0 = rf(ctx, externalID)
 	} else {
-		r0 = ret.Get(0).(domain.TaskStatus)
+		r0 = ret.Get(0).(domain.BotStatus)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -69,19 +69,66 @@ func (_c *MockBotExecutor_GetBotStatus_Call) Run(run func(ctx context.Context, e
 	return _c
 }
 
-func (_c *MockBotExecutor_GetBotStatus_Call) Return(_a0 domain.TaskStatus, _a1 error) *MockBotExecutor_GetBotStatus_Call {
+func (_c *MockBotExecutor_GetBotStatus_Call) Return(_a0 domain.BotStatus, _a1 error) *MockBotExecutor_GetBotStatus_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockBotExecutor_GetBotStatus_Call) RunAndReturn(run func(context.Context, string) (domain.TaskStatus, error)) *MockBotExecutor_GetBotStatus_Call {
+func (_c *MockBotExecutor_GetBotStatus_Call) RunAndReturn(run func(context.Context, string) (domain.BotStatus, error)) *MockBotExecutor_GetBotStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RunBot provides a mock function with given fields: ctx, task
-func (_m *MockBotExecutor) RunBot(ctx context.Context, task *domain.AnalysisTask) (string, error) {
-	ret := _m.Called(ctx, task)
+// ReleaseBot provides a mock function with given fields: ctx, externalID
+func (_m *MockBotExecutor) ReleaseBot(ctx context.Context, externalID string) error {
+	ret := _m.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseBot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, externalID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBotExecutor_ReleaseBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseBot'
+type MockBotExecutor_ReleaseBot_Call struct {
+	*mock.Call
+}
+
+// ReleaseBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockBotExecutor_Expecter) ReleaseBot(ctx interface{}, externalID interface{}) *MockBotExecutor_ReleaseBot_Call {
+	return &MockBotExecutor_ReleaseBot_Call{Call: _e.mock.On("ReleaseBot", ctx, externalID)}
+}
+
+func (_c *MockBotExecutor_ReleaseBot_Call) Run(run func(ctx context.Context, externalID string)) *MockBotExecutor_ReleaseBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBotExecutor_ReleaseBot_Call) Return(_a0 error) *MockBotExecutor_ReleaseBot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBotExecutor_ReleaseBot_Call) RunAndReturn(run func(context.Context, string) error) *MockBotExecutor_ReleaseBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunBot provides a mock function with given fields: ctx, task, env
+func (_m *MockBotExecutor) RunBot(ctx context.Context, task *domain.AnalysisTask, env map[string]string) (string, error) {
+	ret := _m.Called(ctx, task, env)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RunBot")
@@ -89,17 +136,17 @@ func (_m *MockBotExecutor) RunBot(ctx context.Context, task *domain.AnalysisTask
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask) (string, error)); ok {
-		return rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask, map[string]string) (string, error)); ok {
+		return rf(ctx, task, env)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask) string); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask, map[string]string) string); ok {
+		r0 = rf(ctx, task, env)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.AnalysisTask) error); ok {
-		r1 = rf(ctx, task)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AnalysisTask, map[string]string) error); ok {
+		r1 = rf(ctx, task, env)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,13 +162,14 @@ type MockBotExecutor_RunBot_Call struct {
 // RunBot is a helper method to define mock.On call
 //   - ctx context.Context
 //   - task *domain.AnalysisTask
-func (_e *MockBotExecutor_Expecter) RunBot(ctx interface{}, task interface{}) *MockBotExecutor_RunBot_Call {
-	return &MockBotExecutor_RunBot_Call{Call: _e.mock.On("RunBot", ctx, task)}
+//   - env map[string]string
+func (_e *MockBotExecutor_Expecter) RunBot(ctx interface{}, task interface{}, env interface{}) *MockBotExecutor_RunBot_Call {
+	return &MockBotExecutor_RunBot_Call{Call: _e.mock.On("RunBot", ctx, task, env)}
 }
 
-func (_c *MockBotExecutor_RunBot_Call) Run(run func(ctx context.Context, task *domain.AnalysisTask)) *MockBotExecutor_RunBot_Call {
+func (_c *MockBotExecutor_RunBot_Call) Run(run func(ctx context.Context, task *domain.AnalysisTask, env map[string]string)) *MockBotExecutor_RunBot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.AnalysisTask))
+		run(args[0].(context.Context), args[1].(*domain.AnalysisTask), args[2].(map[string]string))
 	})
 	return _c
 }
@@ -131,7 +179,55 @@ func (_c *MockBotExecutor_RunBot_Call) Return(_a0 string, _a1 error) *MockBotExe
 	return _c
 }
 
-func (_c *MockBotExecutor_RunBot_Call) RunAndReturn(run func(context.Context, *domain.AnalysisTask) (string, error)) *MockBotExecutor_RunBot_Call {
+func (_c *MockBotExecutor_RunBot_Call) RunAndReturn(run func(context.Context, *domain.AnalysisTask, map[string]string) (string, error)) *MockBotExecutor_RunBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopBot provides a mock function with given fields: ctx, externalID, reason
+func (_m *MockBotExecutor) StopBot(ctx context.Context, externalID string, reason string) error {
+	ret := _m.Called(ctx, externalID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopBot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, externalID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBotExecutor_StopBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopBot'
+type MockBotExecutor_StopBot_Call struct {
+	*mock.Call
+}
+
+// StopBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+//   - reason string
+func (_e *MockBotExecutor_Expecter) StopBot(ctx interface{}, externalID interface{}, reason interface{}) *MockBotExecutor_StopBot_Call {
+	return &MockBotExecutor_StopBot_Call{Call: _e.mock.On("StopBot", ctx, externalID, reason)}
+}
+
+func (_c *MockBotExecutor_StopBot_Call) Run(run func(ctx context.Context, externalID string, reason string)) *MockBotExecutor_StopBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBotExecutor_StopBot_Call) Return(_a0 error) *MockBotExecutor_StopBot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBotExecutor_StopBot_Call) RunAndReturn(run func(context.Context, string, string) error) *MockBotExecutor_StopBot_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,253 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCallbackRepository is an autogenerated mock type for the CallbackRepository type
+type MockCallbackRepository struct {
+	mock.Mock
+}
+
+type MockCallbackRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCallbackRepository) EXPECT() *MockCallbackRepository_Expecter {
+	return &MockCallbackRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDueDeliveries provides a mock function with given fields: ctx, now, limit, lease
+func (_m *MockCallbackRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.CallbackDelivery, error) {
+	ret := _m.Called(ctx, now, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDeliveries")
+	}
+
+	var r0 []*domain.CallbackDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) ([]*domain.CallbackDelivery, error)); ok {
+		return rf(ctx, now, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) []*domain.CallbackDelivery); ok {
+		r0 = rf(ctx, now, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CallbackDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, time.Duration) error); ok {
+		r1 = rf(ctx, now, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCallbackRepository_ClaimDueDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueDeliveries'
+type MockCallbackRepository_ClaimDueDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDueDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+//   - lease time.Duration
+func (_e *MockCallbackRepository_Expecter) ClaimDueDeliveries(ctx interface{}, now interface{}, limit interface{}, lease interface{}) *MockCallbackRepository_ClaimDueDeliveries_Call {
+	return &MockCallbackRepository_ClaimDueDeliveries_Call{Call: _e.mock.On("ClaimDueDeliveries", ctx, now, limit, lease)}
+}
+
+func (_c *MockCallbackRepository_ClaimDueDeliveries_Call) Run(run func(ctx context.Context, now time.Time, limit int, lease time.Duration)) *MockCallbackRepository_ClaimDueDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCallbackRepository_ClaimDueDeliveries_Call) Return(_a0 []*domain.CallbackDelivery, _a1 error) *MockCallbackRepository_ClaimDueDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCallbackRepository_ClaimDueDeliveries_Call) RunAndReturn(run func(context.Context, time.Time, int, time.Duration) ([]*domain.CallbackDelivery, error)) *MockCallbackRepository_ClaimDueDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, d
+func (_m *MockCallbackRepository) Create(ctx context.Context, d *domain.CallbackDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CallbackDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCallbackRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCallbackRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.CallbackDelivery
+func (_e *MockCallbackRepository_Expecter) Create(ctx interface{}, d interface{}) *MockCallbackRepository_Create_Call {
+	return &MockCallbackRepository_Create_Call{Call: _e.mock.On("Create", ctx, d)}
+}
+
+func (_c *MockCallbackRepository_Create_Call) Run(run func(ctx context.Context, d *domain.CallbackDelivery)) *MockCallbackRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.CallbackDelivery))
+	})
+	return _c
+}
+
+func (_c *MockCallbackRepository_Create_Call) Return(_a0 error) *MockCallbackRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCallbackRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.CallbackDelivery) error) *MockCallbackRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByTask provides a mock function with given fields: ctx, taskID
+func (_m *MockCallbackRepository) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.CallbackDelivery, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTask")
+	}
+
+	var r0 []*domain.CallbackDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.CallbackDelivery, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.CallbackDelivery); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CallbackDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCallbackRepository_ListByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByTask'
+type MockCallbackRepository_ListByTask_Call struct {
+	*mock.Call
+}
+
+// ListByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+func (_e *MockCallbackRepository_Expecter) ListByTask(ctx interface{}, taskID interface{}) *MockCallbackRepository_ListByTask_Call {
+	return &MockCallbackRepository_ListByTask_Call{Call: _e.mock.On("ListByTask", ctx, taskID)}
+}
+
+func (_c *MockCallbackRepository_ListByTask_Call) Run(run func(ctx context.Context, taskID uuid.UUID)) *MockCallbackRepository_ListByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCallbackRepository_ListByTask_Call) Return(_a0 []*domain.CallbackDelivery, _a1 error) *MockCallbackRepository_ListByTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCallbackRepository_ListByTask_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*domain.CallbackDelivery, error)) *MockCallbackRepository_ListByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, d
+func (_m *MockCallbackRepository) Update(ctx context.Context, d *domain.CallbackDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CallbackDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCallbackRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockCallbackRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.CallbackDelivery
+func (_e *MockCallbackRepository_Expecter) Update(ctx interface{}, d interface{}) *MockCallbackRepository_Update_Call {
+	return &MockCallbackRepository_Update_Call{Call: _e.mock.On("Update", ctx, d)}
+}
+
+func (_c *MockCallbackRepository_Update_Call) Run(run func(ctx context.Context, d *domain.CallbackDelivery)) *MockCallbackRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.CallbackDelivery))
+	})
+	return _c
+}
+
+func (_c *MockCallbackRepository_Update_Call) Return(_a0 error) *MockCallbackRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCallbackRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.CallbackDelivery) error) *MockCallbackRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCallbackRepository creates a new instance of MockCallbackRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCallbackRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCallbackRepository {
+	mock := &MockCallbackRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCallbackSender is an autogenerated mock type for the CallbackSender type
+type MockCallbackSender struct {
+	mock.Mock
+}
+
+type MockCallbackSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCallbackSender) EXPECT() *MockCallbackSender_Expecter {
+	return &MockCallbackSender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, url, deliveryID, body
+func (_m *MockCallbackSender) Send(ctx context.Context, url string, deliveryID uuid.UUID, body []byte) (int, error) {
+	ret := _m.Called(ctx, url, deliveryID, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, []byte) (int, error)); ok {
+		return rf(ctx, url, deliveryID, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, []byte) int); ok {
+		r0 = rf(ctx, url, deliveryID, body)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, []byte) error); ok {
+		r1 = rf(ctx, url, deliveryID, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCallbackSender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockCallbackSender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - deliveryID uuid.UUID
+//   - body []byte
+func (_e *MockCallbackSender_Expecter) Send(ctx interface{}, url interface{}, deliveryID interface{}, body interface{}) *MockCallbackSender_Send_Call {
+	return &MockCallbackSender_Send_Call{Call: _e.mock.On("Send", ctx, url, deliveryID, body)}
+}
+
+func (_c *MockCallbackSender_Send_Call) Run(run func(ctx context.Context, url string, deliveryID uuid.UUID, body []byte)) *MockCallbackSender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockCallbackSender_Send_Call) Return(_a0 int, _a1 error) *MockCallbackSender_Send_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCallbackSender_Send_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, []byte) (int, error)) *MockCallbackSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCallbackSender creates a new instance of MockCallbackSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCallbackSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCallbackSender {
+	mock := &MockCallbackSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNonceRepository is an autogenerated mock type for the NonceRepository type
+type MockNonceRepository struct {
+	mock.Mock
+}
+
+type MockNonceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNonceRepository) EXPECT() *MockNonceRepository_Expecter {
+	return &MockNonceRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *MockNonceRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNonceRepository_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockNonceRepository_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockNonceRepository_Expecter) DeleteExpired(ctx interface{}, now interface{}) *MockNonceRepository_DeleteExpired_Call {
+	return &MockNonceRepository_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx, now)}
+}
+
+func (_c *MockNonceRepository_DeleteExpired_Call) Run(run func(ctx context.Context, now time.Time)) *MockNonceRepository_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockNonceRepository_DeleteExpired_Call) Return(_a0 error) *MockNonceRepository_DeleteExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNonceRepository_DeleteExpired_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockNonceRepository_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: ctx, keyID, nonce, now, expiresAt
+func (_m *MockNonceRepository) Remember(ctx context.Context, keyID string, nonce string, now time.Time, expiresAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, keyID, nonce, now, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, keyID, nonce, now, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, keyID, nonce, now, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, keyID, nonce, now, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNonceRepository_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockNonceRepository_Remember_Call struct {
+	*mock.Call
+}
+
+// Remember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyID string
+//   - nonce string
+//   - now time.Time
+//   - expiresAt time.Time
+func (_e *MockNonceRepository_Expecter) Remember(ctx interface{}, keyID interface{}, nonce interface{}, now interface{}, expiresAt interface{}) *MockNonceRepository_Remember_Call {
+	return &MockNonceRepository_Remember_Call{Call: _e.mock.On("Remember", ctx, keyID, nonce, now, expiresAt)}
+}
+
+func (_c *MockNonceRepository_Remember_Call) Run(run func(ctx context.Context, keyID string, nonce string, now time.Time, expiresAt time.Time)) *MockNonceRepository_Remember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockNonceRepository_Remember_Call) Return(_a0 bool, _a1 error) *MockNonceRepository_Remember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNonceRepository_Remember_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time) (bool, error)) *MockNonceRepository_Remember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNonceRepository creates a new instance of MockNonceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNonceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNonceRepository {
+	mock := &MockNonceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
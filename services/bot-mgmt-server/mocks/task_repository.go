@@ -4,11 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/SKD-fastcampus/bot-management/services/bot-mgmt-server/internal/domain"
-	mock "github.com/stretchr/testify/mock"
-
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // MockTaskRepository is an autogenerated mock type for the TaskRepository type
@@ -24,6 +24,124 @@ func (_m *MockTaskRepository) EXPECT() *MockTaskRepository_Expecter {
 	return &MockTaskRepository_Expecter{mock: &_m.Mock}
 }
 
+// ClaimPendingTasks provides a mock function with given fields: ctx, opts
+func (_m *MockTaskRepository) ClaimPendingTasks(ctx context.Context, opts domain.ClaimOptions) ([]*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPendingTasks")
+	}
+
+	var r0 []*domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ClaimOptions) ([]*domain.AnalysisTask, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ClaimOptions) []*domain.AnalysisTask); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ClaimOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_ClaimPendingTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPendingTasks'
+type MockTaskRepository_ClaimPendingTasks_Call struct {
+	*mock.Call
+}
+
+// ClaimPendingTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts domain.ClaimOptions
+func (_e *MockTaskRepository_Expecter) ClaimPendingTasks(ctx interface{}, opts interface{}) *MockTaskRepository_ClaimPendingTasks_Call {
+	return &MockTaskRepository_ClaimPendingTasks_Call{Call: _e.mock.On("ClaimPendingTasks", ctx, opts)}
+}
+
+func (_c *MockTaskRepository_ClaimPendingTasks_Call) Run(run func(ctx context.Context, opts domain.ClaimOptions)) *MockTaskRepository_ClaimPendingTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ClaimOptions))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_ClaimPendingTasks_Call) Return(_a0 []*domain.AnalysisTask, _a1 error) *MockTaskRepository_ClaimPendingTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_ClaimPendingTasks_Call) RunAndReturn(run func(context.Context, domain.ClaimOptions) ([]*domain.AnalysisTask, error)) *MockTaskRepository_ClaimPendingTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByStatus provides a mock function with given fields: ctx, statuses
+func (_m *MockTaskRepository) CountByStatus(ctx context.Context, statuses []domain.TaskStatus) (map[domain.TaskStatus]int, error) {
+	ret := _m.Called(ctx, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[domain.TaskStatus]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TaskStatus) (map[domain.TaskStatus]int, error)); ok {
+		return rf(ctx, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TaskStatus) map[domain.TaskStatus]int); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.TaskStatus]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.TaskStatus) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_CountByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByStatus'
+type MockTaskRepository_CountByStatus_Call struct {
+	*mock.Call
+}
+
+// CountByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []domain.TaskStatus
+func (_e *MockTaskRepository_Expecter) CountByStatus(ctx interface{}, statuses interface{}) *MockTaskRepository_CountByStatus_Call {
+	return &MockTaskRepository_CountByStatus_Call{Call: _e.mock.On("CountByStatus", ctx, statuses)}
+}
+
+func (_c *MockTaskRepository_CountByStatus_Call) Run(run func(ctx context.Context, statuses []domain.TaskStatus)) *MockTaskRepository_CountByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.TaskStatus))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_CountByStatus_Call) Return(_a0 map[domain.TaskStatus]int, _a1 error) *MockTaskRepository_CountByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_CountByStatus_Call) RunAndReturn(run func(context.Context, []domain.TaskStatus) (map[domain.TaskStatus]int, error)) *MockTaskRepository_CountByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, task
 func (_m *MockTaskRepository) Create(ctx context.Context, task *domain.AnalysisTask) error {
 	ret := _m.Called(ctx, task)
@@ -71,9 +189,116 @@ func (_c *MockTaskRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetActiveTaskByURL provides a mock function with given fields: ctx, url
-func (_m *MockTaskRepository) GetActiveTaskByURL(ctx context.Context, url string) (*domain.AnalysisTask, error) {
-	ret := _m.Called(ctx, url)
+// CreateBatch provides a mock function with given fields: ctx, batch, taskIDs
+func (_m *MockTaskRepository) CreateBatch(ctx context.Context, batch *domain.TaskBatch, taskIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, batch, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskBatch, []uuid.UUID) error); ok {
+		r0 = rf(ctx, batch, taskIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaskRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockTaskRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch *domain.TaskBatch
+//   - taskIDs []uuid.UUID
+func (_e *MockTaskRepository_Expecter) CreateBatch(ctx interface{}, batch interface{}, taskIDs interface{}) *MockTaskRepository_CreateBatch_Call {
+	return &MockTaskRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, batch, taskIDs)}
+}
+
+func (_c *MockTaskRepository_CreateBatch_Call) Run(run func(ctx context.Context, batch *domain.TaskBatch, taskIDs []uuid.UUID)) *MockTaskRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.TaskBatch), args[2].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_CreateBatch_Call) Return(_a0 error) *MockTaskRepository_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaskRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, *domain.TaskBatch, []uuid.UUID) error) *MockTaskRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveTaskByDomain provides a mock function with given fields: ctx, registeredDomain
+func (_m *MockTaskRepository) GetActiveTaskByDomain(ctx context.Context, registeredDomain string) (*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, registeredDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveTaskByDomain")
+	}
+
+	var r0 *domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.AnalysisTask, error)); ok {
+		return rf(ctx, registeredDomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.AnalysisTask); ok {
+		r0 = rf(ctx, registeredDomain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, registeredDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetActiveTaskByDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTaskByDomain'
+type MockTaskRepository_GetActiveTaskByDomain_Call struct {
+	*mock.Call
+}
+
+// GetActiveTaskByDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registeredDomain string
+func (_e *MockTaskRepository_Expecter) GetActiveTaskByDomain(ctx interface{}, registeredDomain interface{}) *MockTaskRepository_GetActiveTaskByDomain_Call {
+	return &MockTaskRepository_GetActiveTaskByDomain_Call{Call: _e.mock.On("GetActiveTaskByDomain", ctx, registeredDomain)}
+}
+
+func (_c *MockTaskRepository_GetActiveTaskByDomain_Call) Run(run func(ctx context.Context, registeredDomain string)) *MockTaskRepository_GetActiveTaskByDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetActiveTaskByDomain_Call) Return(_a0 *domain.AnalysisTask, _a1 error) *MockTaskRepository_GetActiveTaskByDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetActiveTaskByDomain_Call) RunAndReturn(run func(context.Context, string) (*domain.AnalysisTask, error)) *MockTaskRepository_GetActiveTaskByDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveTaskByURL provides a mock function with given fields: ctx, canonicalURL
+func (_m *MockTaskRepository) GetActiveTaskByURL(ctx context.Context, canonicalURL string) (*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, canonicalURL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActiveTaskByURL")
@@ -82,10 +307,10 @@ func (_m *MockTaskRepository) GetActiveTaskByURL(ctx context.Context, url string
 	var r0 *domain.AnalysisTask
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.AnalysisTask, error)); ok {
-		return rf(ctx, url)
+		return rf(ctx, canonicalURL)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.AnalysisTask); ok {
-		r0 = rf(ctx, url)
+		r0 = rf(ctx, canonicalURL)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.AnalysisTask)
@@ -93,7 +318,7 @@ func (_m *MockTaskRepository) GetActiveTaskByURL(ctx context.Context, url string
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, url)
+		r1 = rf(ctx, canonicalURL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,12 +333,12 @@ type MockTaskRepository_GetActiveTaskByURL_Call struct {
 
 // GetActiveTaskByURL is a helper method to define mock.On call
 //   - ctx context.Context
-//   - url string
-func (_e *MockTaskRepository_Expecter) GetActiveTaskByURL(ctx interface{}, url interface{}) *MockTaskRepository_GetActiveTaskByURL_Call {
-	return &MockTaskRepository_GetActiveTaskByURL_Call{Call: _e.mock.On("GetActiveTaskByURL", ctx, url)}
+//   - canonicalURL string
+func (_e *MockTaskRepository_Expecter) GetActiveTaskByURL(ctx interface{}, canonicalURL interface{}) *MockTaskRepository_GetActiveTaskByURL_Call {
+	return &MockTaskRepository_GetActiveTaskByURL_Call{Call: _e.mock.On("GetActiveTaskByURL", ctx, canonicalURL)}
 }
 
-func (_c *MockTaskRepository_GetActiveTaskByURL_Call) Run(run func(ctx context.Context, url string)) *MockTaskRepository_GetActiveTaskByURL_Call {
+func (_c *MockTaskRepository_GetActiveTaskByURL_Call) Run(run func(ctx context.Context, canonicalURL string)) *MockTaskRepository_GetActiveTaskByURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
@@ -130,6 +355,65 @@ func (_c *MockTaskRepository_GetActiveTaskByURL_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// GetBatchProgress provides a mock function with given fields: ctx, batchID
+func (_m *MockTaskRepository) GetBatchProgress(ctx context.Context, batchID uuid.UUID) (*domain.BatchProgress, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchProgress")
+	}
+
+	var r0 *domain.BatchProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.BatchProgress, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.BatchProgress); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BatchProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetBatchProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchProgress'
+type MockTaskRepository_GetBatchProgress_Call struct {
+	*mock.Call
+}
+
+// GetBatchProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID uuid.UUID
+func (_e *MockTaskRepository_Expecter) GetBatchProgress(ctx interface{}, batchID interface{}) *MockTaskRepository_GetBatchProgress_Call {
+	return &MockTaskRepository_GetBatchProgress_Call{Call: _e.mock.On("GetBatchProgress", ctx, batchID)}
+}
+
+func (_c *MockTaskRepository_GetBatchProgress_Call) Run(run func(ctx context.Context, batchID uuid.UUID)) *MockTaskRepository_GetBatchProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetBatchProgress_Call) Return(_a0 *domain.BatchProgress, _a1 error) *MockTaskRepository_GetBatchProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetBatchProgress_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.BatchProgress, error)) *MockTaskRepository_GetBatchProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *MockTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.AnalysisTask, error) {
 	ret := _m.Called(ctx, id)
@@ -189,9 +473,9 @@ func (_c *MockTaskRepository_GetByID_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// GetFailedTasks provides a mock function with given fields: ctx
-func (_m *MockTaskRepository) GetFailedTasks(ctx context.Context) ([]*domain.AnalysisTask, error) {
-	ret := _m.Called(ctx)
+// GetFailedTasks provides a mock function with given fields: ctx, now
+func (_m *MockTaskRepository) GetFailedTasks(ctx context.Context, now time.Time) ([]*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, now)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFailedTasks")
@@ -199,19 +483,19 @@ func (_m *MockTaskRepository) GetFailedTasks(ctx context.Context) ([]*domain.Ana
 
 	var r0 []*domain.AnalysisTask
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.AnalysisTask, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*domain.AnalysisTask, error)); ok {
+		return rf(ctx, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*domain.AnalysisTask); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*domain.AnalysisTask); ok {
+		r0 = rf(ctx, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.AnalysisTask)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -226,13 +510,14 @@ type MockTaskRepository_GetFailedTasks_Call struct {
 
 // GetFailedTasks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTaskRepository_Expecter) GetFailedTasks(ctx interface{}) *MockTaskRepository_GetFailedTasks_Call {
-	return &MockTaskRepository_GetFailedTasks_Call{Call: _e.mock.On("GetFailedTasks", ctx)}
+//   - now time.Time
+func (_e *MockTaskRepository_Expecter) GetFailedTasks(ctx interface{}, now interface{}) *MockTaskRepository_GetFailedTasks_Call {
+	return &MockTaskRepository_GetFailedTasks_Call{Call: _e.mock.On("GetFailedTasks", ctx, now)}
 }
 
-func (_c *MockTaskRepository_GetFailedTasks_Call) Run(run func(ctx context.Context)) *MockTaskRepository_GetFailedTasks_Call {
+func (_c *MockTaskRepository_GetFailedTasks_Call) Run(run func(ctx context.Context, now time.Time)) *MockTaskRepository_GetFailedTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
@@ -242,7 +527,186 @@ func (_c *MockTaskRepository_GetFailedTasks_Call) Return(_a0 []*domain.AnalysisT
 	return _c
 }
 
-func (_c *MockTaskRepository_GetFailedTasks_Call) RunAndReturn(run func(context.Context) ([]*domain.AnalysisTask, error)) *MockTaskRepository_GetFailedTasks_Call {
+func (_c *MockTaskRepository_GetFailedTasks_Call) RunAndReturn(run func(context.Context, time.Time) ([]*domain.AnalysisTask, error)) *MockTaskRepository_GetFailedTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastEvent provides a mock function with given fields: ctx, taskID
+func (_m *MockTaskRepository) GetLastEvent(ctx context.Context, taskID uuid.UUID) (*domain.TaskEvent, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastEvent")
+	}
+
+	var r0 *domain.TaskEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.TaskEvent, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.TaskEvent); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetLastEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastEvent'
+type MockTaskRepository_GetLastEvent_Call struct {
+	*mock.Call
+}
+
+// GetLastEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+func (_e *MockTaskRepository_Expecter) GetLastEvent(ctx interface{}, taskID interface{}) *MockTaskRepository_GetLastEvent_Call {
+	return &MockTaskRepository_GetLastEvent_Call{Call: _e.mock.On("GetLastEvent", ctx, taskID)}
+}
+
+func (_c *MockTaskRepository_GetLastEvent_Call) Run(run func(ctx context.Context, taskID uuid.UUID)) *MockTaskRepository_GetLastEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetLastEvent_Call) Return(_a0 *domain.TaskEvent, _a1 error) *MockTaskRepository_GetLastEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetLastEvent_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.TaskEvent, error)) *MockTaskRepository_GetLastEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOverdueTasks provides a mock function with given fields: ctx, dispatchedBefore, startedBefore
+func (_m *MockTaskRepository) GetOverdueTasks(ctx context.Context, dispatchedBefore time.Time, startedBefore time.Time) ([]*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, dispatchedBefore, startedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueTasks")
+	}
+
+	var r0 []*domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*domain.AnalysisTask, error)); ok {
+		return rf(ctx, dispatchedBefore, startedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*domain.AnalysisTask); ok {
+		r0 = rf(ctx, dispatchedBefore, startedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, dispatchedBefore, startedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetOverdueTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverdueTasks'
+type MockTaskRepository_GetOverdueTasks_Call struct {
+	*mock.Call
+}
+
+// GetOverdueTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dispatchedBefore time.Time
+//   - startedBefore time.Time
+func (_e *MockTaskRepository_Expecter) GetOverdueTasks(ctx interface{}, dispatchedBefore interface{}, startedBefore interface{}) *MockTaskRepository_GetOverdueTasks_Call {
+	return &MockTaskRepository_GetOverdueTasks_Call{Call: _e.mock.On("GetOverdueTasks", ctx, dispatchedBefore, startedBefore)}
+}
+
+func (_c *MockTaskRepository_GetOverdueTasks_Call) Run(run func(ctx context.Context, dispatchedBefore time.Time, startedBefore time.Time)) *MockTaskRepository_GetOverdueTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetOverdueTasks_Call) Return(_a0 []*domain.AnalysisTask, _a1 error) *MockTaskRepository_GetOverdueTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetOverdueTasks_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]*domain.AnalysisTask, error)) *MockTaskRepository_GetOverdueTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOwnerActiveTaskByURL provides a mock function with given fields: ctx, ownerUID, canonicalURL
+func (_m *MockTaskRepository) GetOwnerActiveTaskByURL(ctx context.Context, ownerUID string, canonicalURL string) (*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, ownerUID, canonicalURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOwnerActiveTaskByURL")
+	}
+
+	var r0 *domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.AnalysisTask, error)); ok {
+		return rf(ctx, ownerUID, canonicalURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.AnalysisTask); ok {
+		r0 = rf(ctx, ownerUID, canonicalURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ownerUID, canonicalURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetOwnerActiveTaskByURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOwnerActiveTaskByURL'
+type MockTaskRepository_GetOwnerActiveTaskByURL_Call struct {
+	*mock.Call
+}
+
+// GetOwnerActiveTaskByURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerUID string
+//   - canonicalURL string
+func (_e *MockTaskRepository_Expecter) GetOwnerActiveTaskByURL(ctx interface{}, ownerUID interface{}, canonicalURL interface{}) *MockTaskRepository_GetOwnerActiveTaskByURL_Call {
+	return &MockTaskRepository_GetOwnerActiveTaskByURL_Call{Call: _e.mock.On("GetOwnerActiveTaskByURL", ctx, ownerUID, canonicalURL)}
+}
+
+func (_c *MockTaskRepository_GetOwnerActiveTaskByURL_Call) Run(run func(ctx context.Context, ownerUID string, canonicalURL string)) *MockTaskRepository_GetOwnerActiveTaskByURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetOwnerActiveTaskByURL_Call) Return(_a0 *domain.AnalysisTask, _a1 error) *MockTaskRepository_GetOwnerActiveTaskByURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetOwnerActiveTaskByURL_Call) RunAndReturn(run func(context.Context, string, string) (*domain.AnalysisTask, error)) *MockTaskRepository_GetOwnerActiveTaskByURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -305,6 +769,67 @@ func (_c *MockTaskRepository_GetPendingTasks_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetRecentCompletedTask provides a mock function with given fields: ctx, canonicalURL, registeredDomain, completedAfter
+func (_m *MockTaskRepository) GetRecentCompletedTask(ctx context.Context, canonicalURL string, registeredDomain string, completedAfter time.Time) (*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, canonicalURL, registeredDomain, completedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentCompletedTask")
+	}
+
+	var r0 *domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*domain.AnalysisTask, error)); ok {
+		return rf(ctx, canonicalURL, registeredDomain, completedAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *domain.AnalysisTask); ok {
+		r0 = rf(ctx, canonicalURL, registeredDomain, completedAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, canonicalURL, registeredDomain, completedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_GetRecentCompletedTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentCompletedTask'
+type MockTaskRepository_GetRecentCompletedTask_Call struct {
+	*mock.Call
+}
+
+// GetRecentCompletedTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - canonicalURL string
+//   - registeredDomain string
+//   - completedAfter time.Time
+func (_e *MockTaskRepository_Expecter) GetRecentCompletedTask(ctx interface{}, canonicalURL interface{}, registeredDomain interface{}, completedAfter interface{}) *MockTaskRepository_GetRecentCompletedTask_Call {
+	return &MockTaskRepository_GetRecentCompletedTask_Call{Call: _e.mock.On("GetRecentCompletedTask", ctx, canonicalURL, registeredDomain, completedAfter)}
+}
+
+func (_c *MockTaskRepository_GetRecentCompletedTask_Call) Run(run func(ctx context.Context, canonicalURL string, registeredDomain string, completedAfter time.Time)) *MockTaskRepository_GetRecentCompletedTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_GetRecentCompletedTask_Call) Return(_a0 *domain.AnalysisTask, _a1 error) *MockTaskRepository_GetRecentCompletedTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_GetRecentCompletedTask_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*domain.AnalysisTask, error)) *MockTaskRepository_GetRecentCompletedTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRunningTasks provides a mock function with given fields: ctx
 func (_m *MockTaskRepository) GetRunningTasks(ctx context.Context) ([]*domain.AnalysisTask, error) {
 	ret := _m.Called(ctx)
@@ -363,6 +888,173 @@ func (_c *MockTaskRepository_GetRunningTasks_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// HandOverSharedTask provides a mock function with given fields: ctx, leader
+func (_m *MockTaskRepository) HandOverSharedTask(ctx context.Context, leader *domain.AnalysisTask) (*domain.AnalysisTask, error) {
+	ret := _m.Called(ctx, leader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandOverSharedTask")
+	}
+
+	var r0 *domain.AnalysisTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask) (*domain.AnalysisTask, error)); ok {
+		return rf(ctx, leader)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask) *domain.AnalysisTask); ok {
+		r0 = rf(ctx, leader)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AnalysisTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AnalysisTask) error); ok {
+		r1 = rf(ctx, leader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_HandOverSharedTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandOverSharedTask'
+type MockTaskRepository_HandOverSharedTask_Call struct {
+	*mock.Call
+}
+
+// HandOverSharedTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - leader *domain.AnalysisTask
+func (_e *MockTaskRepository_Expecter) HandOverSharedTask(ctx interface{}, leader interface{}) *MockTaskRepository_HandOverSharedTask_Call {
+	return &MockTaskRepository_HandOverSharedTask_Call{Call: _e.mock.On("HandOverSharedTask", ctx, leader)}
+}
+
+func (_c *MockTaskRepository_HandOverSharedTask_Call) Run(run func(ctx context.Context, leader *domain.AnalysisTask)) *MockTaskRepository_HandOverSharedTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AnalysisTask))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_HandOverSharedTask_Call) Return(_a0 *domain.AnalysisTask, _a1 error) *MockTaskRepository_HandOverSharedTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_HandOverSharedTask_Call) RunAndReturn(run func(context.Context, *domain.AnalysisTask) (*domain.AnalysisTask, error)) *MockTaskRepository_HandOverSharedTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter, page
+func (_m *MockTaskRepository) List(ctx context.Context, filter domain.TaskFilter, page domain.Page) (*domain.TaskPage, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskFilter, domain.Page) (*domain.TaskPage, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskFilter, domain.Page) *domain.TaskPage); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskFilter, domain.Page) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaskRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTaskRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.TaskFilter
+//   - page domain.Page
+func (_e *MockTaskRepository_Expecter) List(ctx interface{}, filter interface{}, page interface{}) *MockTaskRepository_List_Call {
+	return &MockTaskRepository_List_Call{Call: _e.mock.On("List", ctx, filter, page)}
+}
+
+func (_c *MockTaskRepository_List_Call) Run(run func(ctx context.Context, filter domain.TaskFilter, page domain.Page)) *MockTaskRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TaskFilter), args[2].(domain.Page))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_List_Call) Return(_a0 *domain.TaskPage, _a1 error) *MockTaskRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaskRepository_List_Call) RunAndReturn(run func(context.Context, domain.TaskFilter, domain.Page) (*domain.TaskPage, error)) *MockTaskRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTransition provides a mock function with given fields: ctx, task, event
+func (_m *MockTaskRepository) SaveTransition(ctx context.Context, task *domain.AnalysisTask, event *domain.TaskEvent) error {
+	ret := _m.Called(ctx, task, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTransition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AnalysisTask, *domain.TaskEvent) error); ok {
+		r0 = rf(ctx, task, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaskRepository_SaveTransition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTransition'
+type MockTaskRepository_SaveTransition_Call struct {
+	*mock.Call
+}
+
+// SaveTransition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *domain.AnalysisTask
+//   - event *domain.TaskEvent
+func (_e *MockTaskRepository_Expecter) SaveTransition(ctx interface{}, task interface{}, event interface{}) *MockTaskRepository_SaveTransition_Call {
+	return &MockTaskRepository_SaveTransition_Call{Call: _e.mock.On("SaveTransition", ctx, task, event)}
+}
+
+func (_c *MockTaskRepository_SaveTransition_Call) Run(run func(ctx context.Context, task *domain.AnalysisTask, event *domain.TaskEvent)) *MockTaskRepository_SaveTransition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AnalysisTask), args[2].(*domain.TaskEvent))
+	})
+	return _c
+}
+
+func (_c *MockTaskRepository_SaveTransition_Call) Return(_a0 error) *MockTaskRepository_SaveTransition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaskRepository_SaveTransition_Call) RunAndReturn(run func(context.Context, *domain.AnalysisTask, *domain.TaskEvent) error) *MockTaskRepository_SaveTransition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, task
 func (_m *MockTaskRepository) Update(ctx context.Context, task *domain.AnalysisTask) error {
 	ret := _m.Called(ctx, task)
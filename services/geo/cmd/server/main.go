@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/SKD-fastcampus/bot-management/pkg/metrics"
 	pb "github.com/SKD-fastcampus/bot-management/proto/geo/v1"
 	grpcHandler "github.com/SKD-fastcampus/bot-management/services/geo/internal/adapter/handler/grpc"
 	httpHandler "github.com/SKD-fastcampus/bot-management/services/geo/internal/adapter/handler/http"
@@ -73,10 +74,20 @@ func main() {
 		grpcPort = parseInt(cfg.Server.GRPC.Port, 9090)
 	}
 
+	// 관리용(메트릭) 서버 포트 설정
+	adminPort := 9100
+	if cfg.Server.Admin.Port != "" {
+		adminPort = parseInt(cfg.Server.Admin.Port, 9100)
+	}
+
+	// HTTP/gRPC 서버가 공유하는 메트릭 레지스트리
+	registry := metrics.NewRegistry()
+
 	// 10. HTTP 서버 초기화 및 시작
 	httpSrv := httpServer.NewServer(
 		httpServer.WithPort(httpPort),
 		httpServer.WithLogger(log),
+		httpServer.WithMetrics(registry),
 	)
 
 	// 라우트 등록
@@ -93,6 +104,7 @@ func main() {
 	grpcSrv := grpcServer.NewServer(
 		grpcServer.WithPort(grpcPort),
 		grpcServer.WithLogger(log),
+		grpcServer.WithMetrics(registry),
 	)
 
 	// gRPC 서비스 등록
@@ -107,9 +119,18 @@ func main() {
 		}
 	}()
 
+	// 메트릭 서버 시작 (서비스 포트와 분리된 관리용 포트)
+	adminSrv := metrics.NewAdminServer(adminPort, registry, log)
+	go func() {
+		if err := adminSrv.Start(); err != nil {
+			log.Error("메트릭 서버 에러", zap.Error(err))
+		}
+	}()
+
 	log.Info("서버 실행 중...",
 		zap.Int("http_port", httpPort),
 		zap.Int("grpc_port", grpcPort),
+		zap.Int("admin_port", adminPort),
 	)
 
 	// 12. 종료 시그널 처리
@@ -133,6 +154,11 @@ func main() {
 		log.Error("gRPC 서버 종료 실패", zap.Error(err))
 	}
 
+	// 16. 메트릭 서버 종료
+	if err := adminSrv.Shutdown(ctx); err != nil {
+		log.Error("메트릭 서버 종료 실패", zap.Error(err))
+	}
+
 	log.Info("서버 정상 종료")
 }
 
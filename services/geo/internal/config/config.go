@@ -47,6 +47,9 @@ func Load() (*Config, error) {
 	appConfig.Server.GRPC.Port = cfg.GetString("server.grpc.port")
 	appConfig.Server.GRPC.Timeout = cfg.GetInt("server.grpc.timeout")
 
+	// 관리용 서버 설정
+	appConfig.Server.Admin.Port = cfg.GetString("server.admin.port")
+
 	// GeoLite 설정
 	appConfig.GeoLite.DbPath = cfg.GetString("geolite.db_path")
 
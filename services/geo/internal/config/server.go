@@ -13,4 +13,9 @@ type Server struct {
 		Port    string `yaml:"port"`
 		Timeout int    `yaml:"timeout"`
 	} `yaml:"grpc"`
+
+	// 관리용 서버 설정 (Prometheus 메트릭)
+	Admin struct {
+		Port string `yaml:"port"`
+	} `yaml:"admin"`
 }
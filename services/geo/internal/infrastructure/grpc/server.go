@@ -6,6 +6,8 @@ import (
 	"net"
 
 	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	"github.com/SKD-fastcampus/bot-management/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -19,6 +21,7 @@ type Server struct {
 	logger     *zap.Logger
 	port       int
 	listener   net.Listener
+	metrics    prometheus.Registerer
 }
 
 // ServerOption Server 생성을 위한 옵션 함수 타입입니다.
@@ -38,6 +41,13 @@ func WithLogger(logger *zap.Logger) ServerOption {
 	}
 }
 
+// WithMetrics 요청 메트릭을 등록할 레지스트리를 설정하는 옵션입니다.
+func WithMetrics(reg prometheus.Registerer) ServerOption {
+	return func(s *Server) {
+		s.metrics = reg
+	}
+}
+
 // NewServer gRPC 서버를 생성합니다.
 func NewServer(opts ...ServerOption) *Server {
 	// 기본 서버 설정
@@ -52,13 +62,18 @@ func NewServer(opts ...ServerOption) *Server {
 	}
 
 	// gRPC 인터셉터 설정
-	unaryInterceptor := logger.NewGrpcUnaryServerInterceptor(s.logger)
-	streamInterceptor := logger.NewGrpcStreamServerInterceptor(s.logger)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{logger.NewGrpcUnaryServerInterceptor(s.logger)}
+	streamInterceptors := []grpc.StreamServerInterceptor{logger.NewGrpcStreamServerInterceptor(s.logger)}
+	if s.metrics != nil {
+		unary, stream := metrics.NewGrpcServerInterceptors(s.metrics)
+		unaryInterceptors = append(unaryInterceptors, unary)
+		streamInterceptors = append(streamInterceptors, stream)
+	}
 
 	// gRPC 서버 생성
 	s.grpcServer = grpc.NewServer(
-		grpc.UnaryInterceptor(unaryInterceptor),
-		grpc.StreamInterceptor(streamInterceptor),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
 
 	// 헬스 체크 서비스 등록
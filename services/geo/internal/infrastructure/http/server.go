@@ -8,14 +8,17 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/SKD-fastcampus/bot-management/pkg/logger"
+	"github.com/SKD-fastcampus/bot-management/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
 // Server HTTP 서버 구조체입니다.
 type Server struct {
-	echo   *echo.Echo
-	logger *zap.Logger
-	port   int
+	echo    *echo.Echo
+	logger  *zap.Logger
+	port    int
+	metrics prometheus.Registerer
 }
 
 // ServerOption Server 생성을 위한 옵션 함수 타입입니다.
@@ -35,6 +38,13 @@ func WithLogger(logger *zap.Logger) ServerOption {
 	}
 }
 
+// WithMetrics 요청 메트릭을 등록할 레지스트리를 설정하는 옵션입니다.
+func WithMetrics(reg prometheus.Registerer) ServerOption {
+	return func(s *Server) {
+		s.metrics = reg
+	}
+}
+
 // NewServer HTTP 서버를 생성합니다.
 func NewServer(opts ...ServerOption) *Server {
 	// 기본 서버 설정
@@ -59,6 +69,9 @@ func NewServer(opts ...ServerOption) *Server {
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
 	e.Use(logger.NewEchoRequestLogger(s.logger))
+	if s.metrics != nil {
+		e.Use(metrics.NewEchoMiddleware(s.metrics))
+	}
 
 	// 기본 라우트 설정
 	e.GET("/health", func(c echo.Context) error {
@@ -67,11 +80,6 @@ func NewServer(opts ...ServerOption) *Server {
 		})
 	})
 
-	// 메트릭 엔드포인트
-	e.GET("/metrics", func(c echo.Context) error {
-		return c.NoContent(http.StatusOK)
-	})
-
 	return s
 }
 